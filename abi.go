@@ -0,0 +1,345 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// abi describes how the kernel lays out the structures exchanged
+// with a process. The layouts depend on the byte order and on the
+// size of a C long, which varies between architectures:
+//
+//   - struct input_event starts with two longs holding the timestamp.
+//     On 32-bit architectures these are 32 bits wide, even when the C
+//     library uses a 64-bit time_t.
+//   - Capability bitmaps are arrays of longs. On big-endian 32-bit
+//     architectures, these do not share the layout of a []Word.
+//   - struct ff_effect holds a pointer, so its size and padding
+//     differ between 32-bit and 64-bit architectures.
+type abi struct {
+	order binary.ByteOrder
+	long  int // Size of a C long, in bytes.
+}
+
+// abis holds the layouts of the supported architectures, by GOARCH.
+var abis = map[string]abi{
+	"386":      {binary.LittleEndian, 4},
+	"amd64":    {binary.LittleEndian, 8},
+	"arm":      {binary.LittleEndian, 4},
+	"arm64":    {binary.LittleEndian, 8},
+	"loong64":  {binary.LittleEndian, 8},
+	"mips":     {binary.BigEndian, 4},
+	"mipsle":   {binary.LittleEndian, 4},
+	"mips64":   {binary.BigEndian, 8},
+	"mips64le": {binary.LittleEndian, 8},
+	"ppc64":    {binary.BigEndian, 8},
+	"ppc64le":  {binary.LittleEndian, 8},
+	"riscv64":  {binary.LittleEndian, 8},
+	"s390x":    {binary.BigEndian, 8},
+}
+
+// nativeABI is the layout used by the kernel for this process.
+var nativeABI = abi{binary.NativeEndian, int(unsafe.Sizeof(uintptr(0)))}
+
+// textABI is the layout used to parse the hexadecimal bitmaps in
+// sysfs and /proc/bus/input/devices. The kernel formats these in
+// longs of the reading process, so this equals nativeABI.
+var textABI = nativeABI
+
+// nativeEvents is true if Event shares the layout of the kernel's
+// struct input_event, so events can be read and written in place.
+var nativeEvents = unsafe.Sizeof(Event{}) == uintptr(nativeABI.eventSize()) &&
+	unsafe.Offsetof(Event{}.Type) == uintptr(2*nativeABI.long)
+
+// putLong stores v as a C long.
+func (a abi) putLong(b []byte, v uint64) {
+	if a.long == 4 {
+		a.order.PutUint32(b, uint32(v))
+	} else {
+		a.order.PutUint64(b, v)
+	}
+}
+
+// getLong reads an unsigned C long.
+func (a abi) getLong(b []byte) uint64 {
+	if a.long == 4 {
+		return uint64(a.order.Uint32(b))
+	}
+	return a.order.Uint64(b)
+}
+
+// eventSize returns the size of struct input_event.
+func (a abi) eventSize() int {
+	return 2*a.long + 8
+}
+
+// putEvent encodes e as a struct input_event.
+func (a abi) putEvent(b []byte, e Event) {
+	sec, nsec := e.Time.Unix()
+	a.putLong(b, uint64(sec))
+	a.putLong(b[a.long:], uint64(nsec/1e3))
+
+	b = b[2*a.long:]
+	a.order.PutUint16(b, e.Type)
+	a.order.PutUint16(b[2:], e.Code)
+	a.order.PutUint32(b[4:], uint32(e.Value))
+}
+
+// event decodes a struct input_event.
+func (a abi) event(b []byte) Event {
+	sec := int64(a.getLong(b))
+	usec := int64(a.getLong(b[a.long:]))
+
+	b = b[2*a.long:]
+	return Event{
+		Time:  syscall.NsecToTimeval(sec*1e9 + usec*1e3),
+		Type:  a.order.Uint16(b),
+		Code:  a.order.Uint16(b[2:]),
+		Value: int32(a.order.Uint32(b[4:])),
+	}
+}
+
+// encodeEvents returns the given events as a series of
+// struct input_event. With the native layout, this is
+// the memory of the events themselves.
+func (a abi) encodeEvents(events []Event) []byte {
+	if a == nativeABI && nativeEvents {
+		return eventBytes(events)
+	}
+
+	size := a.eventSize()
+	b := make([]byte, len(events)*size)
+	for i, e := range events {
+		a.putEvent(b[i*size:], e)
+	}
+	return b
+}
+
+// eventBuffer returns a buffer to read struct input_event
+// values into, for decoding into the given events with
+// decodeEvents. With the native layout, this is the memory
+// of the events themselves.
+func (a abi) eventBuffer(events []Event) []byte {
+	if a == nativeABI && nativeEvents {
+		return eventBytes(events)
+	}
+	return make([]byte, len(events)*a.eventSize())
+}
+
+// decodeEvents decodes the struct input_event values in b, which
+// was returned by eventBuffer, into events. It returns the number
+// of events decoded.
+func (a abi) decodeEvents(events []Event, b []byte) int {
+	size := a.eventSize()
+	n := len(b) / size
+
+	if a == nativeABI && nativeEvents {
+		return n
+	}
+
+	for i := 0; i < n; i++ {
+		events[i] = a.event(b[i*size:])
+	}
+	return n
+}
+
+// bitmapSize returns the size, in bytes, of a kernel
+// bitmap holding the given number of bits.
+func (a abi) bitmapSize(bits int) int {
+	w := a.long * 8
+	return (bits + w - 1) / w * a.long
+}
+
+// putBitset encodes bs as a kernel bitmap of len(b) bytes.
+func (a abi) putBitset(b []byte, bs Bitset) {
+	for i := 0; i < len(b)/a.long; i++ {
+		var v uint64
+		if a.long == 4 {
+			if i/2 < len(bs.words) {
+				v = uint64(bs.words[i/2]>>(32*uint(i%2))) & 0xffffffff
+			}
+		} else if i < len(bs.words) {
+			v = uint64(bs.words[i])
+		}
+		a.putLong(b[i*a.long:], v)
+	}
+}
+
+// bitset decodes the kernel bitmap in b into bs.
+// Bits which do not fit in bs are ignored.
+func (a abi) bitset(bs Bitset, b []byte) {
+	for i := 0; i < len(b)/a.long; i++ {
+		v := a.getLong(b[i*a.long:])
+		if a.long == 4 {
+			if i/2 < len(bs.words) {
+				shift := 32 * uint(i%2)
+				bs.words[i/2] = bs.words[i/2]&^(0xffffffff<<shift) | Word(v)<<shift
+			}
+		} else if i < len(bs.words) {
+			bs.words[i] = Word(v)
+		}
+	}
+
+	// Clear the bits beyond the length of the set.
+	if r := bs.n % WordBitSize; r != 0 {
+		bs.words[len(bs.words)-1] &= 1<<uint(r) - 1
+	}
+}
+
+// parseHexBitset reads a bitmap in the format used by sysfs and
+// /proc/bus/input/devices into the given bitset. This is a list of
+// space separated, hexadecimal longs; the most significant one first.
+func (a abi) parseHexBitset(b Bitset, s string) error {
+	fields := strings.Fields(s)
+	bits := a.long * 8
+
+	for i := range fields {
+		w, err := strconv.ParseUint(fields[len(fields)-1-i], 16, bits)
+		if err != nil {
+			return err
+		}
+
+		for n := 0; w != 0; n++ {
+			if w&1 == 1 {
+				b.Set(i*bits + n)
+			}
+			w >>= 1
+		}
+	}
+
+	return nil
+}
+
+// formatHexBitset formats b as parsed by parseHexBitset. Leading
+// longs of zero are left out, as done by the kernel.
+func (a abi) formatHexBitset(b Bitset) string {
+	buf := make([]byte, a.bitmapSize(b.n))
+	a.putBitset(buf, b)
+
+	var words []string
+	for i := len(buf)/a.long - 1; i >= 0; i-- {
+		v := a.getLong(buf[i*a.long:])
+		if v == 0 && len(words) == 0 {
+			continue
+		}
+		words = append(words, strconv.FormatUint(v, 16))
+	}
+
+	if len(words) == 0 {
+		return "0"
+	}
+	return strings.Join(words, " ")
+}
+
+// absInfoSize is the size of struct input_absinfo.
+const absInfoSize = 24
+
+// putAbsInfo encodes info as a struct input_absinfo.
+func (a abi) putAbsInfo(b []byte, info AbsInfo) {
+	v := [...]int32{info.Value, info.Minimum, info.Maximum, info.Fuzz, info.Flat, info.Resolution}
+	for i, n := range v {
+		a.order.PutUint32(b[i*4:], uint32(n))
+	}
+}
+
+// absInfo decodes a struct input_absinfo.
+func (a abi) absInfo(b []byte) AbsInfo {
+	v := func(i int) int32 { return int32(a.order.Uint32(b[i*4:])) }
+	return AbsInfo{
+		Value:      v(0),
+		Minimum:    v(1),
+		Maximum:    v(2),
+		Fuzz:       v(3),
+		Flat:       v(4),
+		Resolution: v(5),
+	}
+}
+
+// effectUnion is the offset of the effect data in struct ff_effect.
+// It follows 14 bytes of header, aligned to hold a pointer.
+const effectUnion = 16
+
+// effectSize returns the size of struct ff_effect. Its largest union
+// member is struct ff_periodic_effect, which ends in a pointer.
+func (a abi) effectSize() int {
+	return effectUnion + a.periodicCustomData() + a.long
+}
+
+// periodicCustomData returns the offset of the custom_data
+// pointer in struct ff_periodic_effect.
+func (a abi) periodicCustomData() int {
+	return (24 + a.long - 1) / a.long * a.long
+}
+
+// putEffect encodes e as a struct ff_effect. The custom argument
+// is stored as the custom_data pointer of a periodic effect.
+func (a abi) putEffect(b []byte, e *Effect, custom uintptr) {
+	for i := range b[:a.effectSize()] {
+		b[i] = 0
+	}
+
+	u16 := func(off int, v uint16) { a.order.PutUint16(b[off:], v) }
+	envelope := func(off int, v Envelope) {
+		u16(off, v.AttackLength)
+		u16(off+2, v.AttackLevel)
+		u16(off+4, v.FadeLength)
+		u16(off+6, v.FadeLevel)
+	}
+
+	u16(0, e.Type)
+	u16(2, uint16(e.Id))
+	u16(4, e.Direction)
+	u16(6, e.Trigger.Button)
+	u16(8, e.Trigger.Interval)
+	u16(10, e.Replay.Length)
+	u16(12, e.Replay.Delay)
+
+	const u = effectUnion
+
+	switch v := e.data.(type) {
+	case ConstantEffect:
+		u16(u, uint16(v.Level))
+		envelope(u+2, v.Envelope)
+
+	case RampEffect:
+		u16(u, uint16(v.StartLevel))
+		u16(u+2, uint16(v.EndLevel))
+		envelope(u+4, v.Envelope)
+
+	case PeriodicEffect:
+		u16(u, v.Waveform)
+		u16(u+2, v.Period)
+		u16(u+4, uint16(v.Magnitude))
+		u16(u+6, uint16(v.Offset))
+		u16(u+8, v.Phase)
+		envelope(u+10, v.Envelope)
+		a.order.PutUint32(b[u+20:], uint32(len(v.custom)))
+		a.putLong(b[u+a.periodicCustomData():], uint64(custom))
+
+	case [2]ConditionEffect:
+		for i, c := range v {
+			off := u + i*12
+			u16(off, c.RightSaturation)
+			u16(off+2, c.LeftSaturation)
+			u16(off+4, uint16(c.RightCoeff))
+			u16(off+6, uint16(c.LeftCoeff))
+			u16(off+8, c.Deadband)
+			u16(off+10, uint16(c.Center))
+		}
+
+	case RumbleEffect:
+		u16(u, v.StrongMagnitude)
+		u16(u+2, v.WeakMagnitude)
+	}
+}
+
+// effectId returns the effect id stored in a struct ff_effect.
+func (a abi) effectId(b []byte) int16 {
+	return int16(a.order.Uint16(b[2:]))
+}
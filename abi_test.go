@@ -0,0 +1,251 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// pinTextABI parses sysfs and procfs bitmaps with the layout of the
+// given architecture for the duration of the test. The fixtures are
+// written with 64-bit words, which would not parse on 32-bit hosts.
+func pinTextABI(t *testing.T, arch string) {
+	old := textABI
+	textABI = abis[arch]
+	t.Cleanup(func() { textABI = old })
+}
+
+// golden decodes a hex dump, ignoring spaces.
+func golden(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// testArchs lists the architectures with golden layouts.
+var testArchs = []string{"amd64", "386", "arm", "arm64", "s390x", "mips"}
+
+func TestABIEvent(t *testing.T) {
+	e := Event{
+		Time:  syscall.NsecToTimeval(1e9 + 2e3),
+		Type:  EvKeys,
+		Code:  KeyA,
+		Value: -1,
+	}
+
+	want := map[string]string{
+		"amd64": "01000000 00000000 02000000 00000000 0100 1e00 ffffffff",
+		"386":   "01000000 02000000 0100 1e00 ffffffff",
+		"arm":   "01000000 02000000 0100 1e00 ffffffff",
+		"arm64": "01000000 00000000 02000000 00000000 0100 1e00 ffffffff",
+		"s390x": "00000000 00000001 00000000 00000002 0001 001e ffffffff",
+		"mips":  "00000001 00000002 0001 001e ffffffff",
+	}
+
+	for _, arch := range testArchs {
+		a := abis[arch]
+
+		have := a.encodeEvents([]Event{e, e})
+		if w := golden(t, want[arch]+want[arch]); !bytes.Equal(have, w) {
+			t.Fatalf("%s: want %x, have %x", arch, w, have)
+		}
+
+		events := make([]Event, 2)
+		if n := a.decodeEvents(events, have); n != 2 || events[0] != e || events[1] != e {
+			t.Fatalf("%s: want 2 x %+v, have %d x %+v", arch, e, n, events[0])
+		}
+	}
+}
+
+func TestABIBitset(t *testing.T) {
+	bs := NewBitset(96)
+	bs.Set(0)
+	bs.Set(33)
+	bs.Set(65)
+
+	want := map[string]string{
+		"amd64": "01000000 02000000 02000000 00000000",
+		"386":   "01000000 02000000 02000000",
+		"arm":   "01000000 02000000 02000000",
+		"arm64": "01000000 02000000 02000000 00000000",
+		"s390x": "00000002 00000001 00000000 00000002",
+		"mips":  "00000001 00000002 00000002",
+	}
+
+	for _, arch := range testArchs {
+		a := abis[arch]
+
+		have := make([]byte, a.bitmapSize(96))
+		a.putBitset(have, bs)
+		if w := golden(t, want[arch]); !bytes.Equal(have, w) {
+			t.Fatalf("%s: want %x, have %x", arch, w, have)
+		}
+
+		back := NewBitset(96)
+		a.bitset(back, have)
+		for i := 0; i < back.Len(); i++ {
+			if back.Test(i) != bs.Test(i) {
+				t.Fatalf("%s: bit %d: want %v", arch, i, bs.Test(i))
+			}
+		}
+	}
+}
+
+func TestABIAbsInfo(t *testing.T) {
+	info := AbsInfo{Value: 1, Minimum: -2, Maximum: 3, Fuzz: 4, Flat: 5, Resolution: 6}
+
+	le := "01000000 feffffff 03000000 04000000 05000000 06000000"
+	be := "00000001 fffffffe 00000003 00000004 00000005 00000006"
+	want := map[string]string{
+		"amd64": le, "386": le, "arm": le, "arm64": le,
+		"s390x": be, "mips": be,
+	}
+
+	for _, arch := range testArchs {
+		a := abis[arch]
+
+		have := make([]byte, absInfoSize)
+		a.putAbsInfo(have, info)
+		if w := golden(t, want[arch]); !bytes.Equal(have, w) {
+			t.Fatalf("%s: want %x, have %x", arch, w, have)
+		}
+
+		if back := a.absInfo(have); back != info {
+			t.Fatalf("%s: want %+v, have %+v", arch, info, back)
+		}
+	}
+}
+
+func TestABIEffect(t *testing.T) {
+	rumble := Effect{
+		Type:      FFRumble,
+		Id:        -1,
+		Direction: DirLeft,
+		Trigger:   Trigger{Button: 1, Interval: 2},
+		Replay:    Replay{Length: 3, Delay: 4},
+	}
+	rumble.SetData(RumbleEffect{StrongMagnitude: 0x8000, WeakMagnitude: 0x1234})
+
+	var periodic PeriodicEffect
+	periodic.Waveform = FFSine
+	periodic.Period = 5
+	periodic.SetData([]int16{1, 2})
+
+	custom := Effect{Type: FFPeriodic, Id: 7}
+	custom.SetData(periodic)
+
+	tests := []struct {
+		Effect *Effect
+		Want   map[string]string
+	}{
+		{&rumble, map[string]string{
+			"amd64": "5000 ffff 0040 0100 0200 0300 0400 0000 0080 3412" + strings.Repeat("00", 28),
+			"386":   "5000 ffff 0040 0100 0200 0300 0400 0000 0080 3412" + strings.Repeat("00", 24),
+			"arm":   "5000 ffff 0040 0100 0200 0300 0400 0000 0080 3412" + strings.Repeat("00", 24),
+			"arm64": "5000 ffff 0040 0100 0200 0300 0400 0000 0080 3412" + strings.Repeat("00", 28),
+			"s390x": "0050 ffff 4000 0001 0002 0003 0004 0000 8000 1234" + strings.Repeat("00", 28),
+			"mips":  "0050 ffff 4000 0001 0002 0003 0004 0000 8000 1234" + strings.Repeat("00", 24),
+		}},
+		{&custom, map[string]string{
+			"amd64": "5100 0700 0000 0000 0000 0000 0000 0000 5a00 0500" + strings.Repeat("00", 16) + "02000000 4433221100000000",
+			"386":   "5100 0700 0000 0000 0000 0000 0000 0000 5a00 0500" + strings.Repeat("00", 16) + "02000000 44332211",
+			"arm":   "5100 0700 0000 0000 0000 0000 0000 0000 5a00 0500" + strings.Repeat("00", 16) + "02000000 44332211",
+			"arm64": "5100 0700 0000 0000 0000 0000 0000 0000 5a00 0500" + strings.Repeat("00", 16) + "02000000 4433221100000000",
+			"s390x": "0051 0007 0000 0000 0000 0000 0000 0000 005a 0005" + strings.Repeat("00", 16) + "00000002 0000000011223344",
+			"mips":  "0051 0007 0000 0000 0000 0000 0000 0000 005a 0005" + strings.Repeat("00", 16) + "00000002 11223344",
+		}},
+	}
+
+	for _, test := range tests {
+		for _, arch := range testArchs {
+			a := abis[arch]
+
+			have := make([]byte, a.effectSize())
+			a.putEffect(have, test.Effect, 0x11223344)
+			if w := golden(t, test.Want[arch]); !bytes.Equal(have, w) {
+				t.Fatalf("%s: want %x, have %x", arch, w, have)
+			}
+
+			if id := a.effectId(have); id != test.Effect.Id {
+				t.Fatalf("%s: want id %d, have %d", arch, test.Effect.Id, id)
+			}
+		}
+	}
+}
+
+func TestNativeABI(t *testing.T) {
+	if !nativeEvents {
+		t.Fatalf("Want Event to share the layout of struct input_event")
+	}
+
+	a, ok := abis[runtime.GOARCH]
+	if !ok {
+		t.Skipf("No layout known for %s", runtime.GOARCH)
+	}
+
+	if a.long != nativeABI.long {
+		t.Fatalf("Want long of %d bytes, have %d", a.long, nativeABI.long)
+	}
+
+	events := []Event{{Type: EvRelative, Code: RelX, Value: -5}}
+	events[0].Time = syscall.NsecToTimeval(3e9 + 4e3)
+
+	want := make([]byte, a.eventSize())
+	a.putEvent(want, events[0])
+
+	if have := eventBytes(events); !bytes.Equal(have, want) {
+		t.Fatalf("Want native layout %x, have %x", want, have)
+	}
+}
+
+func TestIoctlNumbers(t *testing.T) {
+	type numbers struct {
+		GetAbs, SetFF, DevSetup uintptr
+	}
+
+	generic64 := numbers{0x80184540, 0x40304580, 0x405c5503}
+	generic32 := numbers{0x80184540, 0x402c4580, 0x405c5503}
+	mips32 := numbers{0x40184540, 0x802c4580, 0x805c5503}
+	mips64 := numbers{0x40184540, 0x80304580, 0x805c5503}
+
+	want := map[string]numbers{
+		"386":      generic32,
+		"amd64":    generic64,
+		"arm":      generic32,
+		"arm64":    generic64,
+		"loong64":  generic64,
+		"mips":     mips32,
+		"mipsle":   mips32,
+		"mips64":   mips64,
+		"mips64le": mips64,
+		"ppc64":    mips64,
+		"ppc64le":  mips64,
+		"riscv64":  generic64,
+		"s390x":    generic64,
+	}
+
+	for arch := range abis {
+		if _, ok := want[arch]; !ok {
+			t.Fatalf("No ioctl numbers for %s", arch)
+		}
+	}
+
+	// The encoding is chosen at build time, so only the numbers
+	// of the architecture the test runs on can be checked.
+	w, ok := want[runtime.GOARCH]
+	if !ok {
+		t.Skipf("No ioctl numbers known for %s", runtime.GOARCH)
+	}
+
+	if have := (numbers{_EVIOCGABS(0), _EVIOCSFF, _UI_DEV_SETUP}); have != w {
+		t.Fatalf("Want %#x, have %#x", w, have)
+	}
+}
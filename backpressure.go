@@ -0,0 +1,276 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Backpressure defines what happens to incoming events
+// when the consumer of Device.Inbox can not keep up.
+type Backpressure int
+
+// Known backpressure policies.
+const (
+	// BackpressureBlock waits for the consumer to make room in the inbox.
+	// Meanwhile, events queue up in the kernel until its buffer overflows.
+	// The kernel then discards its queue and reports a SynDropped event.
+	// This is the default.
+	BackpressureBlock Backpressure = iota
+
+	// BackpressureDropOldest discards the oldest event in the inbox
+	// to make room for an incoming one.
+	BackpressureDropOldest
+
+	// BackpressureDropNewest discards incoming events while the inbox is full.
+	BackpressureDropNewest
+
+	// BackpressureCoalesce merges frames which have not yet been delivered.
+	// Relative motion is summed up and only the latest value of an
+	// absolute axis is kept. Frames holding any other kind of event,
+	// as well as multitouch axes, are never merged, so no key presses
+	// or touches are lost. Repeated values within a single frame are
+	// merged the same way. At most InboxSize frames are queued; beyond
+	// that, the oldest frames are dropped, as with BackpressureDropOldest.
+	BackpressureCoalesce
+)
+
+// WithBackpressure sets the policy for handling a full Device.Inbox.
+// It defaults to BackpressureBlock.
+func WithBackpressure(p Backpressure) Option {
+	return func(o *options) {
+		o.backpressure = p
+	}
+}
+
+// Stats holds counters describing the flow of incoming events.
+type Stats struct {
+	Dropped       uint64 // Events discarded by BackpressureDropOldest, BackpressureDropNewest or a full BackpressureCoalesce queue.
+	Coalesced     uint64 // Events merged away by BackpressureCoalesce.
+	KernelDropped uint64 // SynDropped events reported by the kernel.
+}
+
+// stats holds the counters behind Stats.
+type stats struct {
+	dropped       atomic.Uint64
+	coalesced     atomic.Uint64
+	kernelDropped atomic.Uint64
+}
+
+// Stats returns the current event counters for the device.
+func (d *Device) Stats() Stats {
+	return Stats{
+		Dropped:       d.stats.dropped.Load(),
+		Coalesced:     d.stats.coalesced.Load(),
+		KernelDropped: d.stats.kernelDropped.Load(),
+	}
+}
+
+// deliver queues the given event in the inbox,
+// according to the device's backpressure policy.
+// It returns false if the device was closed while
+// waiting for the consumer.
+func (d *Device) deliver(e Event) bool {
+	switch d.backpressure {
+	case BackpressureDropNewest:
+		select {
+		case d.Inbox <- e:
+		default:
+			d.stats.dropped.Add(1)
+		}
+
+	case BackpressureDropOldest:
+		for {
+			select {
+			case d.Inbox <- e:
+				return true
+			default:
+			}
+
+			select {
+			case <-d.Inbox:
+				d.stats.dropped.Add(1)
+			default:
+			}
+		}
+
+	default:
+		select {
+		case d.Inbox <- e:
+		case <-d.done:
+			return false
+		}
+	}
+
+	return true
+}
+
+// coalescer queues frames for delivery to an inbox and merges
+// queued frames while the consumer is not keeping up.
+// The queue is drained by a separate goroutine, so the
+// device can keep reading while the consumer is stalled.
+type coalescer struct {
+	inbox  chan Event
+	done   <-chan struct{} // Closed when the device is closed.
+	wg     *sync.WaitGroup // Tracks the delivery goroutine.
+	mu     sync.Mutex
+	frames [][]Event     // Complete frames, waiting for delivery.
+	limit  int           // Maximum number of queued frames, if positive.
+	frame  []Event       // Incomplete frame, being read.
+	ready  chan struct{} // Signals queued frames or the end of input.
+	eof    bool          // No more frames are queued.
+}
+
+// newCoalescer creates a coalescer for the given inbox
+// and starts its delivery goroutine. It queues as many frames
+// as the inbox holds events, but at least one. The inbox is closed
+// once all frames have been delivered after a call to close,
+// or once the done channel is closed. The goroutine calls
+// wg.Done when it returns.
+func newCoalescer(inbox chan Event, done <-chan struct{}, wg *sync.WaitGroup) *coalescer {
+	c := &coalescer{
+		inbox: inbox,
+		done:  done,
+		wg:    wg,
+		limit: max(cap(inbox), 1),
+		ready: make(chan struct{}, 1),
+	}
+
+	go c.run()
+	return c
+}
+
+// push adds an event to the queue. It returns the number of events
+// which were merged away, and of those dropped from a full queue.
+func (c *coalescer) push(e Event) (merged, dropped uint64) {
+	if e.Type != EvSync || e.Code != SynReport {
+		return mergeEvent(&c.frame, e), 0
+	}
+
+	frame := append(c.frame, e)
+	c.frame = nil
+
+	c.mu.Lock()
+	if n := len(c.frames); n > 0 && mergeable(c.frames[n-1]) && mergeable(frame) {
+		last := c.frames[n-1]
+		last = last[:len(last)-1] // Drop the SynReport.
+
+		for _, e := range frame {
+			merged += mergeEvent(&last, e)
+		}
+
+		merged++ // The dropped SynReport.
+		c.frames[n-1] = last
+	} else {
+		c.frames = append(c.frames, frame)
+	}
+
+	for c.limit > 0 && len(c.frames) > c.limit {
+		dropped += uint64(len(c.frames[0]))
+		c.frames = c.frames[1:]
+	}
+	c.mu.Unlock()
+
+	c.signal()
+	return merged, dropped
+}
+
+// close marks the end of input. Events of an incomplete frame are discarded.
+func (c *coalescer) close() {
+	c.mu.Lock()
+	c.eof = true
+	c.mu.Unlock()
+	c.signal()
+}
+
+// signal wakes up the delivery goroutine.
+func (c *coalescer) signal() {
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers queued frames to the inbox.
+func (c *coalescer) run() {
+	defer c.wg.Done()
+	defer close(c.inbox)
+
+	for range c.ready {
+		for {
+			c.mu.Lock()
+			if len(c.frames) == 0 {
+				eof := c.eof
+				c.mu.Unlock()
+
+				if eof {
+					return
+				}
+				break
+			}
+
+			frame := c.frames[0]
+			c.frames = c.frames[1:]
+			c.mu.Unlock()
+
+			for _, e := range frame {
+				select {
+				case c.inbox <- e:
+				case <-c.done:
+					return
+				}
+			}
+		}
+	}
+}
+
+// mergeable returns true if the given frame holds only
+// relative motion and non-multitouch absolute values.
+func mergeable(frame []Event) bool {
+	for _, e := range frame {
+		switch e.Type {
+		case EvRelative:
+		case EvAbsolute:
+			if e.Code >= AbsMTSlot {
+				return false
+			}
+		case EvSync:
+			if e.Code != SynReport {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// mergeEvent appends the given event to the frame. Relative motion
+// is added to, and non-multitouch absolute values replace, a previous
+// event for the same code in the frame. The order of events within
+// a frame carries no meaning for these, so this is always safe.
+// It returns 1 if the event was merged and 0 if it was appended.
+func mergeEvent(frame *[]Event, e Event) uint64 {
+	if e.Type == EvRelative || (e.Type == EvAbsolute && e.Code < AbsMTSlot) {
+		for i := range *frame {
+			p := &(*frame)[i]
+			if p.Type != e.Type || p.Code != e.Code {
+				continue
+			}
+
+			if e.Type == EvRelative {
+				p.Value += e.Value
+			} else {
+				p.Value = e.Value
+			}
+
+			p.Time = e.Time
+			return 1
+		}
+	}
+
+	*frame = append(*frame, e)
+	return 0
+}
@@ -0,0 +1,163 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// waitStats waits until the device's counters satisfy the given condition.
+func waitStats(t *testing.T, dev *Device, cond func(Stats) bool) Stats {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s := dev.Stats()
+		if cond(s) {
+			return s
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out with stats %+v", s)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBackpressureDrop(t *testing.T) {
+	events := []Event{
+		{Type: EvRelative, Code: RelX, Value: 1},
+		{Type: EvRelative, Code: RelX, Value: 2},
+		{Type: EvRelative, Code: RelX, Value: 3},
+		{Type: EvRelative, Code: RelX, Value: 4},
+		{Type: EvRelative, Code: RelX, Value: 5},
+	}
+
+	want := []struct {
+		Policy Backpressure
+		Values []int32
+	}{
+		{BackpressureDropNewest, []int32{1, 2}},
+		{BackpressureDropOldest, []int32{4, 5}},
+	}
+
+	for _, w := range want {
+		dev, pipe := pipeDevice(t, InboxSize(2), WithBackpressure(w.Policy))
+		writeEvents(t, pipe, events...)
+
+		waitStats(t, dev, func(s Stats) bool { return s.Dropped == 3 })
+
+		for i, v := range w.Values {
+			if e := <-dev.Inbox; e.Value != v {
+				t.Fatalf("Policy %d: event %d: Want %d, have %d", w.Policy, i, v, e.Value)
+			}
+		}
+	}
+}
+
+func TestBackpressureKernelDropped(t *testing.T) {
+	dev, pipe := pipeDevice(t)
+	writeEvents(t, pipe,
+		Event{Type: EvSync, Code: SynDropped},
+		Event{Type: EvSync, Code: SynReport},
+	)
+
+	<-dev.Inbox
+	<-dev.Inbox
+
+	if s := dev.Stats(); s.KernelDropped != 1 {
+		t.Fatalf("Want 1 kernel drop, have %d", s.KernelDropped)
+	}
+}
+
+func TestCoalescer(t *testing.T) {
+	// The delivery goroutine is not started, so all frames stay queued.
+	c := &coalescer{ready: make(chan struct{}, 1)}
+
+	sync := Event{Type: EvSync, Code: SynReport}
+	frames := [][]Event{
+		{{Type: EvRelative, Code: RelX, Value: 1}, {Type: EvRelative, Code: RelX, Value: 2}, sync},
+		{{Type: EvRelative, Code: RelX, Value: 3}, {Type: EvAbsolute, Code: AbsX, Value: 10}, sync},
+		{{Type: EvAbsolute, Code: AbsX, Value: 20}, {Type: EvRelative, Code: RelY, Value: -1}, sync},
+		{{Type: EvKeys, Code: BtnLeft, Value: 1}, {Type: EvRelative, Code: RelX, Value: 1}, sync},
+		{{Type: EvRelative, Code: RelX, Value: 5}, sync},
+		{{Type: EvAbsolute, Code: AbsMTSlot, Value: 0}, {Type: EvAbsolute, Code: AbsMTPositionX, Value: 1}, sync},
+		{{Type: EvAbsolute, Code: AbsMTSlot, Value: 0}, {Type: EvAbsolute, Code: AbsMTPositionX, Value: 2}, sync},
+	}
+
+	var merged uint64
+	for _, frame := range frames {
+		for _, e := range frame {
+			m, _ := c.push(e)
+			merged += m
+		}
+	}
+
+	want := [][]Event{
+		{{Type: EvRelative, Code: RelX, Value: 6}, {Type: EvAbsolute, Code: AbsX, Value: 20}, {Type: EvRelative, Code: RelY, Value: -1}, sync},
+		{{Type: EvKeys, Code: BtnLeft, Value: 1}, {Type: EvRelative, Code: RelX, Value: 1}, sync},
+		{{Type: EvRelative, Code: RelX, Value: 5}, sync},
+		frames[5],
+		frames[6],
+	}
+
+	if !reflect.DeepEqual(c.frames, want) {
+		t.Fatalf("Want %v\nhave %v", want, c.frames)
+	}
+
+	if merged != 5 {
+		t.Fatalf("Want 5 merged events, have %d", merged)
+	}
+}
+
+func TestCoalescerLimit(t *testing.T) {
+	// The delivery goroutine is not started, so all frames stay queued.
+	c := &coalescer{limit: 2, ready: make(chan struct{}, 1)}
+
+	sync := Event{Type: EvSync, Code: SynReport}
+	var dropped uint64
+	for code := KeyA; code < KeyA+4; code++ {
+		for _, e := range []Event{{Type: EvKeys, Code: uint16(code), Value: 1}, sync} {
+			_, d := c.push(e)
+			dropped += d
+		}
+	}
+
+	want := [][]Event{
+		{{Type: EvKeys, Code: KeyA + 2, Value: 1}, sync},
+		{{Type: EvKeys, Code: KeyA + 3, Value: 1}, sync},
+	}
+
+	if !reflect.DeepEqual(c.frames, want) {
+		t.Fatalf("Want %v\nhave %v", want, c.frames)
+	}
+
+	if dropped != 4 {
+		t.Fatalf("Want 4 dropped events, have %d", dropped)
+	}
+}
+
+func TestBackpressureCoalesceFull(t *testing.T) {
+	dev, pipe := pipeDevice(t, InboxSize(2), WithBackpressure(BackpressureCoalesce))
+
+	// Key frames are never merged. The inbox and the queue fill up.
+	for code := KeyA; code < KeyA+8; code++ {
+		writeEvents(t, pipe, Event{Type: EvKeys, Code: uint16(code), Value: 1}, Event{Type: EvSync, Code: SynReport})
+	}
+
+	waitStats(t, dev, func(s Stats) bool { return s.Dropped > 0 })
+
+	// The newest frame is still delivered.
+	for {
+		select {
+		case e := <-dev.Inbox:
+			if e.Type == EvKeys && e.Code == KeyA+7 {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Want the newest frame delivered")
+		}
+	}
+}
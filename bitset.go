@@ -4,7 +4,8 @@
 package evdev
 
 import (
-	"math"
+	"iter"
+	"math/bits"
 	"unsafe"
 )
 
@@ -16,54 +17,225 @@ const (
 // A word is part of a bitset.
 type Word uint64
 
-// Bitset defines a set of bit values.
-type Bitset []Word
+// Bitset defines a set of bit values with a fixed length.
+// Bits beyond the length can not be set and test as false.
+//
+// A Bitset shares its memory with its copies, so setting a bit
+// in a copy sets it in the original too. Use Clone for a copy
+// of its own. The zero value is an empty set of length 0.
+type Bitset struct {
+	words []Word
+	n     int
+}
 
 // NewBitset creates a new bitset of the given size.
+// E.g.: NewBitset(KeyCount) holds all key and button codes.
 func NewBitset(bits int) Bitset {
-	size := int(math.Ceil((float64(bits) / WordBitSize)))
-	return make(Bitset, size)
+	if bits < 0 {
+		bits = 0
+	}
+	return Bitset{
+		words: make([]Word, (bits+WordBitSize-1)/WordBitSize),
+		n:     bits,
+	}
 }
 
 // Len returns the number of bits in the set.
 func (b Bitset) Len() int {
-	return len(b) * WordBitSize
+	return b.n
 }
 
-// Bytes returns the bitset as a byte slice.
+// Bytes returns the bitset as a byte slice of whole words.
 // This is the same memory, so any changes to the returned slice,
-// will affect the bitset.
+// will affect the bitset. Use Bitmap for the kernel's layout.
 func (b Bitset) Bytes() []byte {
-	if len(b) == 0 {
+	if len(b.words) == 0 {
 		return nil
 	}
 
-	size := len(b) * WordByteSize
-	return (*(*[1<<31 - 1]byte)(unsafe.Pointer(&b[0])))[:size]
+	return unsafe.Slice((*byte)(unsafe.Pointer(&b.words[0])), len(b.words)*WordByteSize)
 }
 
 // Set sets the bit at the given index.
 func (b Bitset) Set(i int) {
-	w := i / WordBitSize
-	if i < 0 || w >= len(b) {
-		return
+	if i >= 0 && i < b.n {
+		b.words[i/WordBitSize] |= 1 << uint(i%WordBitSize)
 	}
-
-	bit := Word(1 << uint(i%WordBitSize))
-	b[w] &^= bit
-	b[w] ^= bit
 }
 
 // Unset clears the bit at the given index.
 func (b Bitset) Unset(i int) {
-	w := i / WordBitSize
-	if i >= 0 && w < len(b) {
-		b[w] &^= 1 << uint(i%WordBitSize)
+	if i >= 0 && i < b.n {
+		b.words[i/WordBitSize] &^= 1 << uint(i%WordBitSize)
 	}
 }
 
 // Test returns true if the bit at the given index is set.
 func (b Bitset) Test(i int) bool {
-	w := i / WordBitSize
-	return i >= 0 && w < len(b) && ((b[w]>>uint(i%WordBitSize))&1) == 1
+	return i >= 0 && i < b.n && (b.words[i/WordBitSize]>>uint(i%WordBitSize))&1 == 1
+}
+
+// Count returns the number of bits which are set.
+func (b Bitset) Count() int {
+	var n int
+	for _, w := range b.words {
+		n += bits.OnesCount64(uint64(w))
+	}
+	return n
+}
+
+// Each calls fn with the index of each bit which is set, in ascending order.
+//
+//	caps.Keys.Each(func(code int) {
+//		fmt.Println(CodeName(EvKeys, code))
+//	})
+func (b Bitset) Each(fn func(i int)) {
+	for wi, w := range b.words {
+		for w != 0 {
+			n := bits.TrailingZeros64(uint64(w))
+			fn(wi*WordBitSize + n)
+			w &^= 1 << uint(n)
+		}
+	}
+}
+
+// All returns an iterator over the indices of the bits which are set,
+// in ascending order. E.g.:
+//
+//	for code := range caps.Keys.All() {
+func (b Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for wi, w := range b.words {
+			for w != 0 {
+				n := bits.TrailingZeros64(uint64(w))
+				if !yield(wi*WordBitSize + n) {
+					return
+				}
+				w &^= 1 << uint(n)
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set, which does not share its memory.
+func (b Bitset) Clone() Bitset {
+	c := Bitset{words: make([]Word, len(b.words)), n: b.n}
+	copy(c.words, b.words)
+	return c
+}
+
+// Union returns a new set holding the bits set in either set.
+// Its length is that of the longer set.
+func (b Bitset) Union(o Bitset) Bitset {
+	if o.n > b.n {
+		b, o = o, b
+	}
+
+	c := b.Clone()
+	for i, w := range o.words {
+		c.words[i] |= w
+	}
+	return c
+}
+
+// Intersect returns a new set holding the bits set in both sets.
+// Its length is that of the shorter set.
+func (b Bitset) Intersect(o Bitset) Bitset {
+	if o.n < b.n {
+		b, o = o, b
+	}
+
+	c := b.Clone()
+	for i := range c.words {
+		c.words[i] &= o.words[i]
+	}
+	return c
+}
+
+// Difference returns a new set holding the bits set in b, but not in o.
+// Its length is that of b.
+func (b Bitset) Difference(o Bitset) Bitset {
+	c := b.Clone()
+	for i := range c.words {
+		if i < len(o.words) {
+			c.words[i] &^= o.words[i]
+		}
+	}
+	return c
+}
+
+// Contains returns true if all bits set in o are set in b too.
+// E.g.: To test if a device supports the keys a program needs:
+//
+//	if !caps.Keys.Contains(need) {
+func (b Bitset) Contains(o Bitset) bool {
+	for i, w := range o.words {
+		var have Word
+		if i < len(b.words) {
+			have = b.words[i]
+		}
+		if w&^have != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both sets have the same length and bits.
+func (b Bitset) Equal(o Bitset) bool {
+	if b.n != o.n {
+		return false
+	}
+
+	for i, w := range b.words {
+		if w != o.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseHexBitset reads a bitmap in the format used by sysfs and
+// /proc/bus/input/devices into a new bitset of the given length.
+// This is a list of space separated, hexadecimal words; the most
+// significant word first. Each word holds as many bits as a C long
+// on the host. Bits beyond the length are ignored.
+// E.g.: "1f0000 0 0 0 0" yields BtnLeft to BtnExtra on 64-bit hosts.
+func ParseHexBitset(s string, bits int) (Bitset, error) {
+	b := NewBitset(bits)
+	if err := parseHexBitset(b, s); err != nil {
+		return Bitset{}, err
+	}
+	return b, nil
+}
+
+// HexString formats the bitset in the format read by ParseHexBitset.
+// As in sysfs, leading words of zero are left out. An empty set yields "0".
+func (b Bitset) HexString() string {
+	return textABI.formatHexBitset(b)
+}
+
+// NewBitsetFromBitmap decodes a bitmap in the layout used by the kernel
+// for this process into a new bitset of the given length. This is an
+// array of C longs, as filled in by the EVIOCGBIT ioctls.
+func NewBitsetFromBitmap(bitmap []byte, bits int) Bitset {
+	b := NewBitset(bits)
+	nativeABI.bitset(b, bitmap)
+	return b
+}
+
+// Bitmap returns the bitset in the layout used by the kernel for this
+// process. This is an array of C longs, as read by NewBitsetFromBitmap.
+func (b Bitset) Bitmap() []byte {
+	buf := make([]byte, nativeABI.bitmapSize(b.n))
+	nativeABI.putBitset(buf, b)
+	return buf
+}
+
+// parseHexBitset reads a bitmap in the format used by sysfs and
+// /proc/bus/input/devices into the given bitset. This is a list of
+// space separated, hexadecimal words; the most significant word first.
+// Each word holds as many bits as a C long on the host.
+func parseHexBitset(b Bitset, s string) error {
+	return textABI.parseHexBitset(b, s)
 }
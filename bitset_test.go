@@ -4,6 +4,8 @@
 package evdev
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 )
 
@@ -39,3 +41,163 @@ func TestBitset(t *testing.T) {
 		}
 	}
 }
+
+func TestParseHexBitset(t *testing.T) {
+	for arch, text := range map[string]string{
+		"amd64": "1f0000 0 0 0 0",
+		"386":   "1f0000 0 0 0 0 0 0 0 0",
+	} {
+		pinTextABI(t, arch)
+
+		bs := NewBitset(KeyCount)
+		if err := parseHexBitset(bs, text); err != nil {
+			t.Fatal(err)
+		}
+
+		for n := 0; n < bs.Len(); n++ {
+			want := n >= BtnLeft && n <= BtnExtra
+			if bs.Test(n) != want {
+				t.Fatalf("%s: Index %d: Want %v", arch, n, want)
+			}
+		}
+	}
+
+	if err := parseHexBitset(NewBitset(KeyCount), "1f 0xg"); err == nil {
+		t.Fatalf("Want error for malformed word")
+	}
+}
+
+func TestBitsetLen(t *testing.T) {
+	bs := NewBitset(EvCount)
+	if bs.Len() != EvCount {
+		t.Fatalf("Want length %d, have %d", EvCount, bs.Len())
+	}
+
+	bs.Set(EvCount)
+	if bs.Test(EvCount) || bs.Count() != 0 {
+		t.Fatalf("Want bits beyond the length to be ignored")
+	}
+
+	var zero Bitset
+	zero.Set(0)
+	if zero.Len() != 0 || zero.Test(0) || zero.HexString() != "0" {
+		t.Fatalf("Want empty zero value")
+	}
+}
+
+// bitsetOf returns a bitset of the given length with the given bits set.
+func bitsetOf(n int, list ...int) Bitset {
+	bs := NewBitset(n)
+	for _, i := range list {
+		bs.Set(i)
+	}
+	return bs
+}
+
+func TestBitsetIteration(t *testing.T) {
+	bs := bitsetOf(KeyCount, KeyA, BtnLeft, KeyMax)
+
+	var each []int
+	bs.Each(func(i int) { each = append(each, i) })
+
+	var all []int
+	for i := range bs.All() {
+		all = append(all, i)
+		if len(all) == 2 {
+			break
+		}
+	}
+
+	if bs.Count() != 3 || fmt.Sprint(each) != "[30 272 767]" {
+		t.Fatalf("Want 3 bits, have %d: %v", bs.Count(), each)
+	}
+
+	if fmt.Sprint(all) != "[30 272]" {
+		t.Fatalf("Want iteration to stop after 2 bits, have %v", all)
+	}
+}
+
+func TestBitsetAlgebra(t *testing.T) {
+	a := bitsetOf(100, 1, 2, 70)
+	b := bitsetOf(80, 2, 3, 70, 79)
+
+	tests := []struct {
+		Name string
+		Have Bitset
+		Want Bitset
+	}{
+		{"union", a.Union(b), bitsetOf(100, 1, 2, 3, 70, 79)},
+		{"union", b.Union(a), bitsetOf(100, 1, 2, 3, 70, 79)},
+		{"intersect", a.Intersect(b), bitsetOf(80, 2, 70)},
+		{"difference", a.Difference(b), bitsetOf(100, 1)},
+		{"difference", b.Difference(a), bitsetOf(80, 3, 79)},
+	}
+
+	for _, test := range tests {
+		if !test.Have.Equal(test.Want) {
+			t.Fatalf("%s: want %v, have %v", test.Name, test.Want, test.Have)
+		}
+	}
+
+	if a.Equal(bitsetOf(80, 1, 2, 70)) {
+		t.Fatalf("Want sets of different lengths to differ")
+	}
+
+	if !a.Contains(bitsetOf(80, 1, 70)) || a.Contains(b) {
+		t.Fatalf("Want a to contain its subsets only")
+	}
+
+	c := a.Clone()
+	c.Set(5)
+	if a.Test(5) {
+		t.Fatalf("Want clone not to share memory")
+	}
+}
+
+func TestBitsetHex(t *testing.T) {
+	tests := map[string]string{
+		"amd64": "1f0000 0 0 0 0",
+		"386":   "1f0000 0 0 0 0 0 0 0 0",
+		"mips":  "1f0000 0 0 0 0 0 0 0 0",
+	}
+
+	for _, arch := range []string{"amd64", "386", "mips"} {
+		pinTextABI(t, arch)
+
+		bs, err := ParseHexBitset(tests[arch], KeyCount)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bs.Count() != 5 || !bs.Test(BtnLeft) || !bs.Test(BtnExtra) {
+			t.Fatalf("%s: want BtnLeft to BtnExtra, have %v", arch, bs)
+		}
+
+		if have := bs.HexString(); have != tests[arch] {
+			t.Fatalf("%s: want %q, have %q", arch, tests[arch], have)
+		}
+	}
+
+	if _, err := ParseHexBitset("1f 0xg", KeyCount); err == nil {
+		t.Fatalf("Want error for malformed word")
+	}
+}
+
+func TestBitsetBitmap(t *testing.T) {
+	bs := bitsetOf(EvCount, EvSync, EvKeys, EvLed)
+
+	bitmap := bs.Bitmap()
+	if len(bitmap) != nativeABI.long {
+		t.Fatalf("Want one long, have %d bytes", len(bitmap))
+	}
+
+	if back := NewBitsetFromBitmap(bitmap, EvCount); !back.Equal(bs) {
+		t.Fatalf("Want %v, have %v", bs, back)
+	}
+
+	// Bits beyond the length are dropped.
+	full := bytes.Repeat([]byte{0xff}, 8)
+	if have := NewBitsetFromBitmap(full, 5); have.Count() != 5 {
+		t.Fatalf("Want 5 bits, have %v", have)
+	}
+}
@@ -0,0 +1,101 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import "unsafe"
+
+// Capabilities holds the capability bitsets of a device.
+// Each bitset can be tested against the constants for
+// the corresponding event type. E.g.: Keys against KeyXXX
+// and BtnXXX, Absolute against AbsXXX.
+type Capabilities struct {
+	Properties    Bitset // InputPropXXX
+	Events        Bitset // EvXXX
+	Keys          Bitset // KeyXXX and BtnXXX
+	Relative      Bitset // RelXXX
+	Absolute      Bitset // AbsXXX
+	Misc          Bitset // MiscXXX
+	Switches      Bitset // SwXXX
+	LEDs          Bitset // LedXXX
+	Sounds        Bitset // SndXXX
+	ForceFeedback Bitset // FFXXX
+}
+
+// Bits returns the bitset describing the codes supported for the
+// given event type. Passing EvSync yields the supported event types.
+// This returns an empty set for event types without codes.
+func (c *Capabilities) Bits(evtype int) Bitset {
+	switch evtype {
+	case EvSync:
+		return c.Events
+	case EvKeys:
+		return c.Keys
+	case EvRelative:
+		return c.Relative
+	case EvAbsolute:
+		return c.Absolute
+	case EvMisc:
+		return c.Misc
+	case EvSwitch:
+		return c.Switches
+	case EvLed:
+		return c.LEDs
+	case EvSound:
+		return c.Sounds
+	case EvForceFeedback:
+		return c.ForceFeedback
+	}
+	return Bitset{}
+}
+
+// IsKeyboard returns true if the capabilities qualify as a keyboard.
+// This uses the same criteria as the IsKeyboard function.
+func (c *Capabilities) IsKeyboard() bool {
+	return c.Events.Test(EvKeys) && c.Events.Test(EvLed)
+}
+
+// IsMouse returns true if the capabilities qualify as a mouse.
+// This uses the same criteria as the IsMouse function.
+func (c *Capabilities) IsMouse() bool {
+	return c.Events.Test(EvKeys) && c.Events.Test(EvRelative)
+}
+
+// IsJoystick returns true if the capabilities qualify as a joystick.
+// This uses the same criteria as the IsJoystick function.
+func (c *Capabilities) IsJoystick() bool {
+	return c.Events.Test(EvKeys) && c.Events.Test(EvAbsolute)
+}
+
+// Capabilities queries all capability bitsets of the device.
+func (d *Device) Capabilities() Capabilities {
+	var c Capabilities
+	c.Properties = d.bits(_EVIOCGPROP, InputPropCount)
+	c.Events = d.EventTypes()
+
+	c.Keys = d.eventBits(EvKeys, KeyCount)
+	c.Relative = d.eventBits(EvRelative, RelCount)
+	c.Absolute = d.eventBits(EvAbsolute, AbsCount)
+	c.Misc = d.eventBits(EvMisc, MiscCount)
+	c.Switches = d.eventBits(EvSwitch, SwCount)
+	c.LEDs = d.eventBits(EvLed, LedCount)
+	c.Sounds = d.eventBits(EvSound, SndCount)
+	c.ForceFeedback = d.eventBits(EvForceFeedback, FFCount)
+	return c
+}
+
+// eventBits queries the codes supported for the given event type.
+func (d *Device) eventBits(evtype, bits int) Bitset {
+	return d.bits(func(n int) uintptr { return _EVIOCGBIT(evtype, n) }, bits)
+}
+
+// bits queries a bitset of the given size through the given ioctl.
+// The kernel's bitmap is decoded according to the native layout.
+func (d *Device) bits(name func(int) uintptr, bits int) Bitset {
+	bs := NewBitset(bits)
+	buf := make([]byte, nativeABI.bitmapSize(bits))
+	if d.ioctl(name(len(buf)), unsafe.Pointer(&buf[0])) == nil {
+		nativeABI.bitset(bs, buf)
+	}
+	return bs
+}
@@ -0,0 +1,782 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// The capture format is a compact binary container for long recordings
+// of one or more devices. It is laid out as follows, with all integers
+// stored as varints unless noted otherwise:
+//
+//	magic    "EVCAP" and a version byte
+//	header   the number of devices, followed by the node and
+//	         description of each device
+//	records  a stream of frames and keyframes
+//	index    the time and file offset of each keyframe
+//	trailer  the offset of the index as a little-endian uint64
+//	         and "EVIX"
+//
+// A frame holds the events of one device written together. Timestamps
+// and values are stored as the difference to the previous frame and to
+// the previous value of the same code. A keyframe holds an absolute
+// timestamp and resets these differences, so reading can start at any
+// keyframe. The index and trailer are only written by
+// CaptureWriter.Close. Files without them can still be read, but
+// seeking needs to scan them from the start.
+const (
+	captureMagic   = "EVCAP\x01"
+	captureTrailer = "EVIX"
+
+	captureFrame    = 1
+	captureKeyframe = 2
+	captureIndex    = 3
+
+	// captureKeyframeInterval is the time between keyframes.
+	captureKeyframeInterval = time.Second
+
+	// Limits when reading, so corrupt files do not cause huge allocations.
+	captureMaxString = 1 << 12
+	captureMaxBits   = 1 << 16
+	captureMaxEvents = 1 << 16
+)
+
+// captureKey identifies a code of a device, for the value differences.
+type captureKey struct {
+	Device int
+	Type   uint16
+	Code   uint16
+}
+
+// captureEntry is an entry of the keyframe index.
+type captureEntry struct {
+	Time   int64 // Microseconds since the epoch.
+	Offset int64 // Offset of the keyframe in the file.
+}
+
+// CaptureFrame is a frame of events of one device in a capture.
+type CaptureFrame struct {
+	Device int     // Index in the devices of the capture.
+	Events []Event // The events, as passed to CaptureWriter.WriteFrame.
+}
+
+// CaptureWriter writes events of several devices in the capture format.
+// E.g.:
+//
+//	cw, err := evdev.NewCaptureWriter(file, &evdev.Recording{Description: dev.Describe()})
+//	if err != nil {
+//		return err
+//	}
+//
+//	for frame, err := range dev.Frames(ctx) {
+//		...
+//		cw.WriteFrame(0, frame...)
+//	}
+//
+//	err = cw.Close()
+//
+// It is safe for concurrent use.
+type CaptureWriter struct {
+	mu      sync.Mutex
+	w       *bufio.Writer
+	off     int64 // Bytes written so far.
+	ndevs   int
+	started bool
+	last    int64 // Time of the previous frame, in microseconds.
+	key     int64 // Time of the last keyframe.
+	values  map[captureKey]int32
+	index   []captureEntry
+	buf     []byte
+	err     error
+}
+
+// NewCaptureWriter writes the header for the given devices to w.
+// Only the node and description of each recording are written.
+// Devices are referred to by their index in this list.
+func NewCaptureWriter(w io.Writer, devs ...*Recording) (*CaptureWriter, error) {
+	cw := &CaptureWriter{
+		w:      bufio.NewWriter(w),
+		ndevs:  len(devs),
+		values: make(map[captureKey]int32),
+	}
+
+	cw.buf = append(cw.buf, captureMagic...)
+	cw.buf = binary.AppendUvarint(cw.buf, uint64(len(devs)))
+
+	for _, rec := range devs {
+		cw.buf = appendCaptureDevice(cw.buf, rec)
+	}
+
+	if err := cw.flushRecord(); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// appendCaptureDevice encodes the node and description of a device.
+func appendCaptureDevice(b []byte, rec *Recording) []byte {
+	desc := rec.Description
+	if desc == nil {
+		desc = newEmptyDescription()
+	}
+
+	for _, s := range []string{rec.Node, desc.Name, desc.Phys, desc.Uniq} {
+		b = binary.AppendUvarint(b, uint64(len(s)))
+		b = append(b, s...)
+	}
+
+	for _, v := range []uint16{desc.Id.BusType, desc.Id.Vendor, desc.Id.Product, desc.Id.Version} {
+		b = binary.AppendUvarint(b, uint64(v))
+	}
+
+	for _, bs := range captureBitsets(&desc.Capabilities) {
+		b = appendCaptureBitset(b, *bs)
+	}
+
+	axes := make([]int, 0, len(desc.Abs))
+	for axis := range desc.Abs {
+		axes = append(axes, axis)
+	}
+	sort.Ints(axes)
+
+	b = binary.AppendUvarint(b, uint64(len(axes)))
+	for _, axis := range axes {
+		info := desc.Abs[axis]
+		b = binary.AppendUvarint(b, uint64(axis))
+		for _, v := range []int32{info.Value, info.Minimum, info.Maximum, info.Fuzz, info.Flat, info.Resolution} {
+			b = binary.AppendVarint(b, int64(v))
+		}
+	}
+
+	return b
+}
+
+// captureBitsets lists the bitsets of the capabilities, in file order.
+func captureBitsets(c *Capabilities) []*Bitset {
+	return []*Bitset{
+		&c.Properties, &c.Events, &c.Keys, &c.Relative, &c.Absolute,
+		&c.Misc, &c.Switches, &c.LEDs, &c.Sounds, &c.ForceFeedback,
+	}
+}
+
+// appendCaptureBitset encodes the length of the bitset, followed by its
+// bits in bytes, lowest first. Unlike Bitmap, this does not depend on
+// the host.
+func appendCaptureBitset(b []byte, bs Bitset) []byte {
+	b = binary.AppendUvarint(b, uint64(bs.Len()))
+
+	bits := make([]byte, (bs.Len()+7)/8)
+	for i := range bs.All() {
+		bits[i/8] |= 1 << (i % 8)
+	}
+	return append(b, bits...)
+}
+
+// WriteFrame writes events of the given device. These are typically
+// the events of one frame, up to and including a SynReport.
+func (cw *CaptureWriter) WriteFrame(device int, events ...Event) error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	if cw.err != nil {
+		return cw.err
+	}
+
+	if device < 0 || device >= cw.ndevs {
+		return fmt.Errorf("capture: no device %d", device)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	t := syscall.TimevalToNsec(events[0].Time) / 1e3
+
+	if !cw.started || t-cw.key >= int64(captureKeyframeInterval/time.Microsecond) {
+		if err := cw.keyframe(t); err != nil {
+			return err
+		}
+	}
+
+	cw.buf = append(cw.buf, captureFrame)
+	cw.buf = binary.AppendUvarint(cw.buf, uint64(device))
+	cw.buf = binary.AppendVarint(cw.buf, t-cw.last)
+	cw.buf = binary.AppendUvarint(cw.buf, uint64(len(events)))
+
+	for _, e := range events {
+		k := captureKey{device, e.Type, e.Code}
+
+		cw.buf = binary.AppendVarint(cw.buf, syscall.TimevalToNsec(e.Time)/1e3-t)
+		cw.buf = binary.AppendUvarint(cw.buf, uint64(e.Type))
+		cw.buf = binary.AppendUvarint(cw.buf, uint64(e.Code))
+		cw.buf = binary.AppendVarint(cw.buf, int64(e.Value)-int64(cw.values[k]))
+		cw.values[k] = e.Value
+	}
+
+	cw.last = t
+	return cw.flushRecord()
+}
+
+// keyframe writes a keyframe for the given time and adds it to the index.
+func (cw *CaptureWriter) keyframe(t int64) error {
+	cw.index = append(cw.index, captureEntry{Time: t, Offset: cw.off})
+	cw.started, cw.key, cw.last = true, t, t
+	clear(cw.values)
+
+	cw.buf = append(cw.buf, captureKeyframe)
+	cw.buf = binary.AppendVarint(cw.buf, t)
+	return cw.flushRecord()
+}
+
+// flushRecord moves the encoded record to the buffered writer.
+func (cw *CaptureWriter) flushRecord() error {
+	n, err := cw.w.Write(cw.buf)
+	cw.off += int64(n)
+	cw.buf = cw.buf[:0]
+
+	if err != nil {
+		cw.err = err
+	}
+	return err
+}
+
+// Device returns an EventSink, which writes each batch
+// of events as a frame of the given device.
+func (cw *CaptureWriter) Device(device int) EventSink {
+	return captureDevice{cw, device}
+}
+
+type captureDevice struct {
+	cw     *CaptureWriter
+	device int
+}
+
+func (d captureDevice) WriteEvents(events ...Event) error {
+	return d.cw.WriteFrame(d.device, events...)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (cw *CaptureWriter) Flush() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+// Close writes the keyframe index and flushes the writer.
+// It does not close the underlying writer.
+func (cw *CaptureWriter) Close() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	if cw.err != nil {
+		return cw.err
+	}
+
+	at := cw.off
+
+	cw.buf = append(cw.buf, captureIndex)
+	cw.buf = binary.AppendUvarint(cw.buf, uint64(len(cw.index)))
+
+	var prev captureEntry
+	for _, e := range cw.index {
+		cw.buf = binary.AppendVarint(cw.buf, e.Time-prev.Time)
+		cw.buf = binary.AppendUvarint(cw.buf, uint64(e.Offset-prev.Offset))
+		prev = e
+	}
+
+	cw.buf = binary.LittleEndian.AppendUint64(cw.buf, uint64(at))
+	cw.buf = append(cw.buf, captureTrailer...)
+
+	if err := cw.flushRecord(); err != nil {
+		return err
+	}
+
+	cw.err = errors.New("capture: writer closed")
+	return cw.w.Flush()
+}
+
+// CaptureReader reads a file in the capture format, frame by frame.
+// E.g.:
+//
+//	cr, err := evdev.NewCaptureReader(file)
+//	...
+//	for {
+//		frame, err := cr.ReadFrame()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+type CaptureReader struct {
+	r      io.Reader
+	br     *bufio.Reader
+	devs   []*Recording
+	data   int64 // Offset of the first record.
+	last   int64 // Time of the previous frame, in microseconds.
+	values map[captureKey]int32
+	index  []captureEntry
+	next   *CaptureFrame // Frame read ahead by Seek.
+	done   bool
+}
+
+// NewCaptureReader reads the header of the capture from r.
+// Seeking requires r to implement io.ReadSeeker.
+func NewCaptureReader(r io.Reader) (*CaptureReader, error) {
+	cr := &CaptureReader{
+		r:      r,
+		br:     bufio.NewReader(r),
+		values: make(map[captureKey]int32),
+	}
+
+	magic := make([]byte, len(captureMagic))
+	if _, err := io.ReadFull(cr.br, magic); err != nil || string(magic) != captureMagic {
+		return nil, fmt.Errorf("capture: not a capture file")
+	}
+
+	n, err := cr.uvarint(1 << 10)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < int(n); i++ {
+		rec, err := cr.readDevice()
+		if err != nil {
+			return nil, err
+		}
+		cr.devs = append(cr.devs, rec)
+	}
+
+	if s, ok := r.(io.Seeker); ok {
+		off, err := s.Seek(0, io.SeekCurrent)
+		if err == nil {
+			cr.data = off - int64(cr.br.Buffered())
+		}
+	}
+
+	return cr, nil
+}
+
+// Devices returns the node and description of each device. The
+// recordings hold no events; these are read with ReadFrame.
+func (cr *CaptureReader) Devices() []*Recording {
+	return cr.devs
+}
+
+// readDevice decodes a device written by appendCaptureDevice.
+func (cr *CaptureReader) readDevice() (*Recording, error) {
+	var strs [4]string
+	for i := range strs {
+		n, err := cr.uvarint(captureMaxString)
+		if err != nil {
+			return nil, err
+		}
+
+		b := make([]byte, n)
+		if _, err := io.ReadFull(cr.br, b); err != nil {
+			return nil, cr.unexpected(err)
+		}
+		strs[i] = string(b)
+	}
+
+	var id [4]uint16
+	for i := range id {
+		v, err := cr.uvarint(0xffff)
+		if err != nil {
+			return nil, err
+		}
+		id[i] = uint16(v)
+	}
+
+	desc := &Description{
+		Name: strs[1],
+		Phys: strs[2],
+		Uniq: strs[3],
+		Id:   Id{BusType: id[0], Vendor: id[1], Product: id[2], Version: id[3]},
+		Abs:  make(map[int]AbsInfo),
+	}
+
+	for _, bs := range captureBitsets(&desc.Capabilities) {
+		n, err := cr.uvarint(captureMaxBits)
+		if err != nil {
+			return nil, err
+		}
+
+		bits := make([]byte, (n+7)/8)
+		if _, err := io.ReadFull(cr.br, bits); err != nil {
+			return nil, cr.unexpected(err)
+		}
+
+		*bs = NewBitset(int(n))
+		for i := 0; i < int(n); i++ {
+			if bits[i/8]&(1<<(i%8)) != 0 {
+				bs.Set(i)
+			}
+		}
+	}
+
+	naxes, err := cr.uvarint(AbsCount)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < int(naxes); i++ {
+		axis, err := cr.uvarint(AbsCount)
+		if err != nil {
+			return nil, err
+		}
+
+		var v [6]int32
+		for j := range v {
+			x, err := binary.ReadVarint(cr.br)
+			if err != nil {
+				return nil, cr.unexpected(err)
+			}
+			v[j] = int32(x)
+		}
+
+		desc.Abs[int(axis)] = AbsInfo{v[0], v[1], v[2], v[3], v[4], v[5]}
+	}
+
+	return &Recording{Node: strs[0], Description: desc}, nil
+}
+
+// uvarint reads an unsigned varint of at most max.
+func (cr *CaptureReader) uvarint(max uint64) (uint64, error) {
+	v, err := binary.ReadUvarint(cr.br)
+	if err != nil {
+		return 0, cr.unexpected(err)
+	}
+	if v > max {
+		return 0, fmt.Errorf("capture: value %d out of range", v)
+	}
+	return v, nil
+}
+
+// unexpected turns io.EOF within a record into io.ErrUnexpectedEOF.
+func (cr *CaptureReader) unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// ReadFrame returns the next frame. This returns io.EOF at the end of the
+// records, which is also the case for files whose writer was not closed.
+func (cr *CaptureReader) ReadFrame() (CaptureFrame, error) {
+	if cr.next != nil {
+		f := *cr.next
+		cr.next = nil
+		return f, nil
+	}
+
+	if cr.done {
+		return CaptureFrame{}, io.EOF
+	}
+
+	for {
+		tag, err := cr.br.ReadByte()
+		if err != nil {
+			return CaptureFrame{}, err
+		}
+
+		switch tag {
+		case captureKeyframe:
+			t, err := binary.ReadVarint(cr.br)
+			if err != nil {
+				return CaptureFrame{}, cr.unexpected(err)
+			}
+			cr.last = t
+			clear(cr.values)
+
+		case captureFrame:
+			return cr.readFrame()
+
+		case captureIndex:
+			cr.done = true
+			return CaptureFrame{}, io.EOF
+
+		default:
+			return CaptureFrame{}, fmt.Errorf("capture: unknown record %d", tag)
+		}
+	}
+}
+
+// readFrame decodes a frame written by CaptureWriter.WriteFrame.
+func (cr *CaptureReader) readFrame() (CaptureFrame, error) {
+	device, err := cr.uvarint(uint64(len(cr.devs)))
+	if err != nil || int(device) == len(cr.devs) {
+		return CaptureFrame{}, fmt.Errorf("capture: bad device in frame")
+	}
+
+	dt, err := binary.ReadVarint(cr.br)
+	if err != nil {
+		return CaptureFrame{}, cr.unexpected(err)
+	}
+	t := cr.last + dt
+	cr.last = t
+
+	n, err := cr.uvarint(captureMaxEvents)
+	if err != nil {
+		return CaptureFrame{}, err
+	}
+
+	events := make([]Event, n)
+	for i := range events {
+		var v [4]int64
+		for j := range v {
+			if j == 1 || j == 2 {
+				x, err := cr.uvarint(0xffff)
+				if err != nil {
+					return CaptureFrame{}, err
+				}
+				v[j] = int64(x)
+				continue
+			}
+
+			x, err := binary.ReadVarint(cr.br)
+			if err != nil {
+				return CaptureFrame{}, cr.unexpected(err)
+			}
+			v[j] = x
+		}
+
+		k := captureKey{int(device), uint16(v[1]), uint16(v[2])}
+		value := int32(int64(cr.values[k]) + v[3])
+		cr.values[k] = value
+
+		events[i] = Event{
+			Time:  syscall.NsecToTimeval((t + v[0]) * 1e3),
+			Type:  k.Type,
+			Code:  k.Code,
+			Value: value,
+		}
+	}
+
+	return CaptureFrame{Device: int(device), Events: events}, nil
+}
+
+// Seek moves to the first frame at or after the given offset from the
+// start of the capture. With an index, this reads a single keyframe
+// interval. Without one, the file is scanned from the start.
+// The reader must implement io.ReadSeeker.
+func (cr *CaptureReader) Seek(offset time.Duration) error {
+	rs, ok := cr.r.(io.ReadSeeker)
+	if !ok {
+		return fmt.Errorf("capture: reader does not support seeking")
+	}
+
+	if cr.index == nil {
+		if err := cr.readIndex(rs); err != nil {
+			return err
+		}
+	}
+
+	// Without an index, or with an empty one, scan from the start.
+	start, at := int64(0), cr.data
+	if len(cr.index) > 0 {
+		start = cr.index[0].Time
+		target := start + int64(offset/time.Microsecond)
+		i := sort.Search(len(cr.index), func(i int) bool {
+			return cr.index[i].Time > target
+		})
+		if i > 0 {
+			at = cr.index[i-1].Offset
+		}
+	}
+
+	if _, err := rs.Seek(at, io.SeekStart); err != nil {
+		return err
+	}
+	cr.br.Reset(rs)
+	cr.next, cr.done = nil, false
+
+	for {
+		f, err := cr.ReadFrame()
+		if err != nil {
+			return err
+		}
+
+		t := syscall.TimevalToNsec(f.Events[0].Time) / 1e3
+		if len(cr.index) == 0 {
+			// The first frame of the file marks the start.
+			cr.index = []captureEntry{{Time: t, Offset: cr.data}}
+			start = t
+		}
+
+		if t >= start+int64(offset/time.Microsecond) {
+			cr.next = &f
+			return nil
+		}
+	}
+}
+
+// readIndex loads the keyframe index from the end of the file.
+// Files without a trailer yield an empty index.
+func (cr *CaptureReader) readIndex(rs io.ReadSeeker) error {
+	cr.index = []captureEntry{}
+
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	trailer := make([]byte, 8+len(captureTrailer))
+	if size < cr.data+int64(len(trailer)) {
+		return nil
+	}
+
+	if _, err := rs.Seek(-int64(len(trailer)), io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(rs, trailer); err != nil {
+		return err
+	}
+	if string(trailer[8:]) != captureTrailer {
+		return nil
+	}
+
+	at := int64(binary.LittleEndian.Uint64(trailer))
+	if at < cr.data || at >= size {
+		return fmt.Errorf("capture: bad index offset")
+	}
+
+	if _, err := rs.Seek(at, io.SeekStart); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(io.LimitReader(rs, size-at))
+	if tag, err := br.ReadByte(); err != nil || tag != captureIndex {
+		return fmt.Errorf("capture: bad index")
+	}
+
+	n, err := binary.ReadUvarint(br)
+	if err != nil || n > uint64(size) {
+		return fmt.Errorf("capture: bad index")
+	}
+
+	var prev captureEntry
+	for i := 0; i < int(n); i++ {
+		dt, err := binary.ReadVarint(br)
+		if err != nil {
+			return fmt.Errorf("capture: bad index")
+		}
+		doff, err := binary.ReadUvarint(br)
+		if err != nil {
+			return fmt.Errorf("capture: bad index")
+		}
+
+		prev = captureEntry{Time: prev.Time + dt, Offset: prev.Offset + int64(doff)}
+		cr.index = append(cr.index, prev)
+	}
+
+	return nil
+}
+
+// ReadCapture reads a whole capture into a session. Each frame is added
+// to the events of its device.
+func ReadCapture(r io.Reader) (*Session, error) {
+	cr, err := NewCaptureReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{Devices: cr.Devices()}
+	for {
+		f, err := cr.ReadFrame()
+		if err == io.EOF {
+			return s, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rec := s.Devices[f.Device]
+		rec.Events = append(rec.Events, f.Events...)
+	}
+}
+
+// WriteCapture writes the session in the capture format. The events of
+// all devices are split into frames and written in the order of time.
+func WriteCapture(w io.Writer, s *Session) error {
+	cw, err := NewCaptureWriter(w, s.Devices...)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range replayFrames(s) {
+		if err := cw.WriteFrame(f.Device, f.Events...); err != nil {
+			return err
+		}
+	}
+
+	return cw.Close()
+}
+
+// RecordCapture records the given devices into w in the capture format,
+// frame by frame, until ctx is done or all devices are closed. Unlike
+// RecordLibinput, nothing is held in memory. An error reading from a
+// device, other than ctx being done, is returned after writing the index.
+func RecordCapture(ctx context.Context, w io.Writer, devs ...*Device) error {
+	recs := make([]*Recording, len(devs))
+	for i, dev := range devs {
+		recs[i] = &Recording{Description: dev.Describe()}
+	}
+
+	cw, err := NewCaptureWriter(w, recs...)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(devs))
+
+	var wg sync.WaitGroup
+
+	for i, dev := range devs {
+		wg.Add(1)
+		go func(i int, dev *Device) {
+			defer wg.Done()
+
+			for frame, err := range dev.Frames(ctx) {
+				if err != nil {
+					if ctx.Err() == nil {
+						errs[i] = err
+					}
+					return
+				}
+
+				if err := cw.WriteFrame(i, frame...); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+		}(i, dev)
+	}
+
+	wg.Wait()
+
+	if err := cw.Close(); err != nil {
+		return err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCaptureRoundTrip(t *testing.T) {
+	s := readSession(t)
+	s.Devices[0].Node = "/dev/input/event7"
+
+	var buf bytes.Buffer
+	if err := WriteCapture(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := ReadCapture(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(have.Devices) != 2 {
+		t.Fatalf("Want 2 devices, have %d", len(have.Devices))
+	}
+
+	for i, want := range s.Devices {
+		rec := have.Devices[i]
+		if rec.Node != want.Node {
+			t.Fatalf("Device %d: want node %q, have %q", i, want.Node, rec.Node)
+		}
+		if !reflect.DeepEqual(rec.Description, want.Description) {
+			t.Fatalf("Device %d: want %+v, have %+v", i, want.Description, rec.Description)
+		}
+		if !reflect.DeepEqual(rec.Events, want.Events) {
+			t.Fatalf("Device %d: want %v, have %v", i, want.Events, rec.Events)
+		}
+	}
+}
+
+// gamepadCapture writes the given seconds of a gamepad reporting
+// two axes at 1 kHz, alongside a keyboard pressing a key every second.
+func gamepadCapture(t *testing.T, seconds int) []byte {
+	start := int64(1700000000) * 1e9
+
+	var buf bytes.Buffer
+	cw, err := NewCaptureWriter(&buf, &Recording{Description: newEmptyDescription()}, &Recording{Description: testKeyboard()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for ms := 0; ms < seconds*1000; ms++ {
+		tv := syscall.NsecToTimeval(start + int64(ms)*1e6)
+		err := cw.WriteFrame(0,
+			Event{Time: tv, Type: EvAbsolute, Code: AbsX, Value: int32(ms % 200)},
+			Event{Time: tv, Type: EvAbsolute, Code: AbsY, Value: int32(-ms % 50)},
+			Event{Time: tv, Type: EvSync, Code: SynReport},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ms%1000 == 500 {
+			err := cw.Device(1).WriteEvents(
+				Event{Time: tv, Type: EvKeys, Code: KeyA, Value: 1},
+				Event{Time: tv, Type: EvSync, Code: SynReport},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if err := cw.WriteFrame(2, Event{}); err == nil {
+		t.Fatalf("Want error for unknown device")
+	}
+
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCaptureSize(t *testing.T) {
+	data := gamepadCapture(t, 10)
+
+	// The input_event structs alone take 24 bytes each on 64-bit hosts.
+	if per := float64(len(data)) / 30000; per > 6 {
+		t.Fatalf("Want at most 6 bytes per event, have %.1f", per)
+	}
+}
+
+func TestCaptureSeek(t *testing.T) {
+	data := gamepadCapture(t, 5)
+
+	for _, name := range []string{"indexed", "unindexed"} {
+		t.Run(name, func(t *testing.T) {
+			input := data
+			if name == "unindexed" {
+				// As if the writer was never closed.
+				input = data[:bytes.LastIndexByte(data, captureIndex)]
+			}
+
+			cr, err := NewCaptureReader(bytes.NewReader(input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(cr.Devices()) != 2 || cr.Devices()[1].Description.Name != "evdev test keyboard" {
+				t.Fatalf("Unexpected devices %+v", cr.Devices())
+			}
+
+			for _, offset := range []time.Duration{2500 * time.Millisecond, 1500 * time.Microsecond, 0} {
+				if err := cr.Seek(offset); err != nil {
+					t.Fatal(err)
+				}
+
+				f, err := cr.ReadFrame()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				ms := int(offset / time.Millisecond)
+				if offset%time.Millisecond != 0 {
+					ms++
+				}
+
+				want := Event{Type: EvAbsolute, Code: AbsX, Value: int32(ms % 200)}
+				if have := f.Events[0]; f.Device != 0 || have.Code != want.Code || have.Value != want.Value {
+					t.Fatalf("Seek(%v): want %v, have %v", offset, want, have)
+				}
+
+				if have := f.Events[1].Value; have != int32(-ms%50) {
+					t.Fatalf("Seek(%v): want AbsY %d, have %d", offset, -ms%50, have)
+				}
+			}
+
+			// Read on to the end: the remaining 4.5s minus the frame read.
+			n := 0
+			for {
+				_, err := cr.ReadFrame()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				n++
+			}
+
+			if n != 5000+5-1 {
+				t.Fatalf("Want %d frames, have %d", 5000+5-1, n)
+			}
+		})
+	}
+}
+
+func TestCaptureMalformed(t *testing.T) {
+	data := gamepadCapture(t, 1)
+
+	if _, err := NewCaptureReader(bytes.NewReader([]byte("EVEMU\x01"))); err == nil {
+		t.Fatalf("Want error for bad magic")
+	}
+
+	if _, err := NewCaptureReader(bytes.NewReader(data[:20])); err == nil {
+		t.Fatalf("Want error for truncated header")
+	}
+
+	cr, err := NewCaptureReader(bytes.NewReader(data[:len(data)/2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for err == nil {
+		_, err = cr.ReadFrame()
+	}
+
+	if err != io.EOF && err != io.ErrUnexpectedEOF {
+		t.Fatalf("Want io.EOF or io.ErrUnexpectedEOF, have %v", err)
+	}
+}
+
+func TestRecordCapture(t *testing.T) {
+	pad, pw := pipeDevice(t)
+	kbd, kw := pipeDevice(t)
+
+	tv := func(us int64) syscall.Timeval { return syscall.NsecToTimeval(5e9 + us*1e3) }
+
+	writeEvents(t, pw,
+		Event{tv(1000), EvAbsolute, AbsX, 10},
+		Event{tv(1000), EvSync, SynReport, 0},
+	)
+	writeEvents(t, kw,
+		Event{tv(0), EvKeys, KeyA, 1},
+		Event{tv(0), EvSync, SynReport, 0},
+	)
+	pw.Close()
+	kw.Close()
+
+	var out bytes.Buffer
+	if err := RecordCapture(context.Background(), &out, pad, kbd); err != io.EOF {
+		t.Fatalf("Want io.EOF, have %v", err)
+	}
+
+	s, err := ReadCapture(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Timestamps are kept as they are, whichever device was written first.
+	if e := s.Devices[0].Events[0]; e != (Event{tv(1000), EvAbsolute, AbsX, 10}) {
+		t.Fatalf("Want AbsX at 5.001s, have %v", e)
+	}
+
+	if e := s.Devices[1].Events[0]; e != (Event{tv(0), EvKeys, KeyA, 1}) {
+		t.Fatalf("Want KeyA at 5s, have %v", e)
+	}
+}
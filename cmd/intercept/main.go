@@ -0,0 +1,82 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+// Command intercept reads events from a device and writes them to
+// stdout as raw input_event structs, like the intercept command of
+// interception-tools. E.g.:
+//
+//	intercept -g /dev/input/event3 | caps2esc | uinput -d /dev/input/event3
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/giulianopz/evdev"
+)
+
+func main() {
+	grab := flag.Bool("g", false, "grab the device, so other programs receive no events from it")
+	node := parseArgs()
+
+	dev, err := evdev.Open(node, evdev.ReadOnly(), evdev.NoReader())
+	if err != nil {
+		fatal(err)
+	}
+	defer dev.Close()
+
+	if *grab {
+		// Keys held while grabbing, such as the Enter key which started
+		// this command, would never be released for other programs.
+		waitForRelease(dev)
+
+		if !dev.Grab() {
+			fatal(fmt.Errorf("%s: failed to grab the device", node))
+		}
+		defer dev.Release()
+	}
+
+	// Closing the device ends the copy below.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		dev.Close()
+	}()
+
+	if _, err := evdev.CopyEvents(evdev.NewEncoder(os.Stdout), dev); err != nil {
+		dev.Release()
+		fatal(err)
+	}
+}
+
+// waitForRelease waits up to a few seconds for all keys to be released.
+func waitForRelease(dev *evdev.Device) {
+	for i := 0; i < 100 && dev.KeyState().Count() > 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "intercept: %v\n", err)
+	os.Exit(1)
+}
+
+func parseArgs() string {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-g] <node>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	return flag.Arg(0)
+}
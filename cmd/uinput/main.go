@@ -0,0 +1,125 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+// Command uinput creates a virtual device and emits the raw input_event
+// structs read from stdin on it, like the uinput command of
+// interception-tools. The device is described by existing devices (-d),
+// YAML files (-c) or both; their capabilities are merged. E.g.:
+//
+//	intercept -g /dev/input/event3 | caps2esc | uinput -d /dev/input/event3
+//	uinput -p -d /dev/input/event3 > keyboard.yaml
+//	uinput -c keyboard.yaml < events.raw
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/giulianopz/evdev"
+)
+
+// list collects the values of a flag given more than once.
+type list []string
+
+func (l *list) String() string     { return strings.Join(*l, ",") }
+func (l *list) Set(s string) error { *l = append(*l, s); return nil }
+
+func main() {
+	var configs, nodes list
+
+	printDesc := flag.Bool("p", false, "print the merged device description as YAML and exit")
+	flag.Var(&configs, "c", "read a device description from the given YAML `file`")
+	flag.Var(&nodes, "d", "clone the description of the given device `node`")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-p] [-c <file>]... [-d <node>]...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() > 0 || len(configs)+len(nodes) == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var desc *evdev.Description
+
+	for _, file := range configs {
+		fd, err := os.Open(file)
+		if err != nil {
+			fatal(err)
+		}
+
+		d, err := evdev.ReadInterceptionYAML(fd)
+		fd.Close()
+		if err != nil {
+			fatal(fmt.Errorf("%s: %v", file, err))
+		}
+		desc = merge(desc, d)
+	}
+
+	for _, node := range nodes {
+		dev, err := evdev.Open(node, evdev.ReadOnly(), evdev.NoReader())
+		if err != nil {
+			fatal(err)
+		}
+		desc = merge(desc, dev.Describe())
+		dev.Close()
+	}
+
+	if *printDesc {
+		if err := evdev.WriteInterceptionYAML(os.Stdout, desc); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	u, err := evdev.NewUInput(desc)
+	if err != nil {
+		fatal(err)
+	}
+	defer u.Close()
+
+	if _, err := evdev.CopyEvents(u, evdev.NewDecoder(os.Stdin)); err != nil {
+		u.Close()
+		fatal(err)
+	}
+}
+
+// merge adds the capabilities of d to desc. The identity is
+// taken from the first description.
+func merge(desc, d *evdev.Description) *evdev.Description {
+	if desc == nil {
+		return d
+	}
+
+	a, b := &desc.Capabilities, &d.Capabilities
+	for _, f := range []struct{ A, B *evdev.Bitset }{
+		{&a.Properties, &b.Properties},
+		{&a.Events, &b.Events},
+		{&a.Keys, &b.Keys},
+		{&a.Relative, &b.Relative},
+		{&a.Absolute, &b.Absolute},
+		{&a.Misc, &b.Misc},
+		{&a.Switches, &b.Switches},
+		{&a.LEDs, &b.LEDs},
+		{&a.Sounds, &b.Sounds},
+		{&a.ForceFeedback, &b.ForceFeedback},
+	} {
+		*f.A = f.A.Union(*f.B)
+	}
+
+	for axis, info := range d.Abs {
+		if _, ok := desc.Abs[axis]; !ok {
+			desc.Abs[axis] = info
+		}
+	}
+
+	return desc
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "uinput: %v\n", err)
+	os.Exit(1)
+}
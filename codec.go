@@ -0,0 +1,157 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"io"
+)
+
+// EventSource is implemented by anything events can be read from,
+// such as a Device or a Decoder.
+//
+// ReadEvents reads at least one event into buf, unless buf is empty,
+// and returns the number of events read. It returns io.EOF once the
+// stream ends.
+type EventSource interface {
+	ReadEvents(buf []Event) (int, error)
+}
+
+// EventSink is implemented by anything events can be written to,
+// such as a Device, a UInput device or an Encoder.
+type EventSink interface {
+	WriteEvents(events ...Event) error
+}
+
+// Decoder reads events from a stream of native struct input_event
+// values, such as a pipe, a socket or a file holding the raw contents
+// of a device node. E.g.:
+//
+//	dec := evdev.NewDecoder(os.Stdin)
+//	for {
+//		evt, err := dec.Decode()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+//
+// Unlike a device node, such streams may return part of an event
+// in a single read. The rest is completed by the next read.
+// A Decoder is not safe for concurrent use.
+type Decoder struct {
+	r    io.Reader
+	rest []byte // Bytes of an incomplete event.
+	one  [1]Event
+}
+
+// NewDecoder creates a decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decode reads the next event.
+func (d *Decoder) Decode() (Event, error) {
+	if _, err := d.ReadEvents(d.one[:]); err != nil {
+		return Event{}, err
+	}
+	return d.one[0], nil
+}
+
+// ReadEvents reads as many whole events into buf as a single read of
+// the underlying reader yields, but at least one. With the native
+// layout, the events are read directly into buf. It returns io.EOF at
+// the end of the stream, or io.ErrUnexpectedEOF if it ends within an
+// event.
+func (d *Decoder) ReadEvents(buf []Event) (int, error) {
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	size := nativeABI.eventSize()
+	data := nativeABI.eventBuffer(buf)
+	n := copy(data, d.rest)
+	d.rest = d.rest[:0]
+
+	for n < size {
+		m, err := d.r.Read(data[n:])
+		n += m
+
+		if err == io.EOF && n > 0 && n < size {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil && n < size {
+			return 0, err
+		}
+	}
+
+	count := n / size
+	d.rest = append(d.rest, data[count*size:n]...)
+	return nativeABI.decodeEvents(buf, data[:count*size]), nil
+}
+
+// Encoder writes events as native struct input_event values, as read
+// by a Decoder or a device node. It is safe for concurrent use, if the
+// underlying writer is. Each call results in a single write.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder creates an encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the given events.
+func (e *Encoder) Encode(events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	buf := nativeABI.encodeEvents(events)
+
+	n, err := e.w.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	if n < len(buf) {
+		return io.ErrShortWrite
+	}
+
+	return nil
+}
+
+// WriteEvents writes the given events. It is the same as Encode.
+func (e *Encoder) WriteEvents(events ...Event) error {
+	return e.Encode(events...)
+}
+
+// CopyEvents writes the events read from src to dst, until src ends,
+// and returns the number of events copied. The end of src, through
+// io.EOF or ErrClosed, is not reported as an error. E.g.:
+//
+//	// Feed raw events from stdin into a virtual device.
+//	n, err := evdev.CopyEvents(udev, evdev.NewDecoder(os.Stdin))
+func CopyEvents(dst EventSink, src EventSource) (int64, error) {
+	buf := make([]Event, eventBufferSize)
+
+	var total int64
+	for {
+		n, err := src.ReadEvents(buf)
+		if n > 0 {
+			if werr := dst.WriteEvents(buf[:n]...); werr != nil {
+				return total, werr
+			}
+			total += int64(n)
+		}
+
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, ErrClosed):
+			return total, nil
+		case err != nil:
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	if err := enc.Encode(testFrames[:3]...); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.WriteEvents(testFrames[3:]...); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each read yields a single byte, so events arrive in pieces.
+	dec := NewDecoder(iotest.OneByteReader(bytes.NewReader(buf.Bytes())))
+
+	var have []Event
+	for {
+		e, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		have = append(have, e)
+	}
+
+	if !reflect.DeepEqual(have, testFrames) {
+		t.Fatalf("Want %v, have %v", testFrames, have)
+	}
+
+	// A stream ending within an event.
+	dec = NewDecoder(bytes.NewReader(buf.Bytes()[:len(buf.Bytes())-1]))
+	events := make([]Event, len(testFrames))
+
+	n, err := dec.ReadEvents(events)
+	if err != nil || n != len(testFrames)-1 {
+		t.Fatalf("Want %d events, have %d, %v", len(testFrames)-1, n, err)
+	}
+
+	if _, err := dec.ReadEvents(events); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Want io.ErrUnexpectedEOF, have %v", err)
+	}
+}
+
+func TestCopyEvents(t *testing.T) {
+	var buf bytes.Buffer
+	NewEncoder(&buf).Encode(testFrames...)
+
+	var rec frameRecorder
+	n, err := CopyEvents(&rec, NewDecoder(&buf))
+	if err != nil || n != int64(len(testFrames)) {
+		t.Fatalf("Want %d events, have %d, %v", len(testFrames), n, err)
+	}
+
+	if !reflect.DeepEqual(rec.frames, [][]Event{testFrames}) {
+		t.Fatalf("Want %v, have %v", testFrames, rec.frames)
+	}
+}
+
+func TestSourceFrames(t *testing.T) {
+	var buf bytes.Buffer
+	NewEncoder(&buf).Encode(testFrames...)
+
+	var frames [][]Event
+	for frame, err := range Frames(NewDecoder(&buf)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, frame)
+	}
+
+	if len(frames) != 2 || frames[1][0] != testFrames[4] {
+		t.Fatalf("Want SynDropped and BtnLeft frames, have %v", frames)
+	}
+
+	// Errors other than the end of the stream are passed on.
+	src := NewDecoder(iotest.ErrReader(io.ErrClosedPipe))
+	for _, err := range Events(src) {
+		if err != io.ErrClosedPipe {
+			t.Fatalf("Want io.ErrClosedPipe, have %v", err)
+		}
+	}
+}
+
+func TestDeviceReadEvents(t *testing.T) {
+	for _, name := range []string{"reader", "noreader"} {
+		t.Run(name, func(t *testing.T) {
+			var opts []Option
+			if name == "noreader" {
+				opts = append(opts, NoReader())
+			}
+
+			dev, w := pipeDevice(t, opts...)
+			NewEncoder(w).Encode(testFrames...)
+			w.Close()
+
+			var rec frameRecorder
+			n, err := CopyEvents(&rec, dev)
+			if err != nil || n != int64(len(testFrames)) {
+				t.Fatalf("Want %d events, have %d, %v", len(testFrames), n, err)
+			}
+
+			if _, err := dev.ReadEvents(make([]Event, 1)); err != io.EOF {
+				t.Fatalf("Want io.EOF, have %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// TypedEvent is an event decoded into a concrete type by Event.Decode.
+// It is one of KeyEvent, RelEvent, AbsEvent, SwitchEvent, LedEvent,
+// MiscEvent, SyncEvent or FFStatusEvent. Events of any other type
+// are returned as the plain Event. E.g.:
+//
+//	switch e := evt.Decode().(type) {
+//	case KeyEvent:
+//		fmt.Println(CodeName(EvKeys, e.Code), e.State)
+//	case RelEvent:
+//		x += e.Value
+//	}
+type TypedEvent interface {
+	// Raw returns the event as read from the device.
+	Raw() Event
+}
+
+// KeyState is the value of an EvKeys event.
+type KeyState int32
+
+// Values of KeyState.
+const (
+	KeyReleased KeyState = 0
+	KeyPressed  KeyState = 1
+	KeyRepeat   KeyState = 2 // Sent while a key is held down.
+)
+
+// String returns the name of the state. E.g.: pressed
+func (s KeyState) String() string {
+	switch s {
+	case KeyReleased:
+		return "released"
+	case KeyPressed:
+		return "pressed"
+	case KeyRepeat:
+		return "repeat"
+	}
+	return fmt.Sprintf("KeyState(%d)", int32(s))
+}
+
+// KeyEvent reports a key or button changing its state.
+type KeyEvent struct {
+	Time  syscall.Timeval
+	Code  int // E.g.: KeyA or BtnLeft
+	State KeyState
+}
+
+// RelEvent reports the motion along a relative axis.
+type RelEvent struct {
+	Time  syscall.Timeval
+	Code  int   // E.g.: RelX or RelWheel
+	Value int32 // The distance moved.
+}
+
+// AbsEvent reports the new value of an absolute axis.
+// Info holds the range of the axis. It is only filled in
+// by Device.Decode, since a plain event does not know it.
+type AbsEvent struct {
+	Time  syscall.Timeval
+	Code  int // E.g.: AbsX or AbsMTSlot
+	Value int32
+	Info  AbsInfo
+}
+
+// Normalized returns the value scaled from the range of the axis to [0, 1].
+// This returns 0 if the range is unknown or empty.
+func (e AbsEvent) Normalized() float64 {
+	lo, hi := e.Info.Minimum, e.Info.Maximum
+	if hi <= lo {
+		return 0
+	}
+
+	switch {
+	case e.Value <= lo:
+		return 0
+	case e.Value >= hi:
+		return 1
+	}
+	return float64(int64(e.Value)-int64(lo)) / float64(int64(hi)-int64(lo))
+}
+
+// SwitchEvent reports a switch changing its state.
+type SwitchEvent struct {
+	Time syscall.Timeval
+	Code int // E.g.: SwLid
+	On   bool
+}
+
+// LedEvent reports a LED being turned on or off.
+type LedEvent struct {
+	Time syscall.Timeval
+	Code int // E.g.: LedCapsLock
+	On   bool
+}
+
+// MiscEvent carries miscellaneous data, such as scan codes.
+type MiscEvent struct {
+	Time  syscall.Timeval
+	Code  int // E.g.: MiscScan
+	Value int32
+}
+
+// SyncEvent separates the events into frames.
+type SyncEvent struct {
+	Time syscall.Timeval
+	Code int // E.g.: SynReport or SynDropped
+}
+
+// FFStatusEvent reports the status of a force feedback effect.
+type FFStatusEvent struct {
+	Time   syscall.Timeval
+	Effect int // Id of the effect.
+	Status int // FFStatusStopped or FFStatusPlaying
+}
+
+// Raw returns the event itself.
+func (e Event) Raw() Event { return e }
+
+// Raw returns the event as read from the device.
+func (e KeyEvent) Raw() Event { return raw(e.Time, EvKeys, e.Code, int32(e.State)) }
+
+// Raw returns the event as read from the device.
+func (e RelEvent) Raw() Event { return raw(e.Time, EvRelative, e.Code, e.Value) }
+
+// Raw returns the event as read from the device.
+func (e AbsEvent) Raw() Event { return raw(e.Time, EvAbsolute, e.Code, e.Value) }
+
+// Raw returns the event as read from the device.
+func (e SwitchEvent) Raw() Event { return raw(e.Time, EvSwitch, e.Code, boolValue(e.On)) }
+
+// Raw returns the event as read from the device.
+func (e LedEvent) Raw() Event { return raw(e.Time, EvLed, e.Code, boolValue(e.On)) }
+
+// Raw returns the event as read from the device.
+func (e MiscEvent) Raw() Event { return raw(e.Time, EvMisc, e.Code, e.Value) }
+
+// Raw returns the event as read from the device.
+func (e SyncEvent) Raw() Event { return raw(e.Time, EvSync, e.Code, 0) }
+
+// Raw returns the event as read from the device.
+func (e FFStatusEvent) Raw() Event {
+	return raw(e.Time, EvForceFeedbackStatus, e.Effect, int32(e.Status))
+}
+
+func raw(t syscall.Timeval, evtype, code int, value int32) Event {
+	return Event{Time: t, Type: uint16(evtype), Code: uint16(code), Value: value}
+}
+
+func boolValue(on bool) int32 {
+	if on {
+		return 1
+	}
+	return 0
+}
+
+// Decode returns the event as one of the concrete event types.
+// The timestamp is kept. Events of other types are returned unchanged.
+// Use Device.Decode to have AbsEvent.Info filled in.
+func (e Event) Decode() TypedEvent {
+	code := int(e.Code)
+
+	switch e.Type {
+	case EvKeys:
+		return KeyEvent{Time: e.Time, Code: code, State: KeyState(e.Value)}
+	case EvRelative:
+		return RelEvent{Time: e.Time, Code: code, Value: e.Value}
+	case EvAbsolute:
+		return AbsEvent{Time: e.Time, Code: code, Value: e.Value}
+	case EvSwitch:
+		return SwitchEvent{Time: e.Time, Code: code, On: e.Value != 0}
+	case EvLed:
+		return LedEvent{Time: e.Time, Code: code, On: e.Value != 0}
+	case EvMisc:
+		return MiscEvent{Time: e.Time, Code: code, Value: e.Value}
+	case EvSync:
+		return SyncEvent{Time: e.Time, Code: code}
+	case EvForceFeedbackStatus:
+		return FFStatusEvent{Time: e.Time, Effect: code, Status: int(e.Value)}
+	}
+
+	return e
+}
+
+// Decode works like Event.Decode, but fills in AbsEvent.Info with the
+// axis information of this device. The information is queried once per
+// axis and cached, so later changes to the range are not noticed.
+func (d *Device) Decode(e Event) TypedEvent {
+	te := e.Decode()
+
+	if abs, ok := te.(AbsEvent); ok {
+		abs.Info = d.cachedAbsInfo(abs.Code)
+		abs.Info.Value = abs.Value
+		return abs
+	}
+
+	return te
+}
+
+// cachedAbsInfo returns the axis information, querying the device
+// on first use. A failed query is not cached, so it is tried again.
+func (d *Device) cachedAbsInfo(axis int) AbsInfo {
+	d.absMu.Lock()
+	defer d.absMu.Unlock()
+
+	info, ok := d.absInfo[axis]
+	if !ok {
+		var err error
+		if info, err = d.absoluteInfo(axis); err != nil {
+			return info
+		}
+		if d.absInfo == nil {
+			d.absInfo = make(map[int]AbsInfo)
+		}
+		d.absInfo[axis] = info
+	}
+
+	return info
+}
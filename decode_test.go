@@ -0,0 +1,96 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tv := syscall.NsecToTimeval(1e9 + 5e3)
+
+	tests := []struct {
+		Event Event
+		Want  TypedEvent
+	}{
+		{Event{tv, EvKeys, KeyA, 2}, KeyEvent{tv, KeyA, KeyRepeat}},
+		{Event{tv, EvRelative, RelWheel, -1}, RelEvent{tv, RelWheel, -1}},
+		{Event{tv, EvAbsolute, AbsX, 100}, AbsEvent{Time: tv, Code: AbsX, Value: 100}},
+		{Event{tv, EvSwitch, SwLid, 1}, SwitchEvent{tv, SwLid, true}},
+		{Event{tv, EvLed, LedCapsLock, 0}, LedEvent{tv, LedCapsLock, false}},
+		{Event{tv, EvMisc, MiscScan, 0x70004}, MiscEvent{tv, MiscScan, 0x70004}},
+		{Event{tv, EvSync, SynDropped, 0}, SyncEvent{tv, SynDropped}},
+		{Event{tv, EvForceFeedbackStatus, 3, FFStatusPlaying}, FFStatusEvent{tv, 3, FFStatusPlaying}},
+		{Event{tv, EvPower, 0, 1}, Event{tv, EvPower, 0, 1}},
+	}
+
+	for _, test := range tests {
+		have := test.Event.Decode()
+		if have != test.Want {
+			t.Fatalf("Want %+v, have %+v", test.Want, have)
+		}
+
+		if raw := have.Raw(); raw != test.Event {
+			t.Fatalf("Want raw %v, have %v", test.Event, raw)
+		}
+	}
+}
+
+func TestAbsEventNormalized(t *testing.T) {
+	info := AbsInfo{Minimum: -100, Maximum: 100}
+
+	tests := []struct {
+		Value int32
+		Info  AbsInfo
+		Want  float64
+	}{
+		{-100, info, 0},
+		{0, info, 0.5},
+		{50, info, 0.75},
+		{200, info, 1},
+		{-200, info, 0},
+		{10, AbsInfo{}, 0},
+	}
+
+	for _, test := range tests {
+		e := AbsEvent{Value: test.Value, Info: test.Info}
+		if have := e.Normalized(); have != test.Want {
+			t.Fatalf("%d: want %v, have %v", test.Value, test.Want, have)
+		}
+	}
+}
+
+func TestDeviceDecode(t *testing.T) {
+	dev, _ := pipeDevice(t, NoReader())
+	dev.absInfo = map[int]AbsInfo{AbsX: {Minimum: 0, Maximum: 1000}}
+
+	abs, ok := dev.Decode(Event{Type: EvAbsolute, Code: AbsX, Value: 250}).(AbsEvent)
+	if !ok {
+		t.Fatalf("Want AbsEvent")
+	}
+
+	if abs.Info.Maximum != 1000 || abs.Info.Value != 250 {
+		t.Fatalf("Want cached info, have %+v", abs.Info)
+	}
+
+	if n := abs.Normalized(); n != 0.25 {
+		t.Fatalf("Want 0.25, have %v", n)
+	}
+
+	// The pipe cannot be queried, so unknown axes have no range.
+	abs = dev.Decode(Event{Type: EvAbsolute, Code: AbsY, Value: 1}).(AbsEvent)
+	if abs.Normalized() != 0 {
+		t.Fatalf("Want no range for AbsY, have %+v", abs.Info)
+	}
+
+	// The failed query is tried again on the next event.
+	if _, ok := dev.absInfo[AbsY]; ok {
+		t.Fatalf("Want no cached info for AbsY")
+	}
+
+	if _, ok := dev.Decode(Event{Type: EvKeys, Code: KeyA, Value: 1}).(KeyEvent); !ok {
+		t.Fatalf("Want KeyEvent")
+	}
+}
@@ -0,0 +1,35 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+// Description describes the identity and capabilities of a device.
+// It holds everything needed to create a virtual copy of the device.
+type Description struct {
+	Name string          // See Device.Name().
+	Phys string          // See Device.Path().
+	Uniq string          // See Device.Serial().
+	Id   Id              // See Device.Id().
+	Abs  map[int]AbsInfo // Axis information for each absolute axis in Capabilities.Absolute.
+	Capabilities
+}
+
+// Describe queries the identity and capabilities of the device.
+func (d *Device) Describe() *Description {
+	desc := &Description{
+		Name:         d.Name(),
+		Phys:         d.Path(),
+		Uniq:         d.Serial(),
+		Id:           d.Id(),
+		Capabilities: d.Capabilities(),
+		Abs:          make(map[int]AbsInfo),
+	}
+
+	for axis := 0; axis < desc.Absolute.Len(); axis++ {
+		if desc.Absolute.Test(axis) {
+			desc.Abs[axis] = d.AbsoluteInfo(axis)
+		}
+	}
+
+	return desc
+}
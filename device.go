@@ -4,43 +4,276 @@
 package evdev
 
 import (
-	"fmt"
+	"bytes"
+	"errors"
+	"io"
 	"os"
+	"sync"
+	"syscall"
 	"unsafe"
 )
 
 const eventBufferSize = 64
 
+// ErrClosed is returned when using a device which has been closed.
+var ErrClosed = errors.New("device closed")
+
 // Device represents a single device node.
+//
+// Its methods are safe for concurrent use. Once the device has been
+// closed, the methods sending events to the device report ErrClosed
+// and the query methods return zero values.
 type Device struct {
-	fd     *os.File
-	Inbox  chan Event // Channel exposing incoming events.
-	Outbox chan Event // Channel for outgoing events.
+	fd           *os.File
+	nonblock     bool // Device.ReadBatch does not wait for events.
+	backpressure Backpressure
+	stats        stats
+	mu           sync.RWMutex    // Guards closed against pending sends.
+	closed       bool            // Set when Close is called.
+	closeOnce    sync.Once       // Runs the shutdown sequence.
+	closeErr     error           // Result of the shutdown sequence.
+	done         chan struct{}   // Closed when Close is called.
+	readers      sync.WaitGroup  // Tracks the goroutines filling Inbox.
+	writers      sync.WaitGroup  // Tracks the goroutine draining Outbox.
+	errMu        sync.Mutex      // Guards readErr and writeErr.
+	readErr      error           // Reason the reader goroutine stopped.
+	writeErr     error           // Last error writing events from Outbox.
+	absMu        sync.Mutex      // Guards absInfo.
+	absInfo      map[int]AbsInfo // Axis information cached by Device.Decode.
+	dec          *Decoder        // Decodes blocking reads from fd.
+	enc          *Encoder        // Encodes writes to fd.
+	Inbox        chan Event      // Channel exposing incoming events. This is nil if the reader is disabled.
+	Outbox       chan Event      // Channel for outgoing events, no longer drained after Close. This is nil if the writer is disabled.
+}
+
+// Option configures a device opened through Open or NewDeviceFromFile.
+type Option func(*options)
+
+// options holds the settings applied by a list of Options.
+type options struct {
+	flag         int          // Flags passed to os.OpenFile.
+	inbox        int          // Buffer size for Device.Inbox.
+	reader       bool         // Start the goroutine filling Device.Inbox.
+	writer       bool         // Start the goroutine draining Device.Outbox.
+	backpressure Backpressure // Policy for a full Device.Inbox.
+}
+
+// newOptions applies the given list of options to the defaults.
+func newOptions(opts []Option) options {
+	o := options{
+		flag:   os.O_RDWR,
+		inbox:  eventBufferSize,
+		reader: true,
+		writer: true,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// ReadOnly opens the device for reading only. This suffices to
+// receive events and query the device, but not to send events to it.
+// It implies NoWriter.
+func ReadOnly() Option {
+	return func(o *options) {
+		o.flag = o.flag&^(os.O_RDWR|os.O_WRONLY) | os.O_RDONLY
+		o.writer = false
+	}
+}
+
+// NonBlocking opens the device in non-blocking mode.
+// Device.ReadBatch then returns immediately when no events are pending.
+func NonBlocking() Option {
+	return func(o *options) {
+		o.flag |= syscall.O_NONBLOCK
+	}
+}
+
+// InboxSize sets the buffer size of the Device.Inbox channel.
+// It defaults to 64 events.
+func InboxSize(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.inbox = n
+		}
+	}
+}
+
+// NoReader disables the goroutine which reads events from the device.
+// Device.Inbox will be nil.
+func NoReader() Option {
+	return func(o *options) {
+		o.reader = false
+	}
+}
+
+// NoWriter disables the goroutine which writes events to the device.
+// Device.Outbox will be nil and events sent by the Device methods
+// are written to the device directly.
+func NoWriter() Option {
+	return func(o *options) {
+		o.writer = false
+	}
 }
 
 // Open opens a new device for the given node name.
 // This can be anything listed in /dev/input/event[x].
-func Open(node string) (dev *Device, err error) {
-	dev = new(Device)
-	dev.fd, err = os.OpenFile(node, os.O_RDWR, 0)
-	dev.Inbox = make(chan Event, eventBufferSize)
-	dev.Outbox = make(chan Event, 1)
+//
+// By default the device is opened for reading and writing and two
+// goroutines are started to service Device.Inbox and Device.Outbox.
+// This can be changed through the given options.
+func Open(node string, opts ...Option) (*Device, error) {
+	o := newOptions(opts)
+
+	fd, err := os.OpenFile(node, o.flag, 0)
+	if err != nil {
+		return nil, err
+	}
 
-	go dev.pollIn()
-	go dev.pollOut()
-	return
+	return newDevice(fd, o), nil
 }
 
-// Close closes the underlying device node.
-func (d *Device) Close() (err error) {
-	d.Release()
+// NewDeviceFromFile creates a device for the given, already opened node.
+// This allows the use of file descriptors received from elsewhere;
+// such as from a privileged helper process or through socket activation.
+//
+// The device takes ownership of the file, which is closed by Device.Close.
+// The ReadOnly option has no effect, as the file is already open.
+// A file opened for reading only implies NoWriter. The NonBlocking
+// option only affects Device.ReadBatch. A file in blocking mode is
+// switched to non-blocking mode, so Close can interrupt a pending read.
+func NewDeviceFromFile(f *os.File, opts ...Option) (*Device, error) {
+	if f == nil {
+		return nil, errors.New("nil file")
+	}
+
+	o := newOptions(opts)
+
+	sc, err := f.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	var flag int
+	cerr := sc.Control(func(fd uintptr) {
+		flag, err = fcntl(fd, syscall.F_GETFL, 0)
+	})
+
+	if cerr != nil {
+		return nil, cerr
+	}
 
-	if d.fd != nil {
-		err = d.fd.Close()
-		d.fd = nil
+	if err != nil {
+		return nil, err
+	}
+
+	if flag&syscall.O_ACCMODE == syscall.O_RDONLY {
+		o.writer = false
+	}
+
+	if flag&syscall.O_NONBLOCK == 0 {
+		if f, err = pollable(f); err != nil {
+			return nil, err
+		}
 	}
 
-	return
+	return newDevice(f, o), nil
+}
+
+// pollable returns a non-blocking copy of f, which is managed by the
+// runtime poller. The copy replaces f, which is closed.
+func pollable(f *os.File) (*os.File, error) {
+	sc, err := f.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	var nfd int
+	cerr := sc.Control(func(fd uintptr) {
+		nfd, err = syscall.Dup(int(fd))
+	})
+
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	syscall.CloseOnExec(nfd)
+	if err := syscall.SetNonblock(nfd, true); err != nil {
+		syscall.Close(nfd)
+		return nil, err
+	}
+
+	f.Close()
+	return os.NewFile(uintptr(nfd), f.Name()), nil
+}
+
+// newDevice creates the device and starts its goroutines.
+func newDevice(fd *os.File, o options) *Device {
+	dev := &Device{
+		fd:           fd,
+		dec:          NewDecoder(fd),
+		enc:          NewEncoder(fd),
+		nonblock:     o.flag&syscall.O_NONBLOCK != 0,
+		backpressure: o.backpressure,
+		done:         make(chan struct{}),
+	}
+
+	if o.reader {
+		dev.Inbox = make(chan Event, o.inbox)
+		dev.readers.Add(1)
+		go dev.pollIn()
+	}
+
+	if o.writer {
+		dev.Outbox = make(chan Event, 1)
+		dev.writers.Add(1)
+		go dev.pollOut()
+	}
+
+	return dev
+}
+
+// Close closes the underlying device node.
+//
+// Events still pending in Device.Outbox are written to the device first.
+// Later writes through the Device methods are rejected with ErrClosed,
+// while sends on Device.Outbox block forever. If writing events from
+// Device.Outbox failed, Close returns the last such error.
+// A pending read is interrupted and Device.Inbox is closed, once any
+// events already read have been delivered or the consumer stops
+// receiving them. Close returns once both goroutines have stopped.
+//
+// It is safe to call Close more than once and from several
+// goroutines. All calls return the result of the first one.
+func (d *Device) Close() error {
+	d.closeOnce.Do(func() {
+		// Wait for pending sends and reject new ones.
+		d.mu.Lock()
+		d.closed = true
+		d.mu.Unlock()
+
+		close(d.done)
+		d.writers.Wait()
+
+		d.Release()
+		d.closeErr = d.fd.Close()
+		d.readers.Wait()
+
+		if d.closeErr == nil {
+			d.errMu.Lock()
+			d.closeErr = d.writeErr
+			d.errMu.Unlock()
+		}
+	})
+
+	return d.closeErr
 }
 
 // Grab attempts to gain exclusive access to this device.
@@ -56,12 +289,12 @@ func (d *Device) Close() (err error) {
 // events, we may lock ourselves out of the system
 // and a hard reset is required to restore it.
 func (d *Device) Grab() bool {
-	return ioctl(d.fd.Fd(), _EVIOCGRAB, 1) == nil
+	return d.ioctl(_EVIOCGRAB, 1) == nil
 }
 
 // Release releases a lock, previously obtained through `Device.Grab`.
 func (d *Device) Release() bool {
-	return ioctl(d.fd.Fd(), _EVIOCGRAB, 0) == nil
+	return d.ioctl(_EVIOCGRAB, 0) == nil
 }
 
 // Test takes a bitset and a list of constants
@@ -81,25 +314,19 @@ func (d *Device) Release() bool {
 //
 //	if dev.Test(dev.RelativeAxes(), RelX, RelY, RelZ) {
 func (d *Device) Test(set Bitset, values ...int) bool {
-	var count int
-
-	for i := range values {
-		for n := 0; n < set.Len(); n++ {
-			if n == values[i] && set.Test(n) {
-				count++
-				break
-			}
+	for _, v := range values {
+		if !set.Test(v) {
+			return false
 		}
 	}
-
-	return count == len(values)
+	return true
 }
 
 // Name returns the name of the device.
 func (d *Device) Name() string {
 	var str [256]byte
-	ioctl(d.fd.Fd(), _EVIOCGNAME(256), unsafe.Pointer(&str[0]))
-	return string(str[:])
+	d.ioctl(_EVIOCGNAME(256), unsafe.Pointer(&str[0]))
+	return cstring(str[:])
 }
 
 // Path returns the physical path of the device.
@@ -125,23 +352,23 @@ func (d *Device) Name() string {
 // the multimedia function keys on a second interface.
 func (d *Device) Path() string {
 	var str [256]byte
-	ioctl(d.fd.Fd(), _EVIOCGPHYS(len(str)), unsafe.Pointer(&str[0]))
-	return string(str[:])
+	d.ioctl(_EVIOCGPHYS(len(str)), unsafe.Pointer(&str[0]))
+	return cstring(str[:])
 }
 
 // Serial returns the unique serial code for the device.
 // Most devices do not have this and will return an empty string.
 func (d *Device) Serial() string {
 	var str [256]byte
-	ioctl(d.fd.Fd(), _EVIOCGUNIQ(len(str)), unsafe.Pointer(&str[0]))
-	return string(str[:])
+	d.ioctl(_EVIOCGUNIQ(len(str)), unsafe.Pointer(&str[0]))
+	return cstring(str[:])
 }
 
 // Version returns version information for the device driver.
 // These being major, minor and revision numbers.
 func (d *Device) Version() (int, int, int) {
 	var version uint32
-	err := ioctl(d.fd.Fd(), _EVIOCGVERSION, unsafe.Pointer(&version))
+	err := d.ioctl(_EVIOCGVERSION, unsafe.Pointer(&version))
 	if err != nil {
 		return 0, 0, 0
 	}
@@ -154,53 +381,282 @@ func (d *Device) Version() (int, int, int) {
 // Id returns the device identity.
 func (d *Device) Id() Id {
 	var id Id
-	ioctl(d.fd.Fd(), _EVIOCGID, unsafe.Pointer(&id))
+	d.ioctl(_EVIOCGID, unsafe.Pointer(&id))
 	return id
 }
 
 // pollIn polls the device for incoming events.
 // We can receive many events with a single read.
 // This is why the outgoing event channel has a large buffer.
+//
+// Events are queued according to the device's backpressure policy.
 func (d *Device) pollIn() {
-	defer close(d.Inbox)
+	defer d.readers.Done()
 
-	var e Event
+	var q *coalescer
+	if d.backpressure == BackpressureCoalesce {
+		d.readers.Add(1)
+		q = newCoalescer(d.Inbox, d.done, &d.readers)
+		defer q.close()
+	} else {
+		defer close(d.Inbox)
+	}
 
-	size := int(unsafe.Sizeof(e))
-	buf := make([]byte, size*eventBufferSize)
+	evt := make([]Event, eventBufferSize)
 
 	for {
-		n, err := d.fd.Read(buf)
+		n, err := d.read(evt, false)
 		if err != nil {
+			d.stopReader(err)
 			return
 		}
 
-		evt := (*(*[1<<27 - 1]Event)(unsafe.Pointer(&buf[0])))[:n/size]
+		for _, e := range evt[:n] {
+			if e.Type == EvSync && e.Code == SynDropped {
+				d.stats.kernelDropped.Add(1)
+			}
 
-		for n = range evt {
-			d.Inbox <- evt[n]
+			if q != nil {
+				merged, dropped := q.push(e)
+				d.stats.coalesced.Add(merged)
+				d.stats.dropped.Add(dropped)
+			} else if !d.deliver(e) {
+				d.stopReader(ErrClosed)
+				return
+			}
 		}
 	}
 }
 
+// stopReader records the reason the reader goroutine stopped.
+func (d *Device) stopReader(err error) {
+	d.errMu.Lock()
+	d.readErr = err
+	d.errMu.Unlock()
+}
+
+// Err returns the reason Device.Inbox was closed. This is ErrClosed
+// after a call to Close, or the read error which stopped the reader.
+// E.g.: errors.Is(err, syscall.ENODEV) holds once the device is unplugged.
+// This returns nil while the reader is running.
+func (d *Device) Err() error {
+	d.errMu.Lock()
+	defer d.errMu.Unlock()
+	return d.readErr
+}
+
+// ReadBatch reads pending events from the device into the given buffer
+// and returns the number of events read. The events are decoded
+// directly into the buffer, without further allocations or copies.
+//
+// This blocks until at least one event is available. If the device
+// was opened with the NonBlocking option, this returns 0 events and
+// a nil error when none are pending.
+//
+// ReadBatch can only be used on devices opened with the NoReader option.
+// Otherwise the reader goroutine consumes the events.
+func (d *Device) ReadBatch(buf []Event) (int, error) {
+	if d.Inbox != nil {
+		return 0, errors.New("read batch: device has a reader; open it with NoReader")
+	}
+
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	return d.read(buf, d.nonblock)
+}
+
+// ReadEvents reads incoming events into buf and returns the number of
+// events read. Unlike ReadBatch, this always waits for at least one event.
+// If the reader is enabled, the events are taken from Device.Inbox.
+// Once the reader stopped, this returns the error reported by Device.Err.
+// It implements EventSource.
+func (d *Device) ReadEvents(buf []Event) (int, error) {
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	if d.Inbox == nil {
+		return d.read(buf, false)
+	}
+
+	e, ok := <-d.Inbox
+	if !ok {
+		if err := d.Err(); err != nil {
+			return 0, err
+		}
+		return 0, ErrClosed
+	}
+
+	buf[0] = e
+	n := 1
+
+	for n < len(buf) {
+		select {
+		case e, ok := <-d.Inbox:
+			if !ok {
+				return n, nil
+			}
+			buf[n] = e
+			n++
+		default:
+			return n, nil
+		}
+	}
+
+	return n, nil
+}
+
+// read reads events from the device into buf.
+// If nonblock is set, it does not wait for events.
+func (d *Device) read(buf []Event, nonblock bool) (int, error) {
+	if !nonblock {
+		n, err := d.dec.ReadEvents(buf)
+		if errors.Is(err, os.ErrClosed) {
+			return 0, ErrClosed
+		}
+		return n, err
+	}
+
+	sc, err := d.fd.SyscallConn()
+	if err != nil {
+		return 0, ErrClosed
+	}
+
+	data := nativeABI.eventBuffer(buf)
+
+	var n int
+	cerr := sc.Read(func(fd uintptr) bool {
+		n, err = syscall.Read(int(fd), data)
+		return true
+	})
+
+	switch {
+	case cerr != nil:
+		return 0, ErrClosed
+	case err == syscall.EAGAIN:
+		return 0, nil
+	case err == nil && n == 0:
+		return 0, io.EOF
+	case err != nil:
+		return 0, err
+	}
+
+	return nativeABI.decodeEvents(buf, data[:n]), nil
+}
+
 // pollOut polls the outbox for pending messages.
 // These are then sent to the device.
+//
+// Once the device is closed, messages still pending
+// in the outbox are written before this returns.
+// Write errors are kept for Device.Close to return.
 func (d *Device) pollOut() {
-	defer close(d.Outbox)
-
-	var e Event
-	size := int(unsafe.Sizeof(e))
+	defer d.writers.Done()
 
-	for msg := range d.Outbox {
-		buf := (*(*[1<<27 - 1]byte)(unsafe.Pointer(&msg)))[:size]
+	for {
+		select {
+		case msg := <-d.Outbox:
+			d.writeOut(msg)
 
-		n, err := d.fd.Write(buf)
-		if err != nil {
-			return
+		case <-d.done:
+			for {
+				select {
+				case msg := <-d.Outbox:
+					d.writeOut(msg)
+				default:
+					return
+				}
+			}
 		}
+	}
+}
 
-		if n < size {
-			fmt.Fprintf(os.Stderr, "poll outbox: short write\n")
-		}
+// writeOut writes an event from the outbox and records a failure.
+func (d *Device) writeOut(e Event) {
+	if err := d.write(e); err != nil {
+		d.errMu.Lock()
+		d.writeErr = err
+		d.errMu.Unlock()
+	}
+}
+
+// WriteEvents sends the given events to the device.
+//
+// They are queued in Device.Outbox if the writer is enabled
+// and written directly otherwise. This returns ErrClosed if
+// the device has been closed. Errors writing queued events are
+// returned by Device.Close.
+func (d *Device) WriteEvents(events ...Event) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return ErrClosed
+	}
+
+	if d.Outbox == nil {
+		return d.write(events...)
+	}
+
+	for _, e := range events {
+		d.Outbox <- e
+	}
+
+	return nil
+}
+
+// send sends the given event to the device.
+// See Device.WriteEvents.
+func (d *Device) send(e Event) error {
+	return d.WriteEvents(e)
+}
+
+// write writes the given events to the device.
+func (d *Device) write(events ...Event) error {
+	return d.enc.Encode(events...)
+}
+
+// ioctl performs the given ioctl on the device node.
+// This returns ErrClosed if the device has been closed.
+func (d *Device) ioctl(name uintptr, data interface{}) error {
+	sc, err := d.fd.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	cerr := sc.Control(func(fd uintptr) {
+		err = ioctl(fd, name, data)
+	})
+
+	if cerr != nil {
+		return ErrClosed
+	}
+
+	return err
+}
+
+// rawFd returns the file descriptor of the device node.
+// Unlike os.File.Fd, this does not put the file into blocking mode.
+func (d *Device) rawFd() (int, error) {
+	sc, err := d.fd.SyscallConn()
+	if err != nil {
+		return -1, err
+	}
+
+	fd := -1
+	err = sc.Control(func(v uintptr) {
+		fd = int(v)
+	})
+
+	return fd, err
+}
+
+// cstring returns the NUL-terminated string held in the given buffer.
+func cstring(buf []byte) string {
+	if n := bytes.IndexByte(buf, 0); n >= 0 {
+		buf = buf[:n]
 	}
+	return string(buf)
 }
@@ -0,0 +1,398 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// pipeDevice creates a device reading from a pipe. It returns the
+// device and the write end of the pipe, through which events can be fed.
+func pipeDevice(t testing.TB, opts ...Option) (*Device, *os.File) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := NewDeviceFromFile(r, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		w.Close()
+		dev.Close()
+	})
+
+	return dev, w
+}
+
+// writeEvents writes the given events to w in their native layout.
+func writeEvents(t testing.TB, w *os.File, events ...Event) {
+	if _, err := w.Write(eventBytes(events)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenOptions(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "event99")); err == nil {
+		t.Fatalf("Want error for missing node")
+	}
+
+	node := filepath.Join(t.TempDir(), "event0")
+	if err := os.WriteFile(node, nil, 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := Open(node, ReadOnly(), NonBlocking(), InboxSize(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer dev.Close()
+
+	if dev.Outbox != nil {
+		t.Fatalf("Want no writer for read-only device")
+	}
+
+	if cap(dev.Inbox) != 8 {
+		t.Fatalf("Want inbox of size 8, have %d", cap(dev.Inbox))
+	}
+}
+
+func TestNewDeviceFromFile(t *testing.T) {
+	dev, w := pipeDevice(t)
+
+	if dev.Outbox != nil {
+		t.Fatalf("Want no writer for read-only file")
+	}
+
+	want := []Event{
+		{Type: EvKeys, Code: KeyA, Value: 1},
+		{Type: EvSync, Code: SynReport},
+	}
+
+	writeEvents(t, w, want...)
+
+	for i, w := range want {
+		if have := <-dev.Inbox; have != w {
+			t.Fatalf("Event %d: Want %+v, have %+v", i, w, have)
+		}
+	}
+
+	dev, _ = pipeDevice(t, NoReader(), NoWriter())
+	if dev.Inbox != nil || dev.Outbox != nil {
+		t.Fatalf("Want neither reader nor writer")
+	}
+}
+
+// socketDevice creates a device on one end of a socket pair.
+// It returns the device and the other end of the pair, through
+// which events can be fed and events written by the device read.
+func socketDevice(t testing.TB, opts ...Option) (*Device, *os.File) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fd := range fds {
+		if err := syscall.SetNonblock(fd, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	peer := os.NewFile(uintptr(fds[1]), "peer")
+	dev, err := NewDeviceFromFile(os.NewFile(uintptr(fds[0]), "device"), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		peer.Close()
+		dev.Close()
+	})
+
+	return dev, peer
+}
+
+// readEvents reads events from r until it is closed.
+// The events are sent on the returned channel.
+func readEvents(r *os.File) <-chan Event {
+	c := make(chan Event, 1024)
+
+	go func() {
+		defer close(c)
+
+		buf := make([]Event, 64)
+		data := eventBytes(buf)
+		size := len(data) / len(buf)
+
+		var pending int
+		for {
+			n, err := r.Read(data[pending:])
+			if err != nil {
+				return
+			}
+
+			pending += n
+			for _, e := range buf[:pending/size] {
+				c <- e
+			}
+
+			copy(data, data[pending/size*size:pending])
+			pending %= size
+		}
+	}()
+
+	return c
+}
+
+// closeWithin calls Close on the device and fails if it does not
+// return within a reasonable amount of time.
+func closeWithin(t *testing.T, dev *Device) {
+	done := make(chan error, 1)
+	go func() { done <- dev.Close() }()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Close did not return")
+	}
+
+	// Inbox must be closed once Close returns.
+	if dev.Inbox != nil {
+		for range dev.Inbox {
+		}
+	}
+}
+
+func TestCloseUnblocksRead(t *testing.T) {
+	for _, p := range []Backpressure{BackpressureBlock, BackpressureCoalesce} {
+		dev, _ := pipeDevice(t, WithBackpressure(p))
+		closeWithin(t, dev)
+	}
+}
+
+func TestCloseUnblocksBlockingFile(t *testing.T) {
+	var fds [2]int
+	if err := syscall.Pipe(fds[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	w := os.NewFile(uintptr(fds[1]), "w")
+	defer w.Close()
+
+	dev, err := NewDeviceFromFile(os.NewFile(uintptr(fds[0]), "r"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Give the reader a chance to block in read(2).
+	time.Sleep(10 * time.Millisecond)
+	closeWithin(t, dev)
+}
+
+func TestCloseStalledConsumer(t *testing.T) {
+	for _, p := range []Backpressure{BackpressureBlock, BackpressureCoalesce} {
+		dev, pipe := pipeDevice(t, InboxSize(0), WithBackpressure(p))
+		writeEvents(t, pipe,
+			Event{Type: EvRelative, Code: RelX, Value: 1},
+			Event{Type: EvSync, Code: SynReport},
+		)
+
+		// Give the reader a chance to block on the inbox.
+		time.Sleep(10 * time.Millisecond)
+		closeWithin(t, dev)
+	}
+}
+
+func TestCloseDrainsOutbox(t *testing.T) {
+	dev, peer := socketDevice(t)
+	events := readEvents(peer)
+
+	want := []Event{
+		{Type: EvLed, Code: LedCapsLock, Value: 1},
+		{Type: EvLed, Code: LedNumLock, Value: 1},
+		{Type: EvLed, Code: LedScrollLock, Value: 1},
+	}
+
+	if err := dev.WriteEvents(want...); err != nil {
+		t.Fatal(err)
+	}
+
+	closeWithin(t, dev)
+
+	for i, w := range want {
+		if have := <-events; have != w {
+			t.Fatalf("Event %d: Want %+v, have %+v", i, w, have)
+		}
+	}
+
+	if err := dev.WriteEvents(want...); err != ErrClosed {
+		t.Fatalf("Want %v, have %v", ErrClosed, err)
+	}
+}
+
+func TestCloseConcurrent(t *testing.T) {
+	for _, opts := range [][]Option{nil, {NoWriter()}} {
+		dev, peer := socketDevice(t, opts...)
+		events := readEvents(peer)
+
+		go func() {
+			for range events {
+			}
+		}()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				for n := 0; n < 100; n++ {
+					dev.SetEffectGain(n)
+					if err := dev.WriteEvents(Event{Type: EvLed, Code: LedMute}); err == ErrClosed {
+						return
+					}
+				}
+			}()
+
+			go func() {
+				defer wg.Done()
+				time.Sleep(time.Millisecond)
+				dev.Close()
+				dev.Name()
+			}()
+		}
+
+		wg.Wait()
+		closeWithin(t, dev)
+
+		if err := dev.WriteEvents(Event{Type: EvLed, Code: LedMute}); err != ErrClosed {
+			t.Fatalf("Want %v, have %v", ErrClosed, err)
+		}
+
+		if dev.Grab() {
+			t.Fatalf("Want Grab to fail on a closed device")
+		}
+	}
+}
+
+func TestReadBatch(t *testing.T) {
+	dev, w := pipeDevice(t, NoReader())
+
+	want := []Event{
+		{Type: EvRelative, Code: RelX, Value: 3},
+		{Type: EvRelative, Code: RelY, Value: -2},
+		{Type: EvSync, Code: SynReport},
+	}
+	writeEvents(t, w, want...)
+
+	buf := make([]Event, 8)
+	n, err := dev.ReadBatch(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != len(want) {
+		t.Fatalf("Want %d events, have %d", len(want), n)
+	}
+
+	for i := range want {
+		if buf[i] != want[i] {
+			t.Fatalf("Event %d: want %+v, have %+v", i, want[i], buf[i])
+		}
+	}
+
+	dev.Close()
+
+	if _, err := dev.ReadBatch(buf); err != ErrClosed {
+		t.Fatalf("Want ErrClosed after close, have %v", err)
+	}
+}
+
+func TestReadBatchNonBlocking(t *testing.T) {
+	dev, w := pipeDevice(t, NoReader(), NonBlocking())
+
+	buf := make([]Event, 4)
+	n, err := dev.ReadBatch(buf)
+	if n != 0 || err != nil {
+		t.Fatalf("Want no events and no error, have %d, %v", n, err)
+	}
+
+	writeEvents(t, w, Event{Type: EvKeys, Code: KeyA, Value: 1})
+
+	if n, err = dev.ReadBatch(buf); n != 1 || err != nil {
+		t.Fatalf("Want 1 event and no error, have %d, %v", n, err)
+	}
+}
+
+func TestReadBatchWithReader(t *testing.T) {
+	dev, _ := pipeDevice(t)
+
+	if _, err := dev.ReadBatch(make([]Event, 1)); err == nil {
+		t.Fatalf("Want error for device with a reader")
+	}
+}
+
+// benchmarkFrame is a typical frame of a high-rate mouse.
+var benchmarkFrame = []Event{
+	{Type: EvRelative, Code: RelX, Value: 1},
+	{Type: EvRelative, Code: RelY, Value: -1},
+	{Type: EvSync, Code: SynReport},
+}
+
+func BenchmarkReadBatch(b *testing.B) {
+	dev, w := pipeDevice(b, NoReader())
+	buf := make([]Event, len(benchmarkFrame))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		writeEvents(b, w, benchmarkFrame...)
+
+		for have := 0; have < len(benchmarkFrame); {
+			n, err := dev.ReadBatch(buf[have:])
+			if err != nil {
+				b.Fatal(err)
+			}
+			have += n
+		}
+	}
+}
+
+func BenchmarkInbox(b *testing.B) {
+	dev, w := pipeDevice(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		writeEvents(b, w, benchmarkFrame...)
+
+		for range benchmarkFrame {
+			<-dev.Inbox
+		}
+	}
+}
+
+func TestCloseReportsWriteError(t *testing.T) {
+	dev, peer := socketDevice(t, NoReader())
+	peer.Close()
+
+	if err := dev.WriteEvents(Event{Type: EvLed, Code: LedCapsLock, Value: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.Close(); !errors.Is(err, syscall.EPIPE) {
+		t.Fatalf("Want %v, have %v", syscall.EPIPE, err)
+	}
+}
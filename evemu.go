@@ -0,0 +1,493 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Recording holds a device description and the events recorded from it.
+type Recording struct {
+	Node        string // Event node of the device, if known. E.g.: /dev/input/event7
+	Description *Description
+	Events      []Event
+}
+
+// evemuTypes lists the event types with a B: line, in order,
+// along with the number of codes of each type.
+var evemuTypes = []struct {
+	Type  int
+	Count int
+}{
+	{EvSync, EvCount},
+	{EvKeys, KeyCount},
+	{EvRelative, RelCount},
+	{EvAbsolute, AbsCount},
+	{EvMisc, MiscCount},
+	{EvSwitch, SwCount},
+	{EvLed, LedCount},
+	{EvSound, SndCount},
+	{EvForceFeedback, FFCount},
+}
+
+// EvemuWriter writes a device description and its events in the
+// format used by evemu-record. E.g.:
+//
+//	N: Logitech USB Receiver
+//	I: 0003 046d c52b 0111
+//	B: 00 17 00 00 00 00 00 00 00
+//	E: 0.000000 0002 0000 0001	# EV_REL / REL_X                1
+//
+// The event timestamps are written relative to the first event.
+type EvemuWriter struct {
+	w       io.Writer
+	started bool
+	start   time.Duration // Timestamp of the first event.
+	last    time.Duration // Timestamp of the last SynReport.
+}
+
+// NewEvemuWriter creates a writer for the evemu format.
+func NewEvemuWriter(w io.Writer) *EvemuWriter {
+	return &EvemuWriter{w: w}
+}
+
+// WriteDescription writes the N:, I:, P:, B: and A: lines for the device.
+// This should be called once, before writing any events.
+func (ew *EvemuWriter) WriteDescription(desc *Description) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# EVEMU 1.3\n")
+	fmt.Fprintf(&b, "# Input device name: %q\n", desc.Name)
+	fmt.Fprintf(&b, "N: %s\n", desc.Name)
+	fmt.Fprintf(&b, "I: %04x %04x %04x %04x\n", desc.Id.BusType, desc.Id.Vendor, desc.Id.Product, desc.Id.Version)
+
+	for _, line := range evemuMask(desc.Properties, InputPropCount) {
+		fmt.Fprintf(&b, "P:%s\n", line)
+	}
+
+	for _, t := range evemuTypes {
+		for _, line := range evemuMask(desc.Bits(t.Type), t.Count) {
+			fmt.Fprintf(&b, "B: %02x%s\n", t.Type, line)
+		}
+	}
+
+	for axis := range desc.Absolute.All() {
+		info := desc.Abs[axis]
+		fmt.Fprintf(&b, "A: %02x %d %d %d %d %d\n", axis,
+			info.Minimum, info.Maximum, info.Fuzz, info.Flat, info.Resolution)
+	}
+
+	_, err := io.WriteString(ew.w, b.String())
+	return err
+}
+
+// evemuMask formats the bitset as lines of eight hexadecimal bytes.
+// The bytes hold the given number of bits, rounded up to whole lines.
+func evemuMask(bs Bitset, bits int) []string {
+	size := (bits + 63) / 64 * 8
+
+	var lines []string
+	var b strings.Builder
+
+	for i := 0; i < size; i++ {
+		var v byte
+		for n := 0; n < 8; n++ {
+			if bs.Test(i*8 + n) {
+				v |= 1 << uint(n)
+			}
+		}
+
+		fmt.Fprintf(&b, " %02x", v)
+		if i%8 == 7 {
+			lines = append(lines, b.String())
+			b.Reset()
+		}
+	}
+
+	return lines
+}
+
+// WriteEvent writes an E: line for the event.
+func (ew *EvemuWriter) WriteEvent(e Event) error {
+	t := time.Duration(syscall.TimevalToNsec(e.Time))
+	if !ew.started {
+		ew.started = true
+		ew.start, ew.last = t, t
+	}
+
+	rel := t - ew.start
+	line := fmt.Sprintf("E: %d.%06d %04x %04x %04d\t", rel/time.Second,
+		rel%time.Second/time.Microsecond, e.Type, e.Code, e.Value)
+
+	if e.Type == EvSync && e.Code == SynReport {
+		line += fmt.Sprintf("# ------------ SYN_REPORT (%d) ---------- %+dms\n",
+			e.Value, (t-ew.last)/time.Millisecond)
+		ew.last = t
+	} else {
+		line += fmt.Sprintf("# %s / %-20s %d\n", evemuName(TypeName(int(e.Type)), e.Type),
+			evemuName(CodeName(int(e.Type), int(e.Code)), e.Code), e.Value)
+	}
+
+	_, err := io.WriteString(ew.w, line)
+	return err
+}
+
+// evemuName returns the name, or the number if the name is unknown.
+func evemuName(name string, v uint16) string {
+	if name == "" {
+		return fmt.Sprintf("0x%02x", v)
+	}
+	return name
+}
+
+// EvemuReader reads a device description and its events
+// in the format used by evemu-record.
+type EvemuReader struct {
+	scanner *bufio.Scanner
+	line    int
+	desc    *Description
+	pending string // First E: line, read along with the description.
+}
+
+// NewEvemuReader reads the device description from r.
+// The events can then be read with EvemuReader.ReadEvent.
+func NewEvemuReader(r io.Reader) (*EvemuReader, error) {
+	er := &EvemuReader{
+		scanner: bufio.NewScanner(r),
+		desc:    newEmptyDescription(),
+	}
+
+	offsets := make(map[int]int)
+
+	for er.scanner.Scan() {
+		er.line++
+
+		text := er.scanner.Text()
+		kind, rest, ok := evemuLine(text)
+		if !ok {
+			continue
+		}
+
+		var err error
+
+		switch kind {
+		case "N":
+			er.desc.Name = rest
+		case "I":
+			err = parseEvemuId(&er.desc.Id, rest)
+		case "P":
+			err = parseEvemuMask(er.desc.Properties, offsets, -1, rest)
+		case "B":
+			err = parseEvemuBits(er.desc, offsets, rest)
+		case "A":
+			err = parseEvemuAbs(er.desc, rest)
+		case "E":
+			er.pending = text
+			return er, nil
+		}
+
+		if err != nil {
+			return nil, er.errorf("%v", err)
+		}
+	}
+
+	return er, er.scanner.Err()
+}
+
+// newEmptyDescription returns a description with empty
+// bitsets of the full size for every event type.
+func newEmptyDescription() *Description {
+	return &Description{
+		Abs: make(map[int]AbsInfo),
+		Capabilities: Capabilities{
+			Properties:    NewBitset(InputPropCount),
+			Events:        NewBitset(EvCount),
+			Keys:          NewBitset(KeyCount),
+			Relative:      NewBitset(RelCount),
+			Absolute:      NewBitset(AbsCount),
+			Misc:          NewBitset(MiscCount),
+			Switches:      NewBitset(SwCount),
+			LEDs:          NewBitset(LedCount),
+			Sounds:        NewBitset(SndCount),
+			ForceFeedback: NewBitset(FFCount),
+		},
+	}
+}
+
+// errorf returns an error for the current line.
+func (er *EvemuReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("evemu: line %d: %s", er.line, fmt.Sprintf(format, args...))
+}
+
+// evemuLine splits a line into its kind and the rest. E.g.: "N" and
+// "Logitech USB Receiver". Comments and empty lines yield false.
+func evemuLine(text string) (kind, rest string, ok bool) {
+	if i := strings.IndexByte(text, '#'); i >= 0 && !strings.HasPrefix(text, "N:") {
+		text = text[:i]
+	}
+
+	kind, rest, ok = strings.Cut(text, ":")
+	return strings.TrimSpace(kind), strings.TrimSpace(rest), ok
+}
+
+func parseEvemuId(id *Id, s string) error {
+	var v [4]uint16
+	for i, f := range strings.Fields(s) {
+		if i >= len(v) {
+			break
+		}
+
+		n, err := strconv.ParseUint(f, 16, 16)
+		if err != nil {
+			return err
+		}
+		v[i] = uint16(n)
+	}
+
+	*id = Id{BusType: v[0], Vendor: v[1], Product: v[2], Version: v[3]}
+	return nil
+}
+
+// parseEvemuBits reads a B: line. Its first byte is the event type.
+func parseEvemuBits(desc *Description, offsets map[int]int, s string) error {
+	evtype, rest, _ := strings.Cut(s, " ")
+
+	t, err := strconv.ParseUint(evtype, 16, 8)
+	if err != nil {
+		return err
+	}
+
+	// EV_REP and unknown types have no codes we keep.
+	bs := desc.Bits(int(t))
+	if int(t) != EvSync && bs.Len() == 0 {
+		return nil
+	}
+
+	return parseEvemuMask(bs, offsets, int(t), rest)
+}
+
+// parseEvemuMask reads the bytes of a P: or B: line into the bitset.
+// The offsets hold the number of bytes already read for each key.
+func parseEvemuMask(bs Bitset, offsets map[int]int, key int, s string) error {
+	for _, f := range strings.Fields(s) {
+		v, err := strconv.ParseUint(f, 16, 8)
+		if err != nil {
+			return err
+		}
+
+		for n := 0; n < 8; n++ {
+			if v&(1<<uint(n)) != 0 {
+				bs.Set(offsets[key]*8 + n)
+			}
+		}
+		offsets[key]++
+	}
+	return nil
+}
+
+// parseEvemuAbs reads an A: line. Older versions of the
+// format do not list the resolution.
+func parseEvemuAbs(desc *Description, s string) error {
+	f := strings.Fields(s)
+	if len(f) < 5 {
+		return fmt.Errorf("malformed axis %q", s)
+	}
+
+	axis, err := strconv.ParseUint(f[0], 16, 8)
+	if err != nil {
+		return err
+	}
+
+	var v [5]int32
+	for i := 1; i < len(f) && i <= len(v); i++ {
+		n, err := strconv.ParseInt(f[i], 10, 32)
+		if err != nil {
+			return err
+		}
+		v[i-1] = int32(n)
+	}
+
+	desc.Abs[int(axis)] = AbsInfo{Minimum: v[0], Maximum: v[1], Fuzz: v[2], Flat: v[3], Resolution: v[4]}
+	return nil
+}
+
+// Description returns the device description read from the header.
+func (er *EvemuReader) Description() *Description {
+	return er.desc
+}
+
+// ReadEvent returns the next event. It returns io.EOF at the end of the input.
+func (er *EvemuReader) ReadEvent() (Event, error) {
+	for {
+		var text string
+
+		if er.pending != "" {
+			text, er.pending = er.pending, ""
+		} else if er.scanner.Scan() {
+			er.line++
+			text = er.scanner.Text()
+		} else if err := er.scanner.Err(); err != nil {
+			return Event{}, err
+		} else {
+			return Event{}, io.EOF
+		}
+
+		kind, rest, ok := evemuLine(text)
+		if !ok || kind != "E" {
+			continue
+		}
+
+		e, err := parseEvemuEvent(rest)
+		if err != nil {
+			return Event{}, er.errorf("%v", err)
+		}
+		return e, nil
+	}
+}
+
+// parseEvemuEvent reads an E: line. E.g.: 0.012000 0003 0000 0512
+func parseEvemuEvent(s string) (Event, error) {
+	f := strings.Fields(s)
+	if len(f) != 4 {
+		return Event{}, fmt.Errorf("malformed event %q", s)
+	}
+
+	sec, usec, _ := strings.Cut(f[0], ".")
+	ns, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return Event{}, err
+	}
+
+	var us int64
+	if usec != "" {
+		if us, err = strconv.ParseInt(usec, 10, 64); err != nil {
+			return Event{}, err
+		}
+	}
+
+	t, err := strconv.ParseUint(f[1], 16, 16)
+	if err != nil {
+		return Event{}, err
+	}
+
+	c, err := strconv.ParseUint(f[2], 16, 16)
+	if err != nil {
+		return Event{}, err
+	}
+
+	v, err := strconv.ParseInt(f[3], 10, 32)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Time:  syscall.NsecToTimeval(ns*1e9 + us*1e3),
+		Type:  uint16(t),
+		Code:  uint16(c),
+		Value: int32(v),
+	}, nil
+}
+
+// ReadEvemu reads a complete recording in the evemu format.
+func ReadEvemu(r io.Reader) (*Recording, error) {
+	er, err := NewEvemuReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &Recording{Description: er.Description()}
+
+	for {
+		e, err := er.ReadEvent()
+		if err == io.EOF {
+			return rec, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rec.Events = append(rec.Events, e)
+	}
+}
+
+// WriteEvemu writes a complete recording in the evemu format.
+func WriteEvemu(w io.Writer, rec *Recording) error {
+	bw := bufio.NewWriter(w)
+	ew := NewEvemuWriter(bw)
+
+	if err := ew.WriteDescription(rec.Description); err != nil {
+		return err
+	}
+
+	for _, e := range rec.Events {
+		if err := ew.WriteEvent(e); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Record writes the description of the device to w in the evemu
+// format, followed by the events read from the device. It returns
+// nil once the device is closed, or the error which ended the reading.
+// E.g.:
+//
+//	go evdev.Record(dev, file)
+//	...
+//	dev.Close()
+func Record(dev *Device, w io.Writer) error {
+	return record(w, dev.Describe(), dev.Events(context.Background()))
+}
+
+// RecordFrom writes the given description to w in the evemu format,
+// followed by the events read from src, such as a Decoder. It returns
+// nil at the end of src, or the error which ended the reading. E.g.:
+//
+//	err := evdev.RecordFrom(desc, evdev.NewDecoder(os.Stdin), os.Stdout)
+func RecordFrom(desc *Description, src EventSource, w io.Writer) error {
+	return record(w, desc, Events(src))
+}
+
+// record writes the description and the events in the evemu format.
+func record(w io.Writer, desc *Description, events iter.Seq2[Event, error]) error {
+	ew := NewEvemuWriter(w)
+	if err := ew.WriteDescription(desc); err != nil {
+		return err
+	}
+
+	for e, err := range events {
+		if err != nil {
+			return err
+		}
+
+		if err = ew.WriteEvent(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Play reads a recording in the evemu format from r and writes its
+// events to the given device, typically a UInput device created from
+// the recorded description. Each frame is written at once, with the
+// delay between frames taken from the recorded timestamps.
+// Use a Replayer for more control over the playback.
+func Play(r io.Reader, dev EventSink) error {
+	rec, err := ReadEvemu(r)
+	if err != nil {
+		return err
+	}
+
+	s := &Session{Devices: []*Recording{rec}}
+	return NewReplayerTo(s, []EventSink{dev}).Run(context.Background())
+}
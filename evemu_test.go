@@ -0,0 +1,229 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadEvemu(t *testing.T) {
+	data, err := os.ReadFile("testdata/tablet.evemu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := ReadEvemu(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc := rec.Description
+	if desc.Name != "evdev test tablet" {
+		t.Fatalf("Name: want %q, have %q", "evdev test tablet", desc.Name)
+	}
+
+	if want := (Id{BusType: BusUSB, Vendor: 0x1234, Product: 0x5678, Version: 1}); desc.Id != want {
+		t.Fatalf("Id: want %+v, have %+v", want, desc.Id)
+	}
+
+	checks := []struct {
+		Name string
+		Have Bitset
+		Want string
+	}{
+		{"properties", desc.Properties, "{1}"},
+		{"events", desc.Events, "{0, 1, 3}"},
+		{"keys", desc.Keys, "{330, 331}"},
+		{"absolute", desc.Absolute, "{0, 1}"},
+	}
+
+	for _, c := range checks {
+		if c.Have.String() != c.Want {
+			t.Fatalf("%s: want %s, have %s", c.Name, c.Want, c.Have)
+		}
+	}
+
+	if want := (AbsInfo{Maximum: 767, Fuzz: 2, Resolution: 10}); desc.Abs[AbsY] != want {
+		t.Fatalf("AbsY: want %+v, have %+v", want, desc.Abs[AbsY])
+	}
+
+	if len(rec.Events) != 8 {
+		t.Fatalf("Want 8 events, have %d", len(rec.Events))
+	}
+
+	if e := rec.Events[6]; e.Type != EvKeys || e.Code != BtnTouch || e.Value != 0 || e.Time.Usec != 25004 {
+		t.Fatalf("Want BtnTouch release at 25004us, have %v", e)
+	}
+
+	var out bytes.Buffer
+	if err = WriteEvemu(&out, rec); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(out.Bytes(), data) {
+		t.Fatalf("Want round trip, have:\n%s", out.Bytes())
+	}
+}
+
+func TestReadEvemuLegacy(t *testing.T) {
+	text := `# EVEMU 1.0
+N: legacy # device
+I: 0011 0001 0001 ab41
+B: 00 03 00 00 00 00 00 00 00
+B: 14 03 00 00 00 00 00 00 00
+A: 00 -10 10 0 0
+E: 1.5 0001 001e -001
+`
+	rec, err := ReadEvemu(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Description.Name != "legacy # device" {
+		t.Fatalf("Want name with hash, have %q", rec.Description.Name)
+	}
+
+	if info := rec.Description.Abs[AbsX]; info.Minimum != -10 || info.Maximum != 10 {
+		t.Fatalf("Want range -10..10, have %+v", info)
+	}
+
+	if e := rec.Events[0]; e.Value != -1 || e.Time.Sec != 1 || e.Time.Usec != 5 {
+		t.Fatalf("Want KeyA -1 at 1.000005, have %+v", e)
+	}
+
+	for _, bad := range []string{"I: 00zz 0 0 0\n", "A: 00 1 2\n", "E: 0.0 0001 001e\n"} {
+		if _, err := ReadEvemu(strings.NewReader(bad)); err == nil {
+			t.Fatalf("Want error for %q", bad)
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	dev, w := pipeDevice(t)
+	writeEvents(t, w, testFrames...)
+	w.Close()
+
+	// The recording ends when the writer is gone.
+	var out bytes.Buffer
+	if err := Record(dev, &out); err != io.EOF {
+		t.Fatalf("Want io.EOF, have %v", err)
+	}
+
+	rec, err := ReadEvemu(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.Events) != len(testFrames) {
+		t.Fatalf("Want %d events, have %d", len(testFrames), len(rec.Events))
+	}
+}
+
+func TestRecordFrom(t *testing.T) {
+	var raw bytes.Buffer
+	NewEncoder(&raw).Encode(testFrames...)
+
+	desc := testKeyboard()
+
+	// The recording ends with the stream, without an error.
+	var out bytes.Buffer
+	if err := RecordFrom(desc, NewDecoder(&raw), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := ReadEvemu(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Description.Name != desc.Name || !rec.Description.Keys.Test(KeyA) {
+		t.Fatalf("Want %+v, have %+v", desc, rec.Description)
+	}
+
+	if len(rec.Events) != len(testFrames) {
+		t.Fatalf("Want %d events, have %d", len(testFrames), len(rec.Events))
+	}
+}
+
+// frameRecorder collects the frames written to it.
+type frameRecorder struct {
+	mu     sync.Mutex
+	frames [][]Event
+	times  []time.Time
+}
+
+func (r *frameRecorder) WriteEvents(events ...Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frames = append(r.frames, append([]Event(nil), events...))
+	r.times = append(r.times, time.Now())
+	return nil
+}
+
+func TestPlay(t *testing.T) {
+	fd, err := os.Open("testdata/tablet.evemu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	var r frameRecorder
+	if err = Play(fd, &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.frames) != 3 || len(r.frames[0]) != 4 {
+		t.Fatalf("Want 3 frames, have %v", r.frames)
+	}
+
+	if d := r.times[2].Sub(r.times[0]); d < 25*time.Millisecond {
+		t.Fatalf("Want frames spread over 25ms, have %v", d)
+	}
+}
+
+func TestPlayUInput(t *testing.T) {
+	fd, err := os.Open("testdata/tablet.evemu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	er, err := NewEvemuReader(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, dev := uinputDevice(t, er.Description())
+
+	if _, err = fd.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = Play(fd, u); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var n int
+	for frame, err := range dev.Frames(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 3 && frame[0].Code != BtnTouch {
+			t.Fatalf("Want BtnTouch, have %v", frame)
+		}
+		if n == 3 {
+			break
+		}
+	}
+}
@@ -20,32 +20,11 @@ type Event struct {
 	Value int32
 }
 
-// Event types
-const (
-	EvSync                = 0x00 // Synchronisation events.
-	EvKeys                = 0x01 // Absolute binary results, such as keys and buttons.
-	EvRelative            = 0x02 // Relative results, such as the axes on a mouse.
-	EvAbsolute            = 0x03 // Absolute integer results, such as the axes on a joystick or for a tablet.
-	EvMisc                = 0x04 // Miscellaneous uses that didn't fit anywhere else.
-	EvSwitch              = 0x05 // Used to describe stateful binary switches.
-	EvLed                 = 0x11 // LEDs and similar indications.
-	EvSound               = 0x12 // Sound output, such as buzzers.
-	EvRepeat              = 0x14 // Enables autorepeat of keys in the input core.
-	EvForceFeedback       = 0x15 // Sends force-feedback effects to a device.
-	EvPower               = 0x16 // Power management events.
-	EvForceFeedbackStatus = 0x17 // Device reporting of force-feedback effects back to the host.
-	EvMax                 = 0x1f
-	EvCount               = EvMax + 1
-)
-
 // EventTypes determines the device's capabilities.
 // It yields a bitset which can be tested against
 // EvXXX constants to determine which types are supported.
 func (d *Device) EventTypes() Bitset {
-	bs := NewBitset(EvMax)
-	buf := bs.Bytes()
-	ioctl(d.fd.Fd(), _EVIOCGBIT(0, EvMax), unsafe.Pointer(&buf[0]))
-	return bs
+	return d.eventBits(0, EvCount)
 }
 
 // IDs.
@@ -55,3 +34,14 @@ const (
 	IdProduct
 	IdVersion
 )
+
+// eventBytes returns the memory of the given events as a byte slice,
+// in the layout of the kernel's input_event struct.
+func eventBytes(events []Event) []byte {
+	if len(events) == 0 {
+		return nil
+	}
+
+	size := int(unsafe.Sizeof(events[0]))
+	return unsafe.Slice((*byte)(unsafe.Pointer(&events[0])), len(events)*size)
+}
@@ -0,0 +1,277 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"syscall"
+)
+
+// Handler receives events read from a device by an EventLoop.
+// The events slice is reused between calls and is only valid
+// for the duration of the call.
+//
+// A non-nil error means the device can no longer be read; for instance
+// because it was unplugged. The device has then already been removed
+// from the loop, but it is not closed.
+type Handler func(dev *Device, events []Event, err error)
+
+// EventLoop reads events from many devices in a single goroutine.
+// The devices are registered with one epoll instance and read
+// without blocking, in batches. This avoids the two goroutines
+// a Device otherwise needs.
+//
+// Devices must be opened with the NoReader option, so they do not
+// compete with the loop for events. Devices can be added and removed
+// while the loop is running. A device should be removed from the
+// loop before it is closed.
+type EventLoop struct {
+	epfd    int
+	wake    [2]int // Pipe used to interrupt epoll_wait.
+	mu      sync.Mutex
+	entries map[int32]*loopEntry
+	closed  bool
+	done    chan struct{} // Closed when Run returns. Nil if Run is not running.
+	err     error         // Result of closing the loop, once it is stopped.
+}
+
+// loopEntry describes a device registered with the loop.
+type loopEntry struct {
+	dev     *Device
+	fd      int
+	handler Handler
+}
+
+// NewEventLoop creates a new, empty event loop.
+func NewEventLoop() (*EventLoop, error) {
+	epfd, err := syscall.EpollCreate1(syscall.EPOLL_CLOEXEC)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &EventLoop{
+		epfd:    epfd,
+		entries: make(map[int32]*loopEntry),
+	}
+
+	err = syscall.Pipe2(l.wake[:], syscall.O_NONBLOCK|syscall.O_CLOEXEC)
+	if err != nil {
+		syscall.Close(epfd)
+		return nil, err
+	}
+
+	ev := syscall.EpollEvent{Events: syscall.EPOLLIN, Fd: int32(l.wake[0])}
+	err = syscall.EpollCtl(epfd, syscall.EPOLL_CTL_ADD, l.wake[0], &ev)
+	if err != nil {
+		l.closeFds()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Add registers the device with the loop. The handler is called
+// from the loop's goroutine, whenever events have been read.
+func (l *EventLoop) Add(dev *Device, h Handler) error {
+	if dev.Inbox != nil {
+		return errors.New("event loop: device has a reader; open it with NoReader")
+	}
+
+	fd, err := dev.rawFd()
+	if err != nil {
+		return err
+	}
+
+	if err = syscall.SetNonblock(fd, true); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return errors.New("event loop: closed")
+	}
+
+	if _, ok := l.entries[int32(fd)]; ok {
+		return errors.New("event loop: device already added")
+	}
+
+	ev := syscall.EpollEvent{Events: syscall.EPOLLIN, Fd: int32(fd)}
+	if err = syscall.EpollCtl(l.epfd, syscall.EPOLL_CTL_ADD, fd, &ev); err != nil {
+		return err
+	}
+
+	l.entries[int32(fd)] = &loopEntry{
+		dev:     dev,
+		fd:      fd,
+		handler: h,
+	}
+
+	return nil
+}
+
+// Remove unregisters the device from the loop.
+// Its handler will not be called again, unless it
+// is currently running in the loop's goroutine.
+func (l *EventLoop) Remove(dev *Device) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for fd, e := range l.entries {
+		if e.dev == dev {
+			delete(l.entries, fd)
+			return syscall.EpollCtl(l.epfd, syscall.EPOLL_CTL_DEL, e.fd, nil)
+		}
+	}
+
+	return errors.New("event loop: unknown device")
+}
+
+// Run reads events from the registered devices and dispatches
+// them to their handlers. It returns when the loop is closed.
+func (l *EventLoop) Run() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return errors.New("event loop: closed")
+	}
+
+	if l.done != nil {
+		l.mu.Unlock()
+		return errors.New("event loop: already running")
+	}
+
+	done := make(chan struct{})
+	l.done = done
+	l.mu.Unlock()
+
+	defer func() {
+		// A loop stopped while running is cleaned up here, since
+		// Stop does not wait for Run to return.
+		l.mu.Lock()
+		if l.closed {
+			l.err = l.closeFds()
+		}
+		l.done = nil
+		l.mu.Unlock()
+		close(done)
+	}()
+
+	events := make([]syscall.EpollEvent, 32)
+	buf := make([]Event, eventBufferSize)
+
+	for {
+		n, err := syscall.EpollWait(l.epfd, events, -1)
+		if err != nil {
+			if err == syscall.EINTR {
+				continue
+			}
+			return err
+		}
+
+		for _, ev := range events[:n] {
+			if l.isClosed() {
+				return nil
+			}
+
+			if ev.Fd == int32(l.wake[0]) {
+				continue
+			}
+
+			l.mu.Lock()
+			e := l.entries[ev.Fd]
+			l.mu.Unlock()
+
+			if e != nil {
+				l.dispatch(e, buf)
+			}
+		}
+	}
+}
+
+// dispatch reads all pending events from the given device
+// and passes them to its handler.
+func (l *EventLoop) dispatch(e *loopEntry, buf []Event) {
+	data := nativeABI.eventBuffer(buf)
+
+	for {
+		n, err := syscall.Read(e.fd, data)
+		if err == syscall.EAGAIN || err == syscall.EINTR {
+			return
+		}
+
+		if err == nil && n == 0 {
+			err = io.EOF
+		}
+
+		if err != nil {
+			l.Remove(e.dev)
+			e.handler(e.dev, nil, err)
+			return
+		}
+
+		e.handler(e.dev, buf[:nativeABI.decodeEvents(buf, data[:n])], nil)
+
+		if n < len(data) {
+			return
+		}
+	}
+}
+
+// Close stops the loop and releases its resources. If the loop is running,
+// this waits for Run to return. The registered devices are not closed.
+//
+// Close must not be called from a handler, since Run cannot return
+// before the handler does. Use Stop instead.
+func (l *EventLoop) Close() error {
+	if done := l.stop(); done != nil {
+		<-done
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Stop stops the loop without waiting for Run to return. Run releases
+// the resources of the loop once the current handler, if any, returns.
+// E.g.: to stop the loop from a handler.
+func (l *EventLoop) Stop() {
+	l.stop()
+}
+
+// stop marks the loop as closed and wakes Run up. It returns the
+// channel closed when Run returns, or nil if Run is not running.
+func (l *EventLoop) stop() chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.closed {
+		l.closed = true
+		if l.done == nil {
+			l.err = l.closeFds()
+		} else {
+			// The wake-up pipe is only closed by Run while holding the lock.
+			syscall.Write(l.wake[1], []byte{0})
+		}
+	}
+	return l.done
+}
+
+// isClosed returns true if Close has been called.
+func (l *EventLoop) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+// closeFds closes the epoll instance and the wake-up pipe.
+func (l *EventLoop) closeFds() error {
+	syscall.Close(l.wake[0])
+	syscall.Close(l.wake[1])
+	return syscall.Close(l.epfd)
+}
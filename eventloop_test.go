@@ -0,0 +1,264 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventLoop(t *testing.T) {
+	l, err := NewEventLoop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		Dev    *Device
+		Events []Event
+		Err    error
+	}
+
+	results := make(chan result, 16)
+	handler := func(dev *Device, events []Event, err error) {
+		results <- result{dev, append([]Event(nil), events...), err}
+	}
+
+	devA, pipeA := pipeDevice(t, NoReader())
+	devB, pipeB := pipeDevice(t, NoReader())
+
+	if err := l.Add(devA, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	ran := make(chan error, 1)
+	go func() { ran <- l.Run() }()
+
+	// Add a device while the loop is running.
+	if err := l.Add(devB, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Add(devB, handler); err == nil {
+		t.Fatalf("Want error when adding a device twice")
+	}
+
+	if dev, _ := pipeDevice(t); l.Add(dev, handler) == nil {
+		t.Fatalf("Want error when adding a device with a reader")
+	}
+
+	frame := []Event{
+		{Type: EvKeys, Code: KeyA, Value: 1},
+		{Type: EvSync, Code: SynReport},
+	}
+
+	for _, w := range []struct {
+		Dev  *Device
+		Pipe *os.File
+	}{{devA, pipeA}, {devB, pipeB}} {
+		writeEvents(t, w.Pipe, frame...)
+
+		r := <-results
+		if r.Dev != w.Dev || r.Err != nil || len(r.Events) != len(frame) || r.Events[0] != frame[0] {
+			t.Fatalf("Unexpected result: %+v", r)
+		}
+	}
+
+	// Events for a removed device are not dispatched.
+	if err := l.Remove(devA); err != nil {
+		t.Fatal(err)
+	}
+
+	writeEvents(t, pipeA, frame...)
+	writeEvents(t, pipeB, frame...)
+
+	if r := <-results; r.Dev != devB {
+		t.Fatalf("Want events from the remaining device, have %+v", r)
+	}
+
+	// Closing the other end ends the device.
+	pipeB.Close()
+
+	if r := <-results; r.Dev != devB || !errors.Is(r.Err, io.EOF) {
+		t.Fatalf("Want EOF, have %+v", r)
+	}
+
+	if err := l.Remove(devB); err == nil {
+		t.Fatalf("Want error removing a device removed by the loop")
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-ran:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run did not return after Close")
+	}
+}
+
+func TestEventLoopStopFromHandler(t *testing.T) {
+	l, err := NewEventLoop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, pipe := pipeDevice(t, NoReader())
+	err = l.Add(dev, func(dev *Device, events []Event, err error) {
+		l.Stop()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ran := make(chan error, 1)
+	go func() { ran <- l.Run() }()
+
+	writeEvents(t, pipe, Event{Type: EvSync, Code: SynReport})
+
+	select {
+	case err := <-ran:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run did not return after Stop from a handler")
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventLoopCloseWhileDispatching(t *testing.T) {
+	l, err := NewEventLoop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	called := make(chan struct{})
+	release := make(chan struct{})
+	dev, pipe := pipeDevice(t, NoReader())
+	err = l.Add(dev, func(dev *Device, events []Event, err error) {
+		close(called)
+		<-release
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ran := make(chan error, 1)
+	go func() { ran <- l.Run() }()
+
+	writeEvents(t, pipe, Event{Type: EvSync, Code: SynReport})
+	<-called
+
+	closed := make(chan error, 1)
+	go func() { closed <- l.Close() }()
+
+	// Close waits for the handler, and thus Run, to return.
+	select {
+	case <-closed:
+		t.Fatalf("Close returned while a handler was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	for _, c := range []chan error{closed, ran} {
+		select {
+		case err := <-c:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Close did not return after the handler")
+		}
+	}
+}
+
+const benchDevices = 32
+
+// benchFrame is the frame written to each device in the benchmarks.
+var benchFrame = []Event{
+	{Type: EvRelative, Code: RelX, Value: 1},
+	{Type: EvRelative, Code: RelY, Value: -1},
+	{Type: EvSync, Code: SynReport},
+}
+
+func BenchmarkEventLoop(b *testing.B) {
+	l, err := NewEventLoop()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	handler := func(dev *Device, events []Event, err error) {
+		for _, e := range events {
+			if e.Type == EvSync {
+				wg.Done()
+			}
+		}
+	}
+
+	pipes := make([]*os.File, benchDevices)
+	for i := range pipes {
+		var dev *Device
+		dev, pipes[i] = pipeDevice(b, NoReader())
+		if err := l.Add(dev, handler); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	go l.Run()
+	defer l.Close()
+
+	data := eventBytes(benchFrame)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		wg.Add(benchDevices)
+		for _, p := range pipes {
+			p.Write(data)
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkGoroutinePerDevice(b *testing.B) {
+	var wg sync.WaitGroup
+
+	pipes := make([]*os.File, benchDevices)
+	for i := range pipes {
+		var dev *Device
+		dev, pipes[i] = pipeDevice(b, NoWriter())
+
+		go func() {
+			for e := range dev.Inbox {
+				if e.Type == EvSync {
+					wg.Done()
+				}
+			}
+		}()
+	}
+
+	data := eventBytes(benchFrame)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		wg.Add(benchDevices)
+		for _, p := range pipes {
+			p.Write(data)
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,379 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// EvtestHeader holds the details printed by evtest before the events.
+type EvtestHeader struct {
+	Driver      [3]int // Version of the input driver: major, minor and revision.
+	Description *Description
+	Repeat      [2]uint // Delay and period of key repeats, if EvRepeat is supported.
+
+	// State holds the current state of keys, switches, LEDs and sounds,
+	// keyed by EvKeys, EvSwitch, EvLed and EvSound. It is optional;
+	// types without a state print none.
+	State map[int]Bitset
+}
+
+// EvtestHeader queries the details printed by evtest.
+func (d *Device) EvtestHeader() *EvtestHeader {
+	h := &EvtestHeader{
+		Description: d.Describe(),
+		State:       make(map[int]Bitset),
+	}
+
+	h.Driver[0], h.Driver[1], h.Driver[2] = d.Version()
+
+	if h.Description.Events.Test(EvRepeat) {
+		h.Repeat[0], h.Repeat[1] = d.RepeatState()
+	}
+	if h.Description.Events.Test(EvKeys) {
+		h.State[EvKeys] = d.KeyState()
+	}
+	if h.Description.Events.Test(EvSwitch) {
+		h.State[EvSwitch] = d.SwitchState()
+	}
+	if h.Description.Events.Test(EvLed) {
+		h.State[EvLed] = d.LEDState()
+	}
+	if h.Description.Events.Test(EvSound) {
+		h.State[EvSound] = d.SoundState()
+	}
+
+	return h
+}
+
+// WriteEvtestHeader writes the header in the format used by evtest,
+// up to and including the "Testing ..." line. E.g.:
+//
+//	Input driver version is 1.0.1
+//	Input device ID: bus 0x3 vendor 0x46d product 0xc52b version 0x111
+//	Input device name: "Logitech USB Receiver"
+//	Supported events:
+//	  Event type 0 (EV_SYN)
+//	  Event type 2 (EV_REL)
+//	    Event code 0 (REL_X)
+//	...
+func WriteEvtestHeader(w io.Writer, h *EvtestHeader) error {
+	bw := bufio.NewWriter(w)
+	desc := h.Description
+	id := desc.Id
+
+	fmt.Fprintf(bw, "Input driver version is %d.%d.%d\n", h.Driver[0], h.Driver[1], h.Driver[2])
+	fmt.Fprintf(bw, "Input device ID: bus 0x%x vendor 0x%x product 0x%x version 0x%x\n",
+		id.BusType, id.Vendor, id.Product, id.Version)
+	fmt.Fprintf(bw, "Input device name: \"%s\"\n", desc.Name)
+	fmt.Fprintf(bw, "Supported events:\n")
+
+	for evtype := range desc.Events.All() {
+		if evtype == EvRepeat {
+			continue
+		}
+
+		fmt.Fprintf(bw, "  Event type %d (%s)\n", evtype, evtestName(TypeName(evtype)))
+		if evtype == EvSync {
+			continue
+		}
+
+		state, hasState := h.State[evtype]
+
+		for code := range desc.Bits(evtype).All() {
+			fmt.Fprintf(bw, "    Event code %d (%s)", code, evtestName(CodeName(evtype, code)))
+			if hasState {
+				fmt.Fprintf(bw, " state %d", boolValue(state.Test(code)))
+			}
+			fmt.Fprintf(bw, "\n")
+
+			if evtype == EvAbsolute {
+				writeEvtestAbs(bw, desc.Abs[code])
+			}
+		}
+	}
+
+	if desc.Events.Test(EvRepeat) {
+		fmt.Fprintf(bw, "Key repeat handling:\n")
+		fmt.Fprintf(bw, "  Repeat type %d (%s)\n", EvRepeat, evtestName(TypeName(EvRepeat)))
+		for code, v := range h.Repeat {
+			fmt.Fprintf(bw, "    Repeat code %d (%s)\n", code, evtestName(CodeName(EvRepeat, code)))
+			fmt.Fprintf(bw, "      Value %6d\n", v)
+		}
+	}
+
+	fmt.Fprintf(bw, "Properties:\n")
+	for prop := range desc.Properties.All() {
+		fmt.Fprintf(bw, "  Property type %d (%s)\n", prop, evtestName(PropName(prop)))
+	}
+
+	fmt.Fprintf(bw, "Testing ... (interrupt to exit)\n")
+	return bw.Flush()
+}
+
+// evtestAbsLabels are the labels of the axis information, padded as by evtest.
+var evtestAbsLabels = [6]string{"Value", "Min  ", "Max  ", "Fuzz ", "Flat ", "Resolution "}
+
+// writeEvtestAbs writes the axis information. Like evtest, the
+// fuzz, flat and resolution are left out if they are zero.
+func writeEvtestAbs(w io.Writer, info AbsInfo) {
+	values := [6]int32{info.Value, info.Minimum, info.Maximum, info.Fuzz, info.Flat, info.Resolution}
+	for i, v := range values {
+		if i < 3 || v != 0 {
+			fmt.Fprintf(w, "      %s %6d\n", evtestAbsLabels[i], v)
+		}
+	}
+}
+
+// evtestName returns the name, or "?" if it is unknown.
+func evtestName(name string) string {
+	if name == "" {
+		return "?"
+	}
+	return name
+}
+
+// FormatEvtest formats the event as evtest prints it, without
+// a trailing newline. E.g.:
+//
+//	Event: time 1700000000.123456, type 1 (EV_KEY), code 30 (KEY_A), value 1
+//	Event: time 1700000000.123456, -------------- SYN_REPORT ------------
+func FormatEvtest(e Event) string {
+	prefix := fmt.Sprintf("Event: time %d.%06d, ", e.Time.Sec, e.Time.Usec)
+	code := evtestName(CodeName(int(e.Type), int(e.Code)))
+
+	if e.Type == EvSync {
+		switch e.Code {
+		case SynMTReport:
+			return prefix + "++++++++++++++ " + code + " ++++++++++++"
+		case SynDropped:
+			return prefix + ">>>>>>>>>>>>>> " + code + " <<<<<<<<<<<<"
+		}
+		return prefix + "-------------- " + code + " ------------"
+	}
+
+	value := strconv.Itoa(int(e.Value))
+	if e.Type == EvMisc && (e.Code == MiscRaw || e.Code == MiscScan) {
+		value = fmt.Sprintf("%02x", uint32(e.Value))
+	}
+
+	return fmt.Sprintf("%stype %d (%s), code %d (%s), value %s", prefix,
+		e.Type, evtestName(TypeName(int(e.Type))), e.Code, code, value)
+}
+
+// ReadEvtest reads the output of evtest, as pasted into bug reports.
+// Lines which are not part of the header or an event, such as the list
+// of devices evtest starts with, are skipped, as is leading whitespace.
+// A log without a header yields an empty description.
+func ReadEvtest(r io.Reader) (*EvtestHeader, []Event, error) {
+	h := &EvtestHeader{
+		Description: newEmptyDescription(),
+		State:       make(map[int]Bitset),
+	}
+	desc := h.Description
+
+	var events []Event
+	evtype, code := -1, -1
+	repeat := false
+
+	scanner := bufio.NewScanner(r)
+	for num := 1; scanner.Scan(); num++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		var err error
+
+		switch {
+		case strings.HasPrefix(line, "Event: time "):
+			var e Event
+			e, err = parseEvtestEvent(strings.TrimPrefix(line, "Event: time "))
+			events = append(events, e)
+
+		case strings.HasPrefix(line, "Input driver version is "):
+			v := strings.TrimPrefix(line, "Input driver version is ")
+			_, err = fmt.Sscanf(v, "%d.%d.%d", &h.Driver[0], &h.Driver[1], &h.Driver[2])
+
+		case strings.HasPrefix(line, "Input device ID: "):
+			id := &desc.Id
+			_, err = fmt.Sscanf(strings.TrimPrefix(line, "Input device ID: "),
+				"bus %v vendor %v product %v version %v", &id.BusType, &id.Vendor, &id.Product, &id.Version)
+
+		case strings.HasPrefix(line, "Input device name: "):
+			name := strings.TrimPrefix(line, "Input device name: ")
+			if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+				name = name[1 : len(name)-1]
+			}
+			desc.Name = name
+
+		case strings.HasPrefix(line, "Event type "), strings.HasPrefix(line, "Repeat type "):
+			_, rest, _ := strings.Cut(line, " type ")
+			repeat = strings.HasPrefix(line, "Repeat")
+			evtype, err = parseEvtestNumber(rest)
+			desc.Events.Set(evtype)
+
+		case strings.HasPrefix(line, "Event code "):
+			code, err = parseEvtestNumber(line[len("Event code "):])
+			desc.Bits(evtype).Set(code)
+
+			if _, s, ok := strings.Cut(line, ") state "); ok && err == nil {
+				err = h.setState(evtype, code, s)
+			}
+
+		case strings.HasPrefix(line, "Repeat code "):
+			code, err = parseEvtestNumber(line[len("Repeat code "):])
+
+		case strings.HasPrefix(line, "Property type "):
+			var prop int
+			prop, err = parseEvtestNumber(line[len("Property type "):])
+			desc.Properties.Set(prop)
+
+		default:
+			err = h.parseValue(line, evtype, code, repeat)
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("evtest: line %d: %v", num, err)
+		}
+	}
+
+	return h, events, scanner.Err()
+}
+
+// setState records the state printed after an event code.
+func (h *EvtestHeader) setState(evtype, code int, s string) error {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+
+	state, ok := h.State[evtype]
+	if !ok {
+		state = NewBitset(h.Description.Bits(evtype).Len())
+		h.State[evtype] = state
+	}
+
+	if v != 0 {
+		state.Set(code)
+	}
+	return nil
+}
+
+// parseValue reads a line of axis information or repeat settings.
+// Other lines are ignored.
+func (h *EvtestHeader) parseValue(line string, evtype, code int, repeat bool) error {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return nil
+	}
+
+	index := -1
+	for i, label := range evtestAbsLabels {
+		if fields[0] == strings.TrimSpace(label) {
+			index = i
+		}
+	}
+	if index < 0 || code < 0 {
+		return nil
+	}
+
+	v, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return err
+	}
+
+	if repeat {
+		if index == 0 && code < len(h.Repeat) {
+			h.Repeat[code] = uint(v)
+		}
+		return nil
+	}
+
+	if evtype != EvAbsolute {
+		return nil
+	}
+
+	info := h.Description.Abs[code]
+	field := [6]*int32{&info.Value, &info.Minimum, &info.Maximum, &info.Fuzz, &info.Flat, &info.Resolution}
+	*field[index] = int32(v)
+	h.Description.Abs[code] = info
+	return nil
+}
+
+// parseEvtestNumber reads the number in front of a name in
+// parentheses. E.g.: 30 (KEY_A)
+func parseEvtestNumber(s string) (int, error) {
+	n, _, _ := strings.Cut(s, " ")
+	return strconv.Atoi(n)
+}
+
+// parseEvtestEvent reads an event line after its "Event: time " prefix.
+func parseEvtestEvent(s string) (Event, error) {
+	stamp, rest, ok := strings.Cut(s, ", ")
+	if !ok {
+		return Event{}, fmt.Errorf("want a time stamp, have %q", s)
+	}
+
+	sec, usec, ok := strings.Cut(stamp, ".")
+	if !ok {
+		return Event{}, fmt.Errorf("bad time stamp %q", stamp)
+	}
+
+	secs, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return Event{}, err
+	}
+	usecs, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil {
+		return Event{}, err
+	}
+
+	e := Event{Time: syscall.NsecToTimeval(secs*1e9 + usecs*1e3)}
+
+	// Synchronization events only carry their name.
+	if !strings.HasPrefix(rest, "type ") {
+		fields := strings.Fields(rest)
+		if len(fields) != 3 {
+			return Event{}, fmt.Errorf("bad event %q", rest)
+		}
+
+		evtype, code, err := ParseCode(fields[1])
+		if err != nil || evtype != EvSync {
+			return Event{}, fmt.Errorf("bad event %q", rest)
+		}
+
+		e.Type, e.Code = EvSync, uint16(code)
+		return e, nil
+	}
+
+	var evtype, code int
+	var value string
+	_, err = fmt.Sscanf(rest, "type %d %s code %d %s value %s", &evtype, new(string), &code, new(string), &value)
+	if err != nil {
+		return Event{}, fmt.Errorf("bad event %q", rest)
+	}
+
+	e.Type, e.Code = uint16(evtype), uint16(code)
+
+	base := 10
+	if e.Type == EvMisc && (e.Code == MiscRaw || e.Code == MiscScan) {
+		base = 16
+	}
+
+	v, err := strconv.ParseInt(value, base, 64)
+	if base == 16 {
+		var u uint64
+		u, err = strconv.ParseUint(value, base, 32)
+		v = int64(int32(u))
+	}
+	if err != nil {
+		return Event{}, err
+	}
+	e.Value = int32(v)
+
+	return e, nil
+}
@@ -0,0 +1,195 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestReadEvtest(t *testing.T) {
+	data, err := os.ReadFile("testdata/keyboard.evtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, events, err := ReadEvtest(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc := h.Description
+	if desc.Name != "AT Translated Set 2 keyboard" || h.Driver != [3]int{1, 0, 1} {
+		t.Fatalf("Unexpected name %q or driver %v", desc.Name, h.Driver)
+	}
+
+	if want := (Id{BusType: BusI8042, Vendor: 1, Product: 1, Version: 0xab83}); desc.Id != want {
+		t.Fatalf("Id: want %+v, have %+v", want, desc.Id)
+	}
+
+	checks := []struct {
+		Name string
+		Have Bitset
+		Want string
+	}{
+		{"events", desc.Events, "{0, 1, 4, 17, 20}"},
+		{"keys", desc.Keys, "{1, 28, 30, 42}"},
+		{"key state", h.State[EvKeys], "{28}"},
+		{"led state", h.State[EvLed], "{0}"},
+	}
+
+	for _, c := range checks {
+		if c.Have.String() != c.Want {
+			t.Fatalf("%s: want %s, have %s", c.Name, c.Want, c.Have)
+		}
+	}
+
+	if h.Repeat != [2]uint{250, 33} {
+		t.Fatalf("Want repeat [250 33], have %v", h.Repeat)
+	}
+
+	if len(events) != 12 {
+		t.Fatalf("Want 12 events, have %d", len(events))
+	}
+
+	tv := syscall.NsecToTimeval(1700000001250000000)
+	if want := (Event{tv, EvMisc, MiscScan, 0x1e}); events[3] != want {
+		t.Fatalf("Want %v, have %v", want, events[3])
+	}
+
+	if e := events[8]; e.Type != EvSync || e.Code != SynDropped {
+		t.Fatalf("Want SYN_DROPPED, have %v", e)
+	}
+
+	// Writing it out again yields the same log.
+	var buf bytes.Buffer
+	if err := WriteEvtestHeader(&buf, h); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range events {
+		buf.WriteString(FormatEvtest(e) + "\n")
+	}
+
+	if buf.String() != string(data) {
+		t.Fatalf("Want:\n%s\nHave:\n%s", data, buf.String())
+	}
+}
+
+func TestWriteEvtestHeader(t *testing.T) {
+	fd, err := os.Open("testdata/tablet.evemu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	rec, err := ReadEvemu(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEvtestHeader(&buf, &EvtestHeader{Description: rec.Description}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `    Event code 1 (ABS_Y)
+      Value      0
+      Min        0
+      Max      767
+      Fuzz       2
+      Resolution      10
+Properties:
+  Property type 1 (INPUT_PROP_DIRECT)
+Testing ... (interrupt to exit)
+`
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Fatalf("Want suffix:\n%s\nHave:\n%s", want, buf.String())
+	}
+
+	// Reading it back restores the axis.
+	h, _, err := ReadEvtest(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have := h.Description.Abs[AbsY]; have != rec.Description.Abs[AbsY] {
+		t.Fatalf("Want %+v, have %+v", rec.Description.Abs[AbsY], have)
+	}
+
+	// Switches print their state, like keys and LEDs.
+	desc := newEmptyDescription()
+	desc.Name = "Lid Switch"
+	desc.Events.Set(EvSync)
+	desc.Events.Set(EvSwitch)
+	desc.Switches.Set(SwLid)
+	desc.Switches.Set(SwHeadphoneInsert)
+
+	state := NewBitset(SwCount)
+	state.Set(SwLid)
+
+	buf.Reset()
+	if err := WriteEvtestHeader(&buf, &EvtestHeader{Description: desc, State: map[int]Bitset{EvSwitch: state}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want = `  Event type 5 (EV_SW)
+    Event code 0 (SW_LID) state 1
+    Event code 2 (SW_HEADPHONE_INSERT) state 0
+`
+	if !strings.Contains(buf.String(), want) {
+		t.Fatalf("Want:\n%s\nHave:\n%s", want, buf.String())
+	}
+
+	if h, _, err = ReadEvtest(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if sw := h.State[EvSwitch]; !sw.Test(SwLid) || sw.Test(SwHeadphoneInsert) {
+		t.Fatalf("Want the lid closed, have %v", sw)
+	}
+}
+
+func TestReadEvtestPasted(t *testing.T) {
+	// Copied from a terminal, with the device list and some indentation.
+	input := `No device specified, trying to scan all of /dev/input/event*
+Available devices:
+/dev/input/event0:	Power Button
+/dev/input/event3:	AT Translated Set 2 keyboard
+Select the device event number [0-3]: 3
+   Event: time 1700000000.000100, type 1 (EV_KEY), code 30 (KEY_A), value 1
+   Event: time 1700000000.000100, -------------- SYN_REPORT ------------
+   Event: time 1700000000.000200, type 4 (EV_MSC), code 4 (MSC_SCAN), value ffffffff
+   Event: time 1700000000.000200, type 99 (?), code 7 (?), value -3
+`
+
+	h, events, err := ReadEvtest(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Description.Name != "" || len(events) != 4 {
+		t.Fatalf("Want 4 events and no name, have %d and %q", len(events), h.Description.Name)
+	}
+
+	if events[2].Value != -1 || events[3].Type != 99 || events[3].Value != -3 {
+		t.Fatalf("Unexpected events %v", events)
+	}
+
+	if have := FormatEvtest(events[2]); !strings.HasSuffix(have, "value ffffffff") {
+		t.Fatalf("Want hexadecimal scan code, have %q", have)
+	}
+
+	for _, bad := range []string{
+		"Event: time 1700000000.000100, type 1 (EV_KEY), code 30 (KEY_A)\n",
+		"Event: time 1700000000.000100, -------------- KEY_A ------------\n",
+		"Event: time x, type 1 (EV_KEY), code 30 (KEY_A), value 1\n",
+	} {
+		if _, _, err := ReadEvtest(strings.NewReader(bad)); err == nil {
+			t.Fatalf("Want error for %q", bad)
+		}
+	}
+}
@@ -80,20 +80,7 @@ func listState(set evdev.Bitset) {
 			continue
 		}
 
-		fmt.Printf("  Key 0x%02x ", n)
-
-		switch n {
-		case evdev.KeyReserved:
-			fmt.Printf("Reserved")
-		case evdev.KeyEscape:
-			fmt.Printf("Escape")
-		case evdev.BtnStylus2:
-			fmt.Printf("2nd stylus button")
-
-			// more keys/buttons..
-		}
-
-		fmt.Println()
+		fmt.Printf("  Key 0x%02x %s\n", n, evdev.CodeName(evdev.EvKeys, n))
 	}
 }
 
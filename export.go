@@ -0,0 +1,481 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// ExportFormat selects the file format written by an Exporter.
+type ExportFormat int
+
+const (
+	ExportJSONL ExportFormat = iota // One JSON object per line.
+	ExportCSV                       // Comma separated values, with a header row.
+)
+
+// ExportOption configures an Exporter.
+type ExportOption func(*Exporter)
+
+// ExportFrames writes one object or row per frame, instead of one per
+// event. In CSV, the events of a frame are joined into a single column.
+// E.g.: "EV_ABS/ABS_X=512 EV_SYN/SYN_REPORT=0"
+func ExportFrames() ExportOption {
+	return func(e *Exporter) {
+		e.frames = true
+	}
+}
+
+// exportRecord is an event, or a frame of events, as written by an
+// Exporter. The field names are the JSON keys and CSV columns.
+type exportRecord struct {
+	Device     int           `json:"device"`
+	TimeUs     int64         `json:"time_us"` // Microseconds since the epoch.
+	T          float64       `json:"t"`       // Seconds since the first exported event.
+	Type       string        `json:"type,omitempty"`
+	Code       string        `json:"code,omitempty"`
+	Value      *int32        `json:"value,omitempty"`
+	Normalized *float64      `json:"normalized,omitempty"`
+	Events     []exportEvent `json:"events,omitempty"`
+}
+
+// exportEvent is an event within a frame.
+type exportEvent struct {
+	Type       string   `json:"type"`
+	Code       string   `json:"code"`
+	Value      int32    `json:"value"`
+	Normalized *float64 `json:"normalized,omitempty"`
+}
+
+var (
+	exportEventColumns = []string{"device", "time_us", "t", "type", "code", "value", "normalized"}
+	exportFrameColumns = []string{"device", "time_us", "t", "events"}
+)
+
+// Exporter writes events as JSON Lines or CSV, for analysis with tools
+// such as pandas. Each event carries the index of its device, its
+// timestamp in microseconds since the epoch, the seconds since the first
+// exported event, the names of its type and code, and its value. For
+// absolute axes with a known range, the value scaled to [0, 1] is
+// included as well. E.g.:
+//
+//	{"device":0,"time_us":1700000000012211,"t":0.012211,"type":"EV_ABS","code":"ABS_X","value":512,"normalized":0.5}
+//
+// Unknown types and codes are written as hexadecimal numbers.
+// It is safe for concurrent use.
+type Exporter struct {
+	mu      sync.Mutex
+	w       *bufio.Writer
+	csv     *csv.Writer
+	json    *json.Encoder
+	descs   []*Description
+	frames  bool
+	started bool
+	start   int64 // Time of the first event, in microseconds.
+}
+
+// NewExporter creates an exporter writing to w in the given format.
+// The descriptions provide the axis ranges for the normalised values.
+// Devices are referred to by their index in this list; a nil
+// description leaves the values of that device unnormalised.
+func NewExporter(w io.Writer, format ExportFormat, descs []*Description, opts ...ExportOption) (*Exporter, error) {
+	e := &Exporter{
+		w:     bufio.NewWriter(w),
+		descs: descs,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	switch format {
+	case ExportJSONL:
+		e.json = json.NewEncoder(e.w)
+	case ExportCSV:
+		e.csv = csv.NewWriter(e.w)
+
+		columns := exportEventColumns
+		if e.frames {
+			columns = exportFrameColumns
+		}
+		if err := e.csv.Write(columns); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("export: unknown format %d", format)
+	}
+
+	return e, nil
+}
+
+// WriteFrame writes events of the given device.
+func (e *Exporter) WriteFrame(device int, events ...Event) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if !e.started {
+		e.start = syscall.TimevalToNsec(events[0].Time) / 1e3
+		e.started = true
+	}
+
+	if e.frames {
+		rec := e.record(device, events[0])
+		for _, evt := range events {
+			rec.Events = append(rec.Events, exportEvent{
+				Type:       exportTypeName(evt.Type),
+				Code:       exportCodeName(evt.Type, evt.Code),
+				Value:      evt.Value,
+				Normalized: e.normalize(device, evt),
+			})
+		}
+		return e.write(rec)
+	}
+
+	for _, evt := range events {
+		rec := e.record(device, evt)
+		rec.Type = exportTypeName(evt.Type)
+		rec.Code = exportCodeName(evt.Type, evt.Code)
+		rec.Value = &evt.Value
+		rec.Normalized = e.normalize(device, evt)
+
+		if err := e.write(rec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// record returns a record with the device and time of the event.
+func (e *Exporter) record(device int, evt Event) *exportRecord {
+	us := syscall.TimevalToNsec(evt.Time) / 1e3
+	return &exportRecord{
+		Device: device,
+		TimeUs: us,
+		T:      float64(us-e.start) / 1e6,
+	}
+}
+
+// normalize returns the value of an absolute axis scaled to [0, 1],
+// or nil if the event is not on an axis with a known range.
+func (e *Exporter) normalize(device int, evt Event) *float64 {
+	if evt.Type != EvAbsolute || device < 0 || device >= len(e.descs) || e.descs[device] == nil {
+		return nil
+	}
+
+	info, ok := e.descs[device].Abs[int(evt.Code)]
+	if !ok || info.Maximum <= info.Minimum {
+		return nil
+	}
+
+	v := AbsEvent{Value: evt.Value, Info: info}.Normalized()
+	return &v
+}
+
+// write encodes a record in the format of the exporter.
+func (e *Exporter) write(rec *exportRecord) error {
+	if e.json != nil {
+		return e.json.Encode(rec)
+	}
+
+	row := []string{
+		strconv.Itoa(rec.Device),
+		strconv.FormatInt(rec.TimeUs, 10),
+		strconv.FormatFloat(rec.T, 'f', 6, 64),
+	}
+
+	if e.frames {
+		list := make([]string, len(rec.Events))
+		for i, evt := range rec.Events {
+			list[i] = fmt.Sprintf("%s/%s=%d", evt.Type, evt.Code, evt.Value)
+		}
+		row = append(row, strings.Join(list, " "))
+	} else {
+		row = append(row, rec.Type, rec.Code, strconv.Itoa(int(*rec.Value)), "")
+		if rec.Normalized != nil {
+			row[len(row)-1] = strconv.FormatFloat(*rec.Normalized, 'g', -1, 64)
+		}
+	}
+
+	return e.csv.Write(row)
+}
+
+// Device returns an EventSink, which writes each batch
+// of events as a frame of the given device.
+func (e *Exporter) Device(device int) EventSink {
+	return exportDevice{e, device}
+}
+
+type exportDevice struct {
+	e      *Exporter
+	device int
+}
+
+func (d exportDevice) WriteEvents(events ...Event) error {
+	return d.e.WriteFrame(d.device, events...)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *Exporter) Flush() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+// exportTypeName returns the name of the type, or its hexadecimal value.
+func exportTypeName(evtype uint16) string {
+	return evemuName(TypeName(int(evtype)), evtype)
+}
+
+// exportCodeName returns the name of the code, or its hexadecimal value.
+func exportCodeName(evtype, code uint16) string {
+	return evemuName(CodeName(int(evtype), int(code)), code)
+}
+
+// ExportSession writes the events of all devices in the session,
+// in the order of time, as described for Exporter.
+func ExportSession(w io.Writer, format ExportFormat, s *Session, opts ...ExportOption) error {
+	descs := make([]*Description, len(s.Devices))
+	for i, rec := range s.Devices {
+		descs[i] = rec.Description
+	}
+
+	e, err := NewExporter(w, format, descs, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range replayFrames(s) {
+		if err := e.WriteFrame(f.Device, f.Events...); err != nil {
+			return err
+		}
+	}
+
+	return e.Flush()
+}
+
+// Import reads events written by an Exporter, in either format and
+// with one event or one frame per line. The format is detected from the
+// first line. Events are rebuilt from their timestamp in microseconds,
+// names and raw values; the normalised values are ignored. Columns
+// added by other tools, such as an index written by pandas, are ignored.
+func Import(r io.Reader) ([]SessionEvent, error) {
+	br := bufio.NewReader(r)
+
+	first, err := br.Peek(1)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if first[0] == '{' {
+		return importJSON(br)
+	}
+	return importCSV(br)
+}
+
+func importJSON(r io.Reader) ([]SessionEvent, error) {
+	var list []SessionEvent
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var rec exportRecord
+		if err := json.Unmarshal(text, &rec); err != nil {
+			return nil, fmt.Errorf("import: line %d: %v", line, err)
+		}
+
+		tv := syscall.NsecToTimeval(rec.TimeUs * 1e3)
+
+		if rec.Events == nil {
+			if rec.Value == nil {
+				return nil, fmt.Errorf("import: line %d: missing value", line)
+			}
+			rec.Events = []exportEvent{{Type: rec.Type, Code: rec.Code, Value: *rec.Value}}
+		}
+
+		for _, evt := range rec.Events {
+			e, err := importEvent(tv, evt.Type, evt.Code, evt.Value)
+			if err != nil {
+				return nil, fmt.Errorf("import: line %d: %v", line, err)
+			}
+			list = append(list, SessionEvent{Device: rec.Device, Event: e})
+		}
+	}
+
+	return list, scanner.Err()
+}
+
+func importCSV(r io.Reader) ([]SessionEvent, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("import: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	for _, name := range []string{"device", "time_us"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("import: missing column %q", name)
+		}
+	}
+
+	_, frames := columns["events"]
+	if !frames {
+		for _, name := range []string{"type", "code", "value"} {
+			if _, ok := columns[name]; !ok {
+				return nil, fmt.Errorf("import: missing column %q", name)
+			}
+		}
+	}
+
+	var list []SessionEvent
+
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return list, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("import: %v", err)
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		device, err := strconv.Atoi(field("device"))
+		if err != nil {
+			return nil, fmt.Errorf("import: line %d: bad device", line)
+		}
+
+		us, err := strconv.ParseInt(field("time_us"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("import: line %d: bad time_us", line)
+		}
+		tv := syscall.NsecToTimeval(us * 1e3)
+
+		var events []Event
+
+		if frames {
+			events, err = importFrame(tv, field("events"))
+		} else {
+			var value int64
+			value, err = strconv.ParseInt(field("value"), 10, 32)
+			if err == nil {
+				var e Event
+				e, err = importEvent(tv, field("type"), field("code"), int32(value))
+				events = []Event{e}
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("import: line %d: %v", line, err)
+		}
+
+		for _, e := range events {
+			list = append(list, SessionEvent{Device: device, Event: e})
+		}
+	}
+}
+
+// importFrame parses the events column of a frame.
+// E.g.: "EV_ABS/ABS_X=512 EV_SYN/SYN_REPORT=0"
+func importFrame(tv syscall.Timeval, s string) ([]Event, error) {
+	var events []Event
+
+	for _, f := range strings.Fields(s) {
+		names, value, ok := strings.Cut(f, "=")
+		evtype, code, ok2 := strings.Cut(names, "/")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("want TYPE/CODE=value, have %q", f)
+		}
+
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := importEvent(tv, evtype, code, int32(v))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// importEvent builds an event from the names, or hexadecimal
+// values, of its type and code.
+func importEvent(tv syscall.Timeval, typeName, codeName string, value int32) (Event, error) {
+	var evtype int
+	var err error
+
+	if strings.HasPrefix(typeName, "0x") {
+		var v uint64
+		v, err = strconv.ParseUint(typeName[2:], 16, 16)
+		evtype = int(v)
+	} else {
+		evtype, err = ParseType(typeName)
+	}
+	if err != nil {
+		return Event{}, err
+	}
+
+	var code int
+	if strings.HasPrefix(codeName, "0x") {
+		var v uint64
+		v, err = strconv.ParseUint(codeName[2:], 16, 16)
+		code = int(v)
+	} else {
+		var codeType int
+		codeType, code, err = ParseCode(codeName)
+		if err == nil && codeType != evtype {
+			err = fmt.Errorf("code %s does not belong to %s", codeName, typeName)
+		}
+	}
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{Time: tv, Type: uint16(evtype), Code: uint16(code), Value: value}, nil
+}
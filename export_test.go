@@ -0,0 +1,125 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	s := readSession(t)
+	want := s.Interleaved()
+
+	formats := []struct {
+		Name   string
+		Format ExportFormat
+	}{
+		{"jsonl", ExportJSONL},
+		{"csv", ExportCSV},
+	}
+
+	for _, f := range formats {
+		for _, frames := range []bool{false, true} {
+			name := f.Name
+			var opts []ExportOption
+			if frames {
+				name += "/frames"
+				opts = append(opts, ExportFrames())
+			}
+
+			t.Run(name, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := ExportSession(&buf, f.Format, s, opts...); err != nil {
+					t.Fatal(err)
+				}
+
+				have, err := Import(&buf)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(have, want) {
+					t.Fatalf("Want %v, have %v", want, have)
+				}
+			})
+		}
+	}
+}
+
+func TestExportJSONL(t *testing.T) {
+	desc := newEmptyDescription()
+	desc.Abs[AbsX] = AbsInfo{Minimum: 0, Maximum: 1000}
+
+	var buf bytes.Buffer
+	e, err := NewExporter(&buf, ExportJSONL, []*Description{desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tv := func(us int64) syscall.Timeval { return syscall.NsecToTimeval(1700000000e9 + us*1e3) }
+
+	e.WriteFrame(0, Event{tv(0), EvAbsolute, AbsX, 250}, Event{tv(0), EvSync, SynReport, 0})
+	e.Device(0).WriteEvents(Event{tv(1500), EvAbsolute, 0x3e, -1})
+
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"device":0,"time_us":1700000000000000,"t":0,"type":"EV_ABS","code":"ABS_X","value":250,"normalized":0.25}
+{"device":0,"time_us":1700000000000000,"t":0,"type":"EV_SYN","code":"SYN_REPORT","value":0}
+{"device":0,"time_us":1700000000001500,"t":0.0015,"type":"EV_ABS","code":"0x3e","value":-1}
+`
+	if buf.String() != want {
+		t.Fatalf("Want:\n%s\nHave:\n%s", want, buf.String())
+	}
+
+	list, err := Import(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 3 || list[2].Event != (Event{tv(1500), EvAbsolute, 0x3e, -1}) {
+		t.Fatalf("Unexpected events %v", list)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	// As written by pandas' to_csv, with an index and reordered columns.
+	input := `,t,time_us,device,code,type,value,normalized
+0,0.0,1700000000000000,1,KEY_A,EV_KEY,1,
+1,0.0,1700000000000000,1,SYN_REPORT,EV_SYN,0,
+`
+
+	list, err := Import(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tv := syscall.NsecToTimeval(1700000000e9)
+	want := []SessionEvent{
+		{1, Event{tv, EvKeys, KeyA, 1}},
+		{1, Event{tv, EvSync, SynReport, 0}},
+	}
+
+	if !reflect.DeepEqual(list, want) {
+		t.Fatalf("Want %v, have %v", want, list)
+	}
+
+	bad := []string{
+		"device,time_us,type,code\n0,0,EV_KEY,KEY_A\n",
+		"device,time_us,type,code,value\n0,0,EV_KEY,REL_X,1\n",
+		"device,time_us,events\n0,0,EV_KEY/KEY_A\n",
+		`{"device":0,"time_us":0,"type":"EV_KEY","code":"KEY_A"}`,
+	}
+
+	for _, s := range bad {
+		if _, err := Import(strings.NewReader(s)); err == nil {
+			t.Fatalf("Want error for %q", s)
+		}
+	}
+}
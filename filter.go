@@ -0,0 +1,397 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"math"
+	"slices"
+)
+
+// Filter transforms a frame of events, as yielded by Device.Frames, into
+// zero or more frames. Returning no frames drops the frame. A filter may
+// modify the given frame in place and return it. The frames it returns
+// may share memory, such as the same frame returned twice.
+//
+// Filters may hold state between frames, such as the keys they have
+// seen pressed. A Pipeline calls its filters from a single goroutine.
+type Filter interface {
+	Filter(frame []Event) [][]Event
+}
+
+// FilterFunc adapts a function to the Filter interface. E.g.:
+//
+//	swallowA := evdev.FilterFunc(func(frame []evdev.Event) [][]evdev.Event {
+//		...
+//	})
+type FilterFunc func(frame []Event) [][]Event
+
+// Filter calls f(frame).
+func (f FilterFunc) Filter(frame []Event) [][]Event {
+	return f(frame)
+}
+
+// FilterDescriber is implemented by filters which change the codes
+// a device emits, such as Remap. FilterDescription updates the
+// description of the filtered device, before a Pipeline creates a
+// virtual device from it.
+type FilterDescriber interface {
+	FilterDescription(desc *Description)
+}
+
+// EventCode identifies a code of an event type. E.g.: {EvKeys, KeyA}.
+type EventCode struct {
+	Type uint16
+	Code uint16
+}
+
+// code returns the event code of the event.
+func (e Event) code() EventCode {
+	return EventCode{e.Type, e.Code}
+}
+
+// describeFilter updates desc for f, if f is a FilterDescriber.
+func describeFilter(f Filter, desc *Description) {
+	if d, ok := f.(FilterDescriber); ok {
+		d.FilterDescription(desc)
+	}
+}
+
+// mapEvents applies fn to each event in the frame, in place. Events for
+// which fn returns false are removed. A frame left with nothing but its
+// SynReport is dropped.
+func mapEvents(frame []Event, fn func(e *Event) bool) [][]Event {
+	out := frame[:0]
+	for _, e := range frame {
+		if fn(&e) {
+			out = append(out, e)
+		}
+	}
+
+	if len(out) == 0 || (len(out) == 1 && len(frame) > 1 && out[0].Type == EvSync && out[0].Code == SynReport) {
+		return nil
+	}
+	return [][]Event{out}
+}
+
+// chain runs frames through a list of filters.
+type chain []Filter
+
+// Chain returns a filter passing each frame through the given
+// filters in order. Every frame a filter returns is passed on
+// to the next one. If a filter returns several frames, each is
+// copied first, so filters modifying them in place do not see
+// the changes made to the others. E.g.:
+//
+//	f := evdev.Chain(
+//		evdev.Remap(map[evdev.EventCode]evdev.EventCode{
+//			{evdev.EvKeys, evdev.KeyCapsLock}: {evdev.EvKeys, evdev.KeyEscape},
+//		}),
+//		evdev.Drop(evdev.EventCode{evdev.EvMisc, evdev.MiscScan}),
+//	)
+func Chain(filters ...Filter) Filter {
+	return chain(filters)
+}
+
+func (c chain) Filter(frame []Event) [][]Event {
+	frames := [][]Event{frame}
+
+	for i, f := range c {
+		var next [][]Event
+		for _, frame := range frames {
+			out := f.Filter(frame)
+			if len(out) > 1 && i < len(c)-1 {
+				for j := range out {
+					out[j] = slices.Clone(out[j])
+				}
+			}
+			next = append(next, out...)
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+		frames = next
+	}
+
+	return frames
+}
+
+func (c chain) FilterDescription(desc *Description) {
+	for _, f := range c {
+		describeFilter(f, desc)
+	}
+}
+
+// branch routes frames to one of two filters.
+type branch struct {
+	match     func(frame []Event) bool
+	then, alt Filter
+}
+
+// Branch returns a filter passing frames for which match returns
+// true through then, and all others through otherwise. Either filter
+// may be nil, which passes the frames on unchanged. E.g.:
+//
+//	// Only scale the wheel while the right button is held.
+//	evdev.Branch(rightHeld, evdev.ScaleAxis(wheel, 0.25), nil)
+func Branch(match func(frame []Event) bool, then, otherwise Filter) Filter {
+	return &branch{match, then, otherwise}
+}
+
+func (b *branch) Filter(frame []Event) [][]Event {
+	f := b.alt
+	if b.match(frame) {
+		f = b.then
+	}
+
+	if f == nil {
+		return [][]Event{frame}
+	}
+	return f.Filter(frame)
+}
+
+func (b *branch) FilterDescription(desc *Description) {
+	for _, f := range []Filter{b.then, b.alt} {
+		if f != nil {
+			describeFilter(f, desc)
+		}
+	}
+}
+
+// HasCode returns a function for Branch, matching frames holding
+// an event with any of the given codes.
+func HasCode(codes ...EventCode) func(frame []Event) bool {
+	return func(frame []Event) bool {
+		for _, e := range frame {
+			for _, c := range codes {
+				if e.code() == c {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// remap replaces event codes.
+type remap map[EventCode]EventCode
+
+// Remap returns a filter replacing the codes of events according to
+// the given map. Codes may be mapped to codes of another type, such
+// as a key to a mouse button. Values are left unchanged.
+func Remap(m map[EventCode]EventCode) Filter {
+	return remap(m)
+}
+
+func (m remap) Filter(frame []Event) [][]Event {
+	return mapEvents(frame, func(e *Event) bool {
+		if to, ok := m[e.code()]; ok {
+			e.Type, e.Code = to.Type, to.Code
+		}
+		return true
+	})
+}
+
+// FilterDescription adds the codes mapped to, and axis information
+// for absolute axes mapped from other absolute axes.
+func (m remap) FilterDescription(desc *Description) {
+	for from, to := range m {
+		desc.Events.Set(int(to.Type))
+		desc.Bits(int(to.Type)).Set(int(to.Code))
+
+		if from.Type == EvAbsolute && to.Type == EvAbsolute {
+			if info, ok := desc.Abs[int(from.Code)]; ok {
+				if desc.Abs == nil {
+					desc.Abs = make(map[int]AbsInfo)
+				}
+				desc.Abs[int(to.Code)] = info
+			}
+		}
+	}
+}
+
+// drop removes events with given codes.
+type drop map[EventCode]bool
+
+// Drop returns a filter removing events with any of the given codes.
+// Frames left without other events than their SynReport are dropped.
+func Drop(codes ...EventCode) Filter {
+	d := make(drop)
+	for _, c := range codes {
+		d[c] = true
+	}
+	return d
+}
+
+func (d drop) Filter(frame []Event) [][]Event {
+	return mapEvents(frame, func(e *Event) bool {
+		return !d[e.code()]
+	})
+}
+
+// invert mirrors the values of an axis.
+type invert struct {
+	axis EventCode
+	info AbsInfo
+}
+
+// InvertAxis returns a filter mirroring the values of the given axis.
+// Absolute values are mirrored within the range in info, so the
+// minimum becomes the maximum. Relative values are negated, and
+// info is ignored.
+func InvertAxis(axis EventCode, info AbsInfo) Filter {
+	return &invert{axis, info}
+}
+
+func (f *invert) Filter(frame []Event) [][]Event {
+	return mapEvents(frame, func(e *Event) bool {
+		if e.code() == f.axis {
+			if e.Type == EvAbsolute {
+				e.Value = int32(int64(f.info.Minimum) + int64(f.info.Maximum) - int64(e.Value))
+			} else {
+				e.Value = -e.Value
+			}
+		}
+		return true
+	})
+}
+
+// swap exchanges the codes of two axes.
+type swap struct {
+	a, b EventCode
+}
+
+// SwapAxes returns a filter exchanging the codes of two axes, such as
+// AbsX and AbsY for a rotated touchscreen. The values are left as
+// they are. A Pipeline exchanges the ranges of the axes as well.
+func SwapAxes(a, b EventCode) Filter {
+	return &swap{a, b}
+}
+
+func (f *swap) Filter(frame []Event) [][]Event {
+	return mapEvents(frame, func(e *Event) bool {
+		switch e.code() {
+		case f.a:
+			e.Type, e.Code = f.b.Type, f.b.Code
+		case f.b:
+			e.Type, e.Code = f.a.Type, f.a.Code
+		}
+		return true
+	})
+}
+
+// FilterDescription exchanges the axis information of absolute axes.
+func (f *swap) FilterDescription(desc *Description) {
+	if f.a.Type != EvAbsolute || f.b.Type != EvAbsolute || desc.Abs == nil {
+		return
+	}
+
+	a, aok := desc.Abs[int(f.a.Code)]
+	b, bok := desc.Abs[int(f.b.Code)]
+	if aok && bok {
+		desc.Abs[int(f.a.Code)], desc.Abs[int(f.b.Code)] = b, a
+	}
+}
+
+// scale multiplies the values of an axis.
+type scale struct {
+	axis   EventCode
+	factor float64
+	rest   float64 // Fraction left over from relative motion.
+}
+
+// ScaleAxis returns a filter multiplying the values of the given axis
+// by factor. E.g.: 0.5 halves the speed of a mouse. For relative axes,
+// the fractions left over by rounding are carried over to later
+// events, so slow motion is not lost. Scaled events which amount to
+// no motion are dropped. A Pipeline scales the range of absolute axes
+// as well.
+func ScaleAxis(axis EventCode, factor float64) Filter {
+	return &scale{axis: axis, factor: factor}
+}
+
+func (f *scale) Filter(frame []Event) [][]Event {
+	return mapEvents(frame, func(e *Event) bool {
+		if e.code() != f.axis {
+			return true
+		}
+
+		if e.Type != EvRelative {
+			e.Value = clampInt32(math.Round(float64(e.Value) * f.factor))
+			return true
+		}
+
+		v := float64(e.Value)*f.factor + f.rest
+		n := math.Trunc(v)
+		f.rest = v - n
+
+		e.Value = clampInt32(n)
+		return e.Value != 0
+	})
+}
+
+// FilterDescription scales the axis information of an absolute axis,
+// so its range covers the scaled values.
+func (f *scale) FilterDescription(desc *Description) {
+	if f.axis.Type != EvAbsolute || desc.Abs == nil {
+		return
+	}
+
+	info, ok := desc.Abs[int(f.axis.Code)]
+	if !ok {
+		return
+	}
+
+	mul := func(v int32) int32 {
+		return clampInt32(math.Round(float64(v) * f.factor))
+	}
+
+	info.Value = mul(info.Value)
+	info.Minimum, info.Maximum = mul(info.Minimum), mul(info.Maximum)
+	if info.Minimum > info.Maximum {
+		info.Minimum, info.Maximum = info.Maximum, info.Minimum
+	}
+
+	info.Fuzz = abs32(mul(info.Fuzz))
+	info.Flat = abs32(mul(info.Flat))
+	info.Resolution = abs32(mul(info.Resolution))
+	desc.Abs[int(f.axis.Code)] = info
+}
+
+// abs32 returns the absolute value of v.
+func abs32(v int32) int32 {
+	return max(v, -v)
+}
+
+// clampInt32 converts v to an int32, limited to the range of int32.
+func clampInt32(v float64) int32 {
+	switch {
+	case v > math.MaxInt32:
+		return math.MaxInt32
+	case v < math.MinInt32:
+		return math.MinInt32
+	}
+	return int32(v)
+}
+
+// clamp limits the values of a code.
+type clamp struct {
+	code     EventCode
+	min, max int32
+}
+
+// Clamp returns a filter limiting the values of events with the
+// given code to the range [min, max].
+func Clamp(code EventCode, min, max int32) Filter {
+	return &clamp{code, min, max}
+}
+
+func (f *clamp) Filter(frame []Event) [][]Event {
+	return mapEvents(frame, func(e *Event) bool {
+		if e.code() == f.code {
+			e.Value = max(f.min, min(f.max, e.Value))
+		}
+		return true
+	})
+}
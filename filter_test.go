@@ -0,0 +1,202 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// frame returns a frame of the given events, terminated by a SynReport.
+func frame(events ...Event) []Event {
+	return append(events, Event{Type: EvSync, Code: SynReport})
+}
+
+var (
+	relX = EventCode{EvRelative, RelX}
+	relY = EventCode{EvRelative, RelY}
+	absX = EventCode{EvAbsolute, AbsX}
+	absY = EventCode{EvAbsolute, AbsY}
+)
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Filter Filter
+		In     []Event
+		Want   [][]Event
+	}{
+		{
+			"remap",
+			Remap(map[EventCode]EventCode{{EvKeys, KeyCapsLock}: {EvKeys, KeyEscape}}),
+			frame(Event{Type: EvKeys, Code: KeyCapsLock, Value: 1}),
+			[][]Event{frame(Event{Type: EvKeys, Code: KeyEscape, Value: 1})},
+		},
+		{
+			"drop",
+			Drop(EventCode{EvMisc, MiscScan}),
+			frame(Event{Type: EvMisc, Code: MiscScan, Value: 0x1e}, Event{Type: EvKeys, Code: KeyA, Value: 1}),
+			[][]Event{frame(Event{Type: EvKeys, Code: KeyA, Value: 1})},
+		},
+		{
+			"drop frame",
+			Drop(relX),
+			frame(Event{Type: EvRelative, Code: RelX, Value: 3}),
+			nil,
+		},
+		{
+			"invert abs",
+			InvertAxis(absX, AbsInfo{Minimum: 10, Maximum: 100}),
+			frame(Event{Type: EvAbsolute, Code: AbsX, Value: 30}, Event{Type: EvAbsolute, Code: AbsY, Value: 30}),
+			[][]Event{frame(Event{Type: EvAbsolute, Code: AbsX, Value: 80}, Event{Type: EvAbsolute, Code: AbsY, Value: 30})},
+		},
+		{
+			"invert rel",
+			InvertAxis(relY, AbsInfo{}),
+			frame(Event{Type: EvRelative, Code: RelY, Value: 5}),
+			[][]Event{frame(Event{Type: EvRelative, Code: RelY, Value: -5})},
+		},
+		{
+			"swap",
+			SwapAxes(absX, absY),
+			frame(Event{Type: EvAbsolute, Code: AbsX, Value: 1}, Event{Type: EvAbsolute, Code: AbsY, Value: 2}),
+			[][]Event{frame(Event{Type: EvAbsolute, Code: AbsY, Value: 1}, Event{Type: EvAbsolute, Code: AbsX, Value: 2})},
+		},
+		{
+			"scale abs",
+			ScaleAxis(absX, 1.5),
+			frame(Event{Type: EvAbsolute, Code: AbsX, Value: 3}),
+			[][]Event{frame(Event{Type: EvAbsolute, Code: AbsX, Value: 5})},
+		},
+		{
+			"clamp",
+			Clamp(absX, 0, 100),
+			frame(Event{Type: EvAbsolute, Code: AbsX, Value: -4}),
+			[][]Event{frame(Event{Type: EvAbsolute, Code: AbsX, Value: 0})},
+		},
+		{
+			"chain",
+			Chain(Remap(map[EventCode]EventCode{relX: relY}), InvertAxis(relY, AbsInfo{})),
+			frame(Event{Type: EvRelative, Code: RelX, Value: 2}),
+			[][]Event{frame(Event{Type: EvRelative, Code: RelY, Value: -2})},
+		},
+		{
+			"chain duplicate",
+			Chain(FilterFunc(func(f []Event) [][]Event { return [][]Event{f, f} }), Remap(map[EventCode]EventCode{relX: relY, relY: relX})),
+			frame(Event{Type: EvRelative, Code: RelX, Value: 2}),
+			[][]Event{frame(Event{Type: EvRelative, Code: RelY, Value: 2}), frame(Event{Type: EvRelative, Code: RelY, Value: 2})},
+		},
+		{
+			"branch",
+			Branch(HasCode(EventCode{EvKeys, BtnRight}), nil, Drop(relX)),
+			frame(Event{Type: EvRelative, Code: RelX, Value: 2}),
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			have := test.Filter.Filter(test.In)
+			if !reflect.DeepEqual(have, test.Want) {
+				t.Fatalf("Want %v, have %v", test.Want, have)
+			}
+		})
+	}
+}
+
+func TestScaleRelative(t *testing.T) {
+	f := ScaleAxis(relX, 0.4)
+
+	// Motion of 1 is too small on its own, but adds up.
+	var moved int32
+	var frames int
+	for i := 0; i < 5; i++ {
+		for _, out := range f.Filter(frame(Event{Type: EvRelative, Code: RelX, Value: 1})) {
+			moved += out[0].Value
+			frames++
+		}
+	}
+
+	if moved != 2 || frames != 2 {
+		t.Fatalf("Want 2 frames moving 2, have %d moving %d", frames, moved)
+	}
+}
+
+func TestFilterDescription(t *testing.T) {
+	desc := newEmptyDescription()
+	desc.Events.Set(EvAbsolute)
+	desc.Absolute.Set(AbsX)
+	desc.Absolute.Set(AbsY)
+	desc.Abs[AbsX] = AbsInfo{Maximum: 1920}
+	desc.Abs[AbsY] = AbsInfo{Maximum: 1080}
+
+	f := Chain(
+		SwapAxes(absX, absY),
+		Branch(HasCode(absX), Remap(map[EventCode]EventCode{{EvAbsolute, AbsX}: {EvKeys, BtnTouch}}), nil),
+	)
+	describeFilter(f, desc)
+
+	if desc.Abs[AbsX].Maximum != 1080 || desc.Abs[AbsY].Maximum != 1920 {
+		t.Fatalf("Want swapped axes, have %+v", desc.Abs)
+	}
+
+	if !desc.Events.Test(EvKeys) || !desc.Keys.Test(BtnTouch) {
+		t.Fatalf("Want BtnTouch added, have %s and %s", desc.Events, desc.Keys)
+	}
+}
+
+func TestScaleAxisDescription(t *testing.T) {
+	desc := newEmptyDescription()
+	desc.Events.Set(EvAbsolute)
+	desc.Absolute.Set(AbsX)
+	desc.Absolute.Set(AbsY)
+	desc.Abs[AbsX] = AbsInfo{Value: 50, Minimum: -100, Maximum: 1000, Fuzz: 4, Flat: 8, Resolution: 10}
+	desc.Abs[AbsY] = AbsInfo{Minimum: 0, Maximum: 1000}
+
+	describeFilter(Chain(ScaleAxis(absX, 0.5), ScaleAxis(absY, -2), ScaleAxis(relX, 3)), desc)
+
+	if want := (AbsInfo{Value: 25, Minimum: -50, Maximum: 500, Fuzz: 2, Flat: 4, Resolution: 5}); desc.Abs[AbsX] != want {
+		t.Fatalf("AbsX: Want %+v, have %+v", want, desc.Abs[AbsX])
+	}
+
+	if want := (AbsInfo{Minimum: -2000, Maximum: 0}); desc.Abs[AbsY] != want {
+		t.Fatalf("AbsY: Want %+v, have %+v", want, desc.Abs[AbsY])
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	var buf bytes.Buffer
+	NewEncoder(&buf).Encode(testFrames...)
+
+	var rec frameRecorder
+	p := NewPipeline(NewDecoder(&buf), &rec, Remap(map[EventCode]EventCode{relY: relX}), Drop(EventCode{EvKeys, BtnLeft}))
+
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The SynDropped frame is skipped, the BtnLeft frame dropped.
+	if len(rec.frames) != 0 {
+		t.Fatalf("Want no frames, have %v", rec.frames)
+	}
+
+	dev, w := pipeDevice(t)
+	NewEncoder(w).Encode(frame(Event{Type: EvRelative, Code: RelY, Value: 7})...)
+	w.Close()
+
+	p = NewPipeline(dev, &rec, Remap(map[EventCode]EventCode{relY: relX}))
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := [][]Event{frame(Event{Type: EvRelative, Code: RelX, Value: 7})}; !reflect.DeepEqual(rec.frames, want) {
+		t.Fatalf("Want %v, have %v", want, rec.frames)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
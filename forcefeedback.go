@@ -3,47 +3,9 @@
 
 package evdev
 
-import "unsafe"
-
-// Values describing the status of a force-feedback effect
-const (
-	FFStatusStopped = 0x00
-	FFStatusPlaying = 0x01
-	FFStatusMax     = 0x01
-)
-
-// Force feedback effect types.
-const (
-	FFRumble    = 0x50
-	FFPeriodic  = 0x51
-	FFConstant  = 0x52
-	FFSpring    = 0x53
-	FFFriction  = 0x54
-	FFDamper    = 0x55
-	FFInertia   = 0x56
-	FFRamp      = 0x57
-	FFEffectMin = FFRumble
-	FFEffectMax = FFRamp
-)
-
-// Force feedback periodic effect types
-const (
-	FFSquare      = 0x58
-	FFTriangle    = 0x59
-	FFSine        = 0x5a
-	FFSawUp       = 0x5b
-	FFSawDown     = 0x5c
-	FFCustom      = 0x5d
-	FFWaveformMin = FFSquare
-	FFWaveformMax = FFCustom
-)
-
-// Set Force feedback device properties
-const (
-	FFGain       = 0x60
-	FFAutoCenter = 0x61
-	FFMax        = 0x7f
-	FFCount      = FFMax + 1
+import (
+	"runtime"
+	"unsafe"
 )
 
 // Directions encoded in Effect.Direction
@@ -92,7 +54,7 @@ type Effect struct {
 	Direction uint16
 	Trigger   Trigger
 	Replay    Replay
-	data      unsafe.Pointer
+	data      interface{}
 }
 
 // Data returns the event data structure as a concrete type.
@@ -110,30 +72,32 @@ func (e *Effect) Data() interface{} {
 	// FIXME(jimt): Deal with: FFFriction, FFInertia:
 	// Unsure what they should return.
 
-	if e.data == nil {
-		return nil
-	}
-
 	switch e.Type {
 	case FFConstant:
-		return *(*ConstantEffect)(e.data)
+		v, _ := e.data.(ConstantEffect)
+		return v
 	case FFPeriodic:
-		return *(*PeriodicEffect)(e.data)
+		v, _ := e.data.(PeriodicEffect)
+		return v
 	case FFRamp:
-		return *(*RampEffect)(e.data)
+		v, _ := e.data.(RampEffect)
+		return v
 	case FFRumble:
-		return *(*RumbleEffect)(e.data)
+		v, _ := e.data.(RumbleEffect)
+		return v
 	case FFSpring, FFDamper:
-		return *(*[2]ConditionEffect)(e.data)
+		v, _ := e.data.([2]ConditionEffect)
+		return v
 	}
 
 	return nil
 }
 
 // SetData sets the event data structure.
+// See Effect.Data for the types matching each effect type.
 func (e *Effect) SetData(v interface{}) {
 	if v != nil {
-		e.data = unsafe.Pointer(&v)
+		e.data = v
 	}
 }
 
@@ -188,8 +152,7 @@ type PeriodicEffect struct {
 	Phase     uint16
 	Envelope  Envelope
 
-	custom_len  uint32
-	custom_data unsafe.Pointer // *int16
+	custom []int16
 }
 
 // Data returns custom waveform information.
@@ -198,10 +161,7 @@ type PeriodicEffect struct {
 // The exact layout of a custom waveform is undefined for the
 // time being as no driver supports it yet.
 func (e *PeriodicEffect) Data() []int16 {
-	if e.custom_data == nil {
-		return nil
-	}
-	return (*(*[1<<27 - 1]int16)(e.custom_data))[:e.custom_len]
+	return e.custom
 }
 
 // SetData sets custom waveform information.
@@ -209,12 +169,7 @@ func (e *PeriodicEffect) Data() []int16 {
 // The exact layout of a custom waveform is undefined for the
 // time being as no driver supports it yet.
 func (e *PeriodicEffect) SetData(v []int16) {
-	e.custom_len = uint32(len(v))
-	e.custom_data = unsafe.Pointer(nil)
-
-	if e.custom_len > 0 {
-		e.custom_data = unsafe.Pointer(&v[0])
-	}
+	e.custom = v
 }
 
 // The rumble effect is the most basic effect, it lets the
@@ -232,12 +187,10 @@ type RumbleEffect struct {
 //
 // This is only applicable to devices with EvForceFeedback event support.
 func (d *Device) ForceFeedbackCaps() (int, Bitset) {
-	bs := NewBitset(24)
-	buf := bs.Bytes()
-	ioctl(d.fd.Fd(), _EVIOCGBIT(EvForceFeedback, len(buf)), unsafe.Pointer(&buf[0]))
+	bs := d.eventBits(EvForceFeedback, FFCount)
 
 	var count int32
-	ioctl(d.fd.Fd(), _EVIOCGEFFECTS, unsafe.Pointer(&count))
+	d.ioctl(_EVIOCGEFFECTS, unsafe.Pointer(&count))
 	return int(count), bs
 }
 
@@ -253,11 +206,28 @@ func (d *Device) ForceFeedbackCaps() (int, Bitset) {
 //
 // This is only applicable to devices with EvForceFeedback event support.
 func (d *Device) SetEffects(list ...*Effect) bool {
+	buf := make([]byte, nativeABI.effectSize())
+
 	for _, effect := range list {
-		err := ioctl(d.fd.Fd(), _EVIOCSFF, unsafe.Pointer(effect))
+		var custom []int16
+		if p, ok := effect.data.(PeriodicEffect); ok {
+			custom = p.custom
+		}
+
+		var ptr uintptr
+		if len(custom) > 0 {
+			ptr = uintptr(unsafe.Pointer(&custom[0]))
+		}
+
+		nativeABI.putEffect(buf, effect, ptr)
+		err := d.ioctl(_EVIOCSFF, unsafe.Pointer(&buf[0]))
+		runtime.KeepAlive(custom)
+
 		if err != nil {
 			return false
 		}
+
+		effect.Id = nativeABI.effectId(buf)
 	}
 
 	return true
@@ -270,7 +240,7 @@ func (d *Device) SetEffects(list ...*Effect) bool {
 // This is only applicable to devices with EvForceFeedback event support.
 func (d *Device) UnsetEffects(list ...*Effect) bool {
 	for _, effect := range list {
-		err := ioctl(d.fd.Fd(), _EVIOCRMFF, int(effect.Id))
+		err := d.ioctl(_EVIOCRMFF, int(effect.Id))
 		if err != nil {
 			return false
 		}
@@ -318,7 +288,7 @@ func (d *Device) setEffectFactor(factor int, code uint16) {
 	e.Type = EvForceFeedback
 	e.Code = code
 	e.Value = 0xffff * int32(factor) / 100
-	d.Outbox <- e
+	d.send(e)
 }
 
 // PlayEffect plays a previously uploaded effect.
@@ -343,5 +313,5 @@ func (d *Device) toggleEffect(id int16, play bool) {
 		e.Value = 0
 	}
 
-	d.Outbox <- e
+	d.send(e)
 }
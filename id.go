@@ -3,28 +3,6 @@
 
 package evdev
 
-const (
-	BusPCI       = 0x01
-	BusISAPNP    = 0x02
-	BusUSB       = 0x03
-	BusHIL       = 0x04
-	BusBluetooth = 0x05
-	BusVirtual   = 0x06
-	BusISA       = 0x10
-	BusI8042     = 0x11
-	BusXTKBD     = 0x12
-	BusRS232     = 0x13
-	BusGamePort  = 0x14
-	BusParPort   = 0x15
-	BusAmiga     = 0x16
-	BusADB       = 0x17
-	BusI2C       = 0x18
-	BusHost      = 0x19
-	BusGSC       = 0x1A
-	BusAtari     = 0x1B
-	BusSPI       = 0x1C
-)
-
 // Id represents the device identity.
 //
 // The bus type is the only field that contains accurate data.
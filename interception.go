@@ -0,0 +1,244 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// interceptionAbsKeys are the keys of the axis information, in order.
+var interceptionAbsKeys = [6]string{"VALUE", "MIN", "MAX", "FUZZ", "FLAT", "RES"}
+
+// WriteInterceptionYAML writes the description in the YAML format
+// printed by the uinput command of interception-tools. E.g.:
+//
+//	NAME: "Logitech USB Receiver"
+//	VENDOR: 0x046d
+//	PRODUCT: 0xc52b
+//	VERSION: 0x0111
+//	BUSTYPE: BUS_USB
+//	EVENTS:
+//	  EV_SYN: [SYN_REPORT, SYN_CONFIG, SYN_MT_REPORT, SYN_DROPPED]
+//	  EV_REL: [REL_X, REL_Y, REL_WHEEL]
+//
+// Absolute axes are written as a mapping of their axis information.
+// Unknown names are written as numbers.
+func WriteInterceptionYAML(w io.Writer, desc *Description) error {
+	bw := bufio.NewWriter(w)
+	id := desc.Id
+
+	fmt.Fprintf(bw, "NAME: %s\n", yamlQuote(desc.Name))
+	fmt.Fprintf(bw, "VENDOR: 0x%04x\n", id.Vendor)
+	fmt.Fprintf(bw, "PRODUCT: 0x%04x\n", id.Product)
+	fmt.Fprintf(bw, "VERSION: 0x%04x\n", id.Version)
+	fmt.Fprintf(bw, "BUSTYPE: %s\n", interceptionName(BusName(int(id.BusType)), int(id.BusType)))
+
+	if desc.Properties.Count() > 0 {
+		var props []string
+		for p := range desc.Properties.All() {
+			props = append(props, interceptionName(PropName(p), p))
+		}
+		fmt.Fprintf(bw, "PROPERTIES: [%s]\n", strings.Join(props, ", "))
+	}
+
+	fmt.Fprintf(bw, "EVENTS:\n")
+
+	for evtype := range desc.Events.All() {
+		name := interceptionName(TypeName(evtype), evtype)
+
+		if evtype == EvAbsolute {
+			fmt.Fprintf(bw, "  %s:\n", name)
+			for axis := range desc.Absolute.All() {
+				info := desc.Abs[axis]
+				values := [6]int32{info.Value, info.Minimum, info.Maximum, info.Fuzz, info.Flat, info.Resolution}
+
+				fmt.Fprintf(bw, "    %s:\n", interceptionName(CodeName(EvAbsolute, axis), axis))
+				for i, v := range values {
+					fmt.Fprintf(bw, "      %s: %d\n", interceptionAbsKeys[i], v)
+				}
+			}
+			continue
+		}
+
+		var codes []string
+		switch evtype {
+		case EvSync:
+			for code := SynReport; code <= SynDropped; code++ {
+				codes = append(codes, CodeName(EvSync, code))
+			}
+		case EvRepeat:
+			codes = []string{CodeName(EvRepeat, RepDelay), CodeName(EvRepeat, RepPeriod)}
+		default:
+			for code := range desc.Bits(evtype).All() {
+				codes = append(codes, interceptionName(CodeName(evtype, code), code))
+			}
+		}
+
+		fmt.Fprintf(bw, "  %s: [%s]\n", name, strings.Join(codes, ", "))
+	}
+
+	return bw.Flush()
+}
+
+// interceptionName returns the name, or the value if the name is unknown.
+func interceptionName(name string, v int) string {
+	if name == "" {
+		return strconv.Itoa(v)
+	}
+	return name
+}
+
+// ReadInterceptionYAML reads a description in the YAML format used by
+// the uinput command of interception-tools. Names and numbers are both
+// accepted for types, codes, properties and the bus type. The values of
+// EV_REP, if given, are ignored.
+func ReadInterceptionYAML(r io.Reader) (*Description, error) {
+	root, err := parseYAML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := newEmptyDescription()
+
+	if n := root.Get("NAME"); n != nil {
+		desc.Name = n.Scalar
+	}
+
+	for _, f := range []struct {
+		Key string
+		Ptr *uint16
+	}{
+		{"VENDOR", &desc.Id.Vendor},
+		{"PRODUCT", &desc.Id.Product},
+		{"VERSION", &desc.Id.Version},
+	} {
+		if n := root.Get(f.Key); n != nil {
+			v, err := n.Int()
+			if err != nil {
+				return nil, fmt.Errorf("interception: %s: %v", f.Key, err)
+			}
+			*f.Ptr = uint16(v)
+		}
+	}
+
+	if n := root.Get("BUSTYPE"); n != nil {
+		bus, err := interceptionValue(n.Scalar, busNames)
+		if err != nil {
+			return nil, fmt.Errorf("interception: BUSTYPE: %v", err)
+		}
+		desc.Id.BusType = uint16(bus)
+	}
+
+	if n := root.Get("PROPERTIES"); n != nil {
+		for _, item := range n.List {
+			p, err := interceptionValue(item.Scalar, propNames)
+			if err != nil {
+				return nil, fmt.Errorf("interception: PROPERTIES: %v", err)
+			}
+			desc.Properties.Set(p)
+		}
+	}
+
+	events := root.Get("EVENTS")
+	if events == nil {
+		return desc, nil
+	}
+
+	for _, key := range events.Keys {
+		evtype, err := interceptionValue(key, typeNames)
+		if err != nil {
+			return nil, fmt.Errorf("interception: EVENTS: %v", err)
+		}
+		desc.Events.Set(evtype)
+
+		node := events.Map[key]
+
+		// Codes are listed, or are the keys of a mapping of their settings.
+		names := node.Keys
+		if node.IsList {
+			names = nil
+			for _, item := range node.List {
+				names = append(names, item.Scalar)
+			}
+		}
+
+		for _, name := range names {
+			code, err := interceptionCode(evtype, name)
+			if err != nil {
+				return nil, fmt.Errorf("interception: %s: %v", key, err)
+			}
+
+			if evtype == EvSync || evtype == EvRepeat {
+				continue
+			}
+			desc.Bits(evtype).Set(code)
+
+			if evtype == EvAbsolute && !node.IsList {
+				info, err := interceptionAbs(node.Map[name])
+				if err != nil {
+					return nil, fmt.Errorf("interception: %s: %v", name, err)
+				}
+				desc.Abs[code] = info
+			}
+		}
+	}
+
+	return desc, nil
+}
+
+// interceptionAbs reads the axis information of an absolute axis.
+func interceptionAbs(n *yamlNode) (AbsInfo, error) {
+	var info AbsInfo
+	if n == nil || n.Map == nil {
+		return info, fmt.Errorf("want a mapping of VALUE, MIN, MAX, FUZZ, FLAT and RES")
+	}
+	fields := [6]*int32{&info.Value, &info.Minimum, &info.Maximum, &info.Fuzz, &info.Flat, &info.Resolution}
+
+	for i, key := range interceptionAbsKeys {
+		if v := n.Get(key); v != nil {
+			x, err := v.Int()
+			if err != nil {
+				return info, err
+			}
+			*fields[i] = int32(x)
+		}
+	}
+
+	return info, nil
+}
+
+// interceptionValue returns the value with the given name in names,
+// or the number the string holds.
+func interceptionValue(s string, names map[int]string) (int, error) {
+	if v, err := strconv.ParseInt(s, 0, 32); err == nil {
+		return int(v), nil
+	}
+
+	for v, name := range names {
+		if name == s {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown name %q", s)
+}
+
+// interceptionCode returns the code with the given name or number.
+func interceptionCode(evtype int, s string) (int, error) {
+	if v, err := strconv.ParseInt(s, 0, 32); err == nil {
+		return int(v), nil
+	}
+
+	t, code, err := ParseCode(s)
+	if err != nil {
+		return 0, err
+	}
+	if t != evtype {
+		return 0, fmt.Errorf("code %s does not belong to %s", s, TypeName(evtype))
+	}
+	return code, nil
+}
@@ -0,0 +1,121 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterceptionYAML(t *testing.T) {
+	fd, err := os.Open("testdata/tablet.evemu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	rec, err := ReadEvemu(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteInterceptionYAML(&buf, rec.Description); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `NAME: "evdev test tablet"
+VENDOR: 0x1234
+PRODUCT: 0x5678
+VERSION: 0x0001
+BUSTYPE: BUS_USB
+PROPERTIES: [INPUT_PROP_DIRECT]
+EVENTS:
+  EV_SYN: [SYN_REPORT, SYN_CONFIG, SYN_MT_REPORT, SYN_DROPPED]
+  EV_KEY: [BTN_TOUCH, BTN_STYLUS]
+  EV_ABS:
+    ABS_X:
+      VALUE: 0
+      MIN: 0
+      MAX: 1023
+      FUZZ: 0
+      FLAT: 0
+      RES: 10
+`
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Fatalf("Want prefix:\n%s\nHave:\n%s", want, buf.String())
+	}
+
+	desc, err := ReadInterceptionYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(desc, rec.Description) {
+		t.Fatalf("Want %+v, have %+v", rec.Description, desc)
+	}
+}
+
+func TestReadInterceptionYAML(t *testing.T) {
+	// Written by hand, as for a virtual device in a udevmon job.
+	input := `NAME: Caps2Esc Keyboard
+PRODUCT: 1
+VENDOR: 0x1
+BUSTYPE: 0x11
+EVENTS:
+  EV_SYN: [SYN_REPORT]
+  EV_KEY: [KEY_ESC, KEY_CAPSLOCK, 30]
+  EV_REP:
+    REP_DELAY: 250
+    REP_PERIOD: 33
+`
+
+	desc, err := ReadInterceptionYAML(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (Id{BusType: BusI8042, Vendor: 1, Product: 1}); desc.Name != "Caps2Esc Keyboard" || desc.Id != want {
+		t.Fatalf("Unexpected name %q or id %+v", desc.Name, desc.Id)
+	}
+
+	if have := desc.Keys.String(); have != "{1, 30, 58}" {
+		t.Fatalf("Want keys {1, 30, 58}, have %s", have)
+	}
+
+	if have := desc.Events.String(); have != "{0, 1, 20}" {
+		t.Fatalf("Want events {0, 1, 20}, have %s", have)
+	}
+
+	// Axes may also be written as flow mappings.
+	input = `NAME: Flow Tablet
+EVENTS:
+  EV_ABS:
+    ABS_X: {VALUE: 0, MIN: 0, MAX: 1023, FUZZ: 0, FLAT: 0, RES: 10}
+`
+
+	desc, err = ReadInterceptionYAML(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := (AbsInfo{Maximum: 1023, Resolution: 10}), desc.Abs[AbsX]; have != want {
+		t.Fatalf("Want %+v, have %+v", want, have)
+	}
+
+	for _, bad := range []string{
+		"EVENTS:\n  EV_NOPE: [KEY_A]\n",
+		"EVENTS:\n  EV_KEY: [REL_X]\n",
+		"EVENTS:\n  EV_ABS:\n    ABS_X: 1023\n",
+		"EVENTS:\n  EV_ABS:\n    ABS_X: {MAX: 1023\n",
+		"BUSTYPE: BUS_NOPE\n",
+	} {
+		if _, err := ReadInterceptionYAML(strings.NewReader(bad)); err == nil {
+			t.Fatalf("Want error for %q", bad)
+		}
+	}
+}
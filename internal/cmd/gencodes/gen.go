@@ -0,0 +1,354 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// define is a single #define found in a header.
+type define struct {
+	Name    string // E.g.: KEY_A
+	Value   string // E.g.: 30, 0x1e, KEY_HANGEUL or (KEY_MAX+1)
+	Comment string // Trailing comment, if any.
+	Group   *group
+	Prefix  string // The group prefix matching the name.
+	Int     int    // The resolved value.
+	Ref     string // The name referred to by a symbolic value.
+	Add     int    // The value added to Ref.
+}
+
+var (
+	reDefine = regexp.MustCompile(`^#define\s+([A-Z][A-Z0-9_]*)\s+(.*)$`)
+	reNumber = regexp.MustCompile(`^(0x[0-9a-fA-F]+|[0-9]+)$`)
+	reName   = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	reSum    = regexp.MustCompile(`^\(\s*([A-Z][A-Z0-9_]*)\s*\+\s*([0-9]+)\s*\)$`)
+)
+
+// parse reads the #define lines of a header. Lines
+// which do not define a known kind of code are skipped.
+func parse(r io.Reader, defs []*define, byName map[string]*define) ([]*define, error) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		m := reDefine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil || excluded[m[1]] {
+			continue
+		}
+
+		d := &define{Name: m[1], Value: m[2]}
+
+		// Comments may continue on the following lines.
+		if i := strings.Index(d.Value, "/*"); i >= 0 {
+			comment := d.Value[i+2:]
+			for !strings.Contains(comment, "*/") && scanner.Scan() {
+				comment += " " + strings.TrimSpace(scanner.Text())
+			}
+
+			comment, _, _ = strings.Cut(comment, "*/")
+			d.Comment = strings.Join(strings.Fields(comment), " ")
+			d.Value = strings.TrimSpace(d.Value[:i])
+		}
+
+		if d.Group, d.Prefix = groupOf(d.Name); d.Group == nil {
+			continue
+		}
+
+		switch {
+		case reNumber.MatchString(d.Value):
+			n, err := strconv.ParseInt(d.Value, 0, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", d.Name, err)
+			}
+			d.Int = int(n)
+
+		case reName.MatchString(d.Value):
+			d.Ref = d.Value
+
+		case reSum.MatchString(d.Value):
+			s := reSum.FindStringSubmatch(d.Value)
+			d.Ref = s[1]
+			d.Add, _ = strconv.Atoi(s[2])
+
+		default:
+			continue
+		}
+
+		if d.Ref != "" {
+			ref, ok := byName[d.Ref]
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown name %s", d.Name, d.Ref)
+			}
+			d.Int = ref.Int + d.Add
+		}
+
+		if _, ok := byName[d.Name]; ok {
+			return nil, fmt.Errorf("%s: defined twice", d.Name)
+		}
+
+		byName[d.Name] = d
+		defs = append(defs, d)
+	}
+
+	return defs, scanner.Err()
+}
+
+// groupOf returns the group with the longest prefix matching the name.
+func groupOf(name string) (*group, string) {
+	var g *group
+	var prefix string
+
+	for _, grp := range groups {
+		for p := range grp.Prefixes {
+			if strings.HasPrefix(name, p) && len(p) > len(prefix) {
+				g, prefix = grp, p
+			}
+		}
+	}
+
+	return g, prefix
+}
+
+// goName returns the Go name for the given define. Unless listed in
+// goNames, the name is the group's Go prefix, followed by each word of
+// the rest of the name in title case. E.g.: KEY_BRIGHTNESS_AUTO
+// yields KeyBrightnessAuto. A trailing CNT becomes Count.
+func (d *define) goName() string {
+	if n, ok := goNames[d.Name]; ok {
+		return n
+	}
+
+	var sb strings.Builder
+	sb.WriteString(d.Group.Prefixes[d.Prefix])
+
+	for _, w := range strings.Split(d.Name[len(d.Prefix):], "_") {
+		if w == "CNT" {
+			w = "Count"
+		}
+		sb.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+
+	return sb.String()
+}
+
+// goValue returns the Go expression for the value of the define.
+func (d *define) goValue(byName map[string]*define) string {
+	switch {
+	case d.Ref == "":
+		return d.Value
+	case d.Add == 0:
+		return byName[d.Ref].goName()
+	}
+	return fmt.Sprintf("%s + %d", byName[d.Ref].goName(), d.Add)
+}
+
+// isRange returns true if the define marks a range of codes,
+// such as KEY_MAX or KEY_CNT, rather than a single code.
+func (d *define) isRange() bool {
+	rest := d.Name[len(d.Prefix):]
+	return rest == "MAX" || rest == "CNT" || ranges[d.Name]
+}
+
+// Generate returns the Go source for the codes defined in the given headers.
+// The headers are read in order, so names may refer to earlier headers.
+func Generate(headers ...io.Reader) ([]byte, error) {
+	var defs []*define
+	byName := make(map[string]*define)
+
+	for _, r := range headers {
+		var err error
+		if defs, err = parse(r, defs, byName); err != nil {
+			return nil, err
+		}
+	}
+
+	replaced := make(map[string][]string)
+	for old, name := range deprecated {
+		replaced[name] = append(replaced[name], old)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+
+	for _, g := range groups {
+		if !g.used(defs) {
+			continue
+		}
+
+		writeDoc(&buf, "", g.Doc)
+		buf.WriteString("const (\n")
+
+		for _, d := range defs {
+			if d.Group != g {
+				continue
+			}
+
+			name := d.goName()
+
+			if doc, ok := docs[d.Name]; ok {
+				buf.WriteString("\n")
+				writeDoc(&buf, "\t", doc)
+			}
+
+			fmt.Fprintf(&buf, "\t%s = %s", name, d.goValue(byName))
+
+			comment := d.Comment
+			if c, ok := comments[d.Name]; ok {
+				comment = c
+			}
+			if comment != "" {
+				fmt.Fprintf(&buf, " // %s", comment)
+			}
+			buf.WriteString("\n")
+
+			olds := replaced[name]
+			sort.Strings(olds)
+			for _, old := range olds {
+				fmt.Fprintf(&buf, "\n\t// Deprecated: Use %s instead.\n\t%s = %s\n\n", name, old, name)
+			}
+		}
+
+		buf.WriteString(")\n\n")
+	}
+
+	writeTables(&buf, defs)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format: %v", err)
+	}
+
+	return src, nil
+}
+
+// used returns true if any of the defines belongs to the group.
+func (g *group) used(defs []*define) bool {
+	for _, d := range defs {
+		if d.Group == g {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTables writes the name tables for the groups which have one.
+// Where several names share a code, the first literal definition is
+// replaced by a later one. E.g.: BTN_MISC by BTN_0 and BTN_GAMEPAD by
+// BTN_SOUTH. Names defined as another name, such as BTN_A, are no
+// entries of their own. All other names end up in codeAliases.
+func writeTables(buf *bytes.Buffer, defs []*define) {
+	type alias struct {
+		Name string
+		Def  *define
+	}
+
+	var aliases []alias
+	names := make(map[*group]map[int]*define)
+
+	for _, d := range defs {
+		if d.Group.Table == "" || d.isRange() {
+			continue
+		}
+
+		if names[d.Group] == nil {
+			names[d.Group] = make(map[int]*define)
+		}
+
+		tab := names[d.Group]
+
+		if d.Ref != "" {
+			if d.Group.Type != "" {
+				aliases = append(aliases, alias{d.Name, d})
+			}
+			continue
+		}
+
+		if prev, ok := tab[d.Int]; ok && d.Group.Type != "" {
+			aliases = append(aliases, alias{prev.Name, prev})
+		}
+		tab[d.Int] = d
+	}
+
+	fmt.Fprintf(buf, "// codeNames holds the names of the codes, by event type.\n")
+	fmt.Fprintf(buf, "var codeNames = map[int]map[int]string{\n")
+	for _, g := range groups {
+		if g.Type != "" {
+			fmt.Fprintf(buf, "\t%s: %s,\n", g.Type, g.Table)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	for _, g := range groups {
+		if g.Table == "" {
+			continue
+		}
+
+		tab := names[g]
+		keys := make([]int, 0, len(tab))
+		for k := range tab {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+
+		fmt.Fprintf(buf, "// %s holds the names of the %s constants.\n", g.Table, prefixList(g))
+		fmt.Fprintf(buf, "var %s = map[int]string{\n", g.Table)
+		for _, k := range keys {
+			fmt.Fprintf(buf, "\t%s: %q,\n", tab[k].goName(), tab[k].Name)
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	sort.SliceStable(aliases, func(i, j int) bool {
+		if aliases[i].Def.Int != aliases[j].Def.Int {
+			return aliases[i].Def.Int < aliases[j].Def.Int
+		}
+		return aliases[i].Name < aliases[j].Name
+	})
+
+	buf.WriteString("// codeAliases holds alternative names for codes, which are accepted by ParseCode.\n")
+	buf.WriteString("var codeAliases = map[string]int{\n")
+	for _, a := range aliases {
+		fmt.Fprintf(buf, "\t%q: %s,\n", a.Name, a.Def.goName())
+	}
+	buf.WriteString("}\n")
+}
+
+// prefixList returns the header prefixes of the group. E.g.: BTN_ and KEY_
+func prefixList(g *group) string {
+	var list []string
+	for p := range g.Prefixes {
+		list = append(list, p)
+	}
+	sort.Strings(list)
+
+	if len(list) == 1 {
+		return list[0]
+	}
+	return strings.Join(list[:len(list)-1], ", ") + " and " + list[len(list)-1]
+}
+
+// writeDoc writes the given text as a comment with the given indentation.
+func writeDoc(buf *bytes.Buffer, indent, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			fmt.Fprintf(buf, "%s//\n", indent)
+		} else {
+			fmt.Fprintf(buf, "%s// %s\n", indent, line)
+		}
+	}
+}
+
+const header = `// Code generated by gencodes from input-event-codes.h and input.h. DO NOT EDIT.
+
+package evdev
+
+`
@@ -0,0 +1,288 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package main
+
+// group describes a set of related constants, which
+// is generated as a single const block.
+type group struct {
+	Prefixes map[string]string // Prefixes of the header names and their Go names. E.g.: KEY_: Key
+	Type     string            // Go name of the event type, for code groups.
+	Table    string            // Name of the name table, if any.
+	Doc      string            // Doc comment of the const block.
+}
+
+// groups lists the generated const blocks, in order.
+var groups = []*group{
+	{
+		Prefixes: map[string]string{"EV_": "Ev"},
+		Table:    "typeNames",
+		Doc:      `Event types`,
+	},
+	{
+		Prefixes: map[string]string{"INPUT_PROP_": "InputProp"},
+		Table:    "propNames",
+		Doc: `Input device properties and quirks.
+
+Normally, userspace sets up an input device based on the data it emits,
+i.e., the event types. In the case of two devices emitting the same event
+types, additional information can be provided in the form of device
+properties.`,
+	},
+	{
+		Prefixes: map[string]string{"SYN_": "Syn"},
+		Type:     "EvSync",
+		Table:    "synNames",
+		Doc: `Synchronization event values are undefined.
+Their usage is defined only by when they are
+sent in the evdev event stream.
+
+SynReport is used to synchronize and separate
+events into packets of input data changes occurring
+at the same moment in time. For example, motion
+of a mouse may set the RelX and RelY values for
+one motion, then emit a SynReport. The next motion
+will emit more RelX and RelY values and send
+another SynReport.
+
+SynConfig: to be determined.
+
+SynMTReport is used to synchronize and separate
+touch events. See the multi-touch-protocol.txt
+document for more information.
+
+SynDropped is used to indicate buffer overrun
+in the evdev client's event queue.
+Client should ignore all events up to and
+including next SynReport event and query the
+device (using EVIOCG* ioctls) to obtain its current state.`,
+	},
+	{
+		Prefixes: map[string]string{"KEY_": "Key", "BTN_": "Btn"},
+		Type:     "EvKeys",
+		Table:    "keyNames",
+		Doc: `Keys and buttons
+
+Events take the form Key<name> or Btn<name>. For example, KeyA is used
+to represent the 'A' key on a keyboard. When a key is depressed, an event with
+the key's code is emitted with value 1. When the key is released, an event is
+emitted with value 0. Some hardware send events when a key is repeated. These
+events have a value of 2. In general, Key<name> is used for keyboard keys, and
+Btn<name> is used for other types of momentary switch events.
+
+A few codes have special meanings:
+
+  - BtnTool<name>:
+
+  - These codes are used in conjunction with input trackpads, tablets, and
+    touchscreens. These devices may be used with fingers, pens, or other tools.
+    When an event occurs and a tool is used, the corresponding BtnTool<name>
+    code should be set to a value of 1. When the tool is no longer interacting
+    with the input device, the BtnTool<name> code should be reset to 0. All
+    trackpads, tablets, and touchscreens should use at least one BtnTool<name>
+    code when events are generated.
+
+  - BtnTouch:
+    BtnTouch is used for touch contact. While an input tool is determined to be
+    within meaningful physical contact, the value of this property must be set
+    to 1. Meaningful physical contact may mean any contact, or it may mean
+    contact conditioned by an implementation defined property. For example, a
+    touchpad may set the value to 1 only when the touch pressure rises above a
+    certain value. BtnTouch may be combined with BtnTool<name> codes. For
+    example, a pen tablet may set BtnToolPen to 1 and BtnTouch to 0 while the
+    pen is hovering over but not touching the tablet surface.
+
+  - BtnToolFinger, BtnToolDoubleTap, BtnToolTripleTap, BtnToolQuadTap:
+
+  - These codes denote one, two, three, and four finger interaction on a
+    trackpad or touchscreen. For example, if the user uses two fingers and moves
+    them on the touchpad in an effort to scroll content on screen,
+    BtnToolDoubleTap should be set to value 1 for the duration of the motion.
+    Note that all BtnTool<name> codes and the BtnTouch code are orthogonal in
+    purpose. A trackpad event generated by finger touches should generate events
+    for one code from each group. At most only one of these BtnTool<name>
+    codes should have a value of 1 during any synchronization frame.`,
+	},
+	{
+		Prefixes: map[string]string{"REL_": "Rel"},
+		Type:     "EvRelative",
+		Table:    "relNames",
+		Doc: `Relative events describe relative changes in a property.
+For example, a mouse may move to the left by a certain
+number of units, but its absolute position in space is unknown.
+If the absolute position is known, EvAbsolute codes should be used
+instead of EvRelative codes.
+
+RelWheel and RelHWheel are used for vertical and horizontal scroll
+wheels, respectively. RelWheelHiRes and RelHWheelHiRes report the
+same motion in fractions of a detent, in units of 1/120th.`,
+	},
+	{
+		Prefixes: map[string]string{"ABS_": "Abs"},
+		Type:     "EvAbsolute",
+		Table:    "absNames",
+		Doc: `Absolute events describe absolute changes in a property.
+For example, a touchpad may emit coordinates for a touch location.
+A few codes have special meanings:
+
+AbsDistance is used to describe the distance of a tool
+from an interaction surface. This event should only be emitted
+while the tool is hovering, meaning in close proximity to the
+device and while the value of the BtnTouch code is 0.
+If the input device may be used freely in three dimensions,
+consider AbsZ instead.
+
+AbsMt<name> is used to describe multitouch input events.`,
+	},
+	{
+		Prefixes: map[string]string{"SW_": "Sw"},
+		Type:     "EvSwitch",
+		Table:    "swNames",
+		Doc: `Switch events describe stateful binary switches. For example,
+the SwLid code is used to denote when a laptop lid is closed.
+
+Upon binding to a device or resuming from suspend, a driver must report
+the current switch state. This ensures that the device, kernel, and userspace
+state is in sync.
+
+Upon resume, if the switch state is the same as before suspend, then the input
+subsystem will filter out the duplicate switch state reports. The driver does
+not need to keep the state of the switch at any time.`,
+	},
+	{
+		Prefixes: map[string]string{"MSC_": "Misc"},
+		Type:     "EvMisc",
+		Table:    "mscNames",
+		Doc: `Miscellaneous events are used for input and output events
+that do not fall under other categories.
+
+MiscTimestamp has a special meaning.
+It is used to report the number of microseconds since the last reset. This event
+should be coded as an uint32 value, which is allowed to wrap around with
+no special consequence. It is assumed that the time difference between two
+consecutive events is reliable on a reasonable time scale (hours).
+A reset to zero can happen, in which case the time since the last event is
+unknown. If the device does not provide this information, the driver must
+not provide it to user space.`,
+	},
+	{
+		Prefixes: map[string]string{"LED_": "Led"},
+		Type:     "EvLed",
+		Table:    "ledNames",
+		Doc: `LED events are used for input and output to set and query the state of
+various LEDs on devices.`,
+	},
+	{
+		Prefixes: map[string]string{"REP_": "Rep"},
+		Type:     "EvRepeat",
+		Table:    "repNames",
+		Doc:      `Repeat events are used for specifying autorepeating events.`,
+	},
+	{
+		Prefixes: map[string]string{"SND_": "Snd"},
+		Type:     "EvSound",
+		Table:    "sndNames",
+		Doc: `Sound events are used for sending sound
+commands to simple sound output devices.`,
+	},
+	{
+		Prefixes: map[string]string{"MT_TOOL_": "MtTool"},
+		Doc:      `Multitouch tools, as reported by AbsMTToolType.`,
+	},
+	{
+		Prefixes: map[string]string{"FF_STATUS_": "FFStatus"},
+		Type:     "EvForceFeedbackStatus",
+		Table:    "ffStatusNames",
+		Doc:      `Values describing the status of a force-feedback effect`,
+	},
+	{
+		Prefixes: map[string]string{"FF_": "FF"},
+		Type:     "EvForceFeedback",
+		Table:    "ffNames",
+		Doc: `Force feedback effect types, periodic effect waveforms
+and the force feedback device properties FFGain and FFAutoCenter.`,
+	},
+	{
+		Prefixes: map[string]string{"BUS_": "Bus"},
+		Table:    "busNames",
+		Doc:      `Bus types, as found in Id.BusType.`,
+	},
+}
+
+// excluded lists names which match a group, but are no codes.
+var excluded = map[string]bool{
+	"EV_VERSION": true,
+}
+
+// ranges lists names which mark a range of codes, rather than
+// a single code. They are left out of the name tables.
+var ranges = map[string]bool{
+	"KEY_MIN_INTERESTING": true,
+	"FF_EFFECT_MIN":       true,
+	"FF_EFFECT_MAX":       true,
+	"FF_WAVEFORM_MIN":     true,
+	"FF_WAVEFORM_MAX":     true,
+	"FF_MAX_EFFECTS":      true,
+}
+
+// docs holds doc comments for individual constants,
+// which the headers only describe in prose.
+var docs = map[string]string{
+	"INPUT_PROP_POINTER": `The InputPropPointer property indicates that the device is not transposed
+on the screen and thus requires use of an on-screen pointer to trace user's
+movements. Typical pointer devices: touchpads, tablets, mice; non-pointer
+device: touchscreen.
+
+If neither InputPropDirect or InputPropPointer are set, the property is
+considered undefined and the device type should be deduced in the
+traditional way, using emitted event types.`,
+
+	"INPUT_PROP_DIRECT": `The InputPropDirect property indicates that device coordinates should be
+directly mapped to screen coordinates (not taking into account trivial
+transformations, such as scaling, flipping and rotating). Non-direct input
+devices require non-trivial transformation, such as absolute to relative
+transformation for touchpads. Typical direct input devices: touchscreens,
+drawing tablets; non-direct devices: touchpads, mice.
+
+If neither InputPropDirect or InputPropPointer are set, the property is
+considered undefined and the device type should be deduced in the
+traditional way, using emitted event types.`,
+
+	"INPUT_PROP_BUTTONPAD": `For touchpads where the button is placed beneath the surface, such that
+pressing down on the pad causes a button click, this property should be
+set. Common in clickpad notebooks and macbooks from 2009 and onwards.
+
+Originally, the buttonpad property was coded into the bcm5974 driver
+version field under the name integrated button. For backwards
+compatibility, both methods need to be checked in userspace.`,
+
+	"INPUT_PROP_SEMI_MT": `Some touchpads, most common between 2008 and 2011, can detect the presence
+of multiple contacts without resolving the individual positions; only the
+number of contacts and a rectangular shape is known. For such
+touchpads, the semi-mt property should be set.
+
+Depending on the device, the rectangle may enclose all touches, like a
+bounding box, or just some of them, for instance the two most recent
+touches. The diversity makes the rectangle of limited use, but some
+gestures can normally be extracted from it.
+
+If InputPropSemiMT is not set, the device is assumed to be a true
+multi-touch device.`,
+}
+
+// comments holds trailing comments for constants, which
+// replace or add to those found in the headers.
+var comments = map[string]string{
+	"EV_SYN":       "Synchronisation events.",
+	"EV_KEY":       "Absolute binary results, such as keys and buttons.",
+	"EV_REL":       "Relative results, such as the axes on a mouse.",
+	"EV_ABS":       "Absolute integer results, such as the axes on a joystick or for a tablet.",
+	"EV_MSC":       "Miscellaneous uses that didn't fit anywhere else.",
+	"EV_SW":        "Used to describe stateful binary switches.",
+	"EV_LED":       "LEDs and similar indications.",
+	"EV_SND":       "Sound output, such as buzzers.",
+	"EV_REP":       "Enables autorepeat of keys in the input core.",
+	"EV_FF":        "Sends force-feedback effects to a device.",
+	"EV_PWR":       "Power management events.",
+	"EV_FF_STATUS": "Device reporting of force-feedback effects back to the host.",
+}
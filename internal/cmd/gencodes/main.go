@@ -0,0 +1,52 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+// Command gencodes generates the event code constants and their name
+// tables from copies of the kernel headers <linux/input-event-codes.h>
+// and <linux/input.h>. It is run through go generate in the package root.
+//
+// To update the codes, copy newer headers into the linux directory
+// next to this file and run go generate.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("headers", "linux", "Directory holding input-event-codes.h and input.h.")
+	out := flag.String("o", "zcodes.go", "Output file.")
+	flag.Parse()
+
+	src, err := generateFrom(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gencodes: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err = os.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "gencodes: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generateFrom generates the source from the headers in the given directory.
+func generateFrom(dir string) ([]byte, error) {
+	var readers []io.Reader
+
+	for _, name := range []string{"input-event-codes.h", "input.h"} {
+		fd, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		defer fd.Close()
+		readers = append(readers, fd)
+	}
+
+	return Generate(readers...)
+}
@@ -0,0 +1,76 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+	want, err := generateFrom("linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := os.ReadFile("../../../zcodes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(have, want) {
+		t.Fatalf("zcodes.go is out of date with the headers; run go generate")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	header := `
+#define EV_KEY			0x01
+#define EV_MAX			0x1f
+#define EV_CNT			(EV_MAX+1)
+#define KEY_HANGEUL		122
+#define KEY_HANJA		123
+#define KEY_HANGUEL		KEY_HANGEUL
+#define KEY_BRIGHTNESS_AUTO	244	/* Set Auto Brightness: manual
+					  brightness control is off */
+#define KEY_BRIGHTNESS_ZERO	KEY_BRIGHTNESS_AUTO
+#define BTN_MISC		0x100
+#define BTN_0			0x100
+#define KEY_MAX			0x2ff
+`
+	src, err := Generate(strings.NewReader(header))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ignore the alignment done by gofmt.
+	src = []byte(strings.Join(strings.Fields(string(src)), " "))
+
+	for _, want := range []string{
+		"EvKeys = 0x01 // Absolute binary results, such as keys and buttons.",
+		"EvCount = EvMax + 1",
+		"KeyBrightnessAuto = 244 // Set Auto Brightness: manual brightness control is off",
+		"KeyBrightnessZero = KeyBrightnessAuto",
+		`Btn0: "BTN_0"`,
+		`"BTN_MISC": BtnMisc`,
+		`"KEY_HANGUEL": KeyHanguel`,
+	} {
+		if !bytes.Contains(src, []byte(want)) {
+			t.Fatalf("Want %q in:\n%s", want, src)
+		}
+	}
+
+	if bytes.Contains(src, []byte(`KeyMax: "KEY_MAX"`)) {
+		t.Fatalf("Want no table entry for KEY_MAX")
+	}
+}
+
+func TestGenerateUnknownName(t *testing.T) {
+	_, err := Generate(strings.NewReader("#define KEY_A KEY_B\n"))
+	if err == nil {
+		t.Fatalf("Want error for unknown name")
+	}
+}
@@ -0,0 +1,281 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package main
+
+// goNames maps header names to Go names, where these differ from
+// the mechanical translation done by goName. Most of these predate
+// the generator and are kept for compatibility.
+var goNames = map[string]string{
+	"INPUT_PROP_BUTTONPAD":         "InputPropButtonPad",
+	"INPUT_PROP_SEMI_MT":           "InputPropSemiMT",
+	"INPUT_PROP_TOPBUTTONPAD":      "InputPropTopButtonPad",
+	"EV_SYN":                       "EvSync",
+	"EV_KEY":                       "EvKeys",
+	"EV_REL":                       "EvRelative",
+	"EV_ABS":                       "EvAbsolute",
+	"EV_MSC":                       "EvMisc",
+	"EV_SW":                        "EvSwitch",
+	"EV_SND":                       "EvSound",
+	"EV_REP":                       "EvRepeat",
+	"EV_FF":                        "EvForceFeedback",
+	"EV_PWR":                       "EvPower",
+	"EV_FF_STATUS":                 "EvForceFeedbackStatus",
+	"SYN_MT_REPORT":                "SynMTReport",
+	"KEY_ESC":                      "KeyEscape",
+	"KEY_BACKSPACE":                "KeyBackSpace",
+	"KEY_LEFTBRACE":                "KeyLeftBrace",
+	"KEY_RIGHTBRACE":               "KeyRightBrace",
+	"KEY_LEFTCTRL":                 "KeyLeftCtrl",
+	"KEY_SEMICOLON":                "KeySemiColon",
+	"KEY_LEFTSHIFT":                "KeyLeftShift",
+	"KEY_BACKSLASH":                "KeyBackSlash",
+	"KEY_RIGHTSHIFT":               "KeyRightShift",
+	"KEY_KPASTERISK":               "KeyKPAsterisk",
+	"KEY_LEFTALT":                  "KeyLeftAlt",
+	"KEY_CAPSLOCK":                 "KeyCapsLock",
+	"KEY_NUMLOCK":                  "KeyNumLock",
+	"KEY_SCROLLLOCK":               "KeyScrollLock",
+	"KEY_KP7":                      "KeyKP7",
+	"KEY_KP8":                      "KeyKP8",
+	"KEY_KP9":                      "KeyKP9",
+	"KEY_KPMINUS":                  "KeyKPMinus",
+	"KEY_KP4":                      "KeyKP4",
+	"KEY_KP5":                      "KeyKP5",
+	"KEY_KP6":                      "KeyKP6",
+	"KEY_KPPLUS":                   "KeyKPPlus",
+	"KEY_KP1":                      "KeyKP1",
+	"KEY_KP2":                      "KeyKP2",
+	"KEY_KP3":                      "KeyKP3",
+	"KEY_KP0":                      "KeyKP0",
+	"KEY_KPDOT":                    "KeyKPDot",
+	"KEY_102ND":                    "Key102ND",
+	"KEY_RO":                       "KeyRO",
+	"KEY_KATAKANAHIRAGANA":         "KeyKatakanaHiragana",
+	"KEY_KPJPCOMMA":                "KeyKPJPComma",
+	"KEY_KPENTER":                  "KeyKPEnter",
+	"KEY_RIGHTCTRL":                "KeyRightCtrl",
+	"KEY_KPSLASH":                  "KeyKPSlash",
+	"KEY_SYSRQ":                    "KeySysRQ",
+	"KEY_RIGHTALT":                 "KeyRightAlt",
+	"KEY_LINEFEED":                 "KeyLineFeed",
+	"KEY_PAGEUP":                   "KeyPageUp",
+	"KEY_PAGEDOWN":                 "KeyPageDown",
+	"KEY_VOLUMEDOWN":               "KeyVolumeDown",
+	"KEY_VOLUMEUP":                 "KeyVolumeUp",
+	"KEY_KPEQUAL":                  "KeyKPEqual",
+	"KEY_KPPLUSMINUS":              "KeyKPPlusMinus",
+	"KEY_KPCOMMA":                  "KeyKPComma",
+	"KEY_LEFTMETA":                 "KeyLeftMeta",
+	"KEY_RIGHTMETA":                "KeyRightMeta",
+	"KEY_SENDFILE":                 "KeySendFile",
+	"KEY_DELETEFILE":               "KeyDeleteFile",
+	"KEY_XFER":                     "KeyXFer",
+	"KEY_WWW":                      "KeyWWW",
+	"KEY_MSDOS":                    "KeyMSDOS",
+	"KEY_CYCLEWINDOWS":             "KeyCycleWindows",
+	"KEY_CLOSECD":                  "KeyCloseCD",
+	"KEY_EJECTCD":                  "KeyEjectCD",
+	"KEY_EJECTCLOSECD":             "KeyEjectCloseCD",
+	"KEY_NEXTSONG":                 "KeyNextSong",
+	"KEY_PLAYPAUSE":                "KeyPlayPause",
+	"KEY_PREVIOUSSONG":             "KeyPreviousSong",
+	"KEY_STOPCD":                   "KeyStopCD",
+	"KEY_ISO":                      "KeyISO",
+	"KEY_SCROLLUP":                 "KeyScrollUp",
+	"KEY_SCROLLDOWN":               "KeyScrollDown",
+	"KEY_KPLEFTPAREN":              "KeyKPLeftParen",
+	"KEY_KPRIGHTPAREN":             "KeyKPRightParen",
+	"KEY_PLAYCD":                   "KeyPlayCD",
+	"KEY_PAUSECD":                  "KeyPauseCD",
+	"KEY_FASTFORWARD":              "KeyFastForward",
+	"KEY_BASSBOOST":                "KeyBassBoost",
+	"KEY_HP":                       "KeyHP",
+	"KEY_ALTERASE":                 "KeyAltErase",
+	"KEY_BRIGHTNESSDOWN":           "KeyBrightnessDown",
+	"KEY_BRIGHTNESSUP":             "KeyBrightnessUp",
+	"KEY_SWITCHVIDEOMODE":          "KeySwitchVideoMode",
+	"KEY_KBDILLUMTOGGLE":           "KeyKBDIllumToggle",
+	"KEY_KBDILLUMDOWN":             "KeyKBDIllumDown",
+	"KEY_KBDILLUMUP":               "KeyKBDIllumUp",
+	"KEY_FORWARDMAIL":              "KeyForwardMail",
+	"KEY_WLAN":                     "KeyWLAN",
+	"KEY_UWB":                      "KeyUWB",
+	"KEY_VIDEO_PREV":               "KeyVideoPrevious",
+	"KEY_WWAN":                     "KeyWWAN",
+	"KEY_WIMAX":                    "KeyWIMax",
+	"KEY_RFKILL":                   "KeyRFKill",
+	"KEY_MICMUTE":                  "KeyMicMute",
+	"BTN_TL":                       "BtnTL",
+	"BTN_TR":                       "BtnTR",
+	"BTN_TL2":                      "BtnTL2",
+	"BTN_TR2":                      "BtnTR2",
+	"BTN_THUMBL":                   "BtnThumbL",
+	"BTN_THUMBR":                   "BtnThumbR",
+	"BTN_TOOL_QUINTTAP":            "BtnToolQuintTap",
+	"BTN_TOOL_DOUBLETAP":           "BtnToolDoubleTap",
+	"BTN_TOOL_TRIPLETAP":           "BtnToolTripleTap",
+	"BTN_TOOL_QUADTAP":             "BtnToolQuadTap",
+	"KEY_EPG":                      "KeyEPG",
+	"KEY_PVR":                      "KeyPVR",
+	"KEY_MHP":                      "KeyMHP",
+	"KEY_PC":                       "KeyPC",
+	"KEY_TV":                       "KeyTV",
+	"KEY_TV2":                      "KeyTV2",
+	"KEY_VCR":                      "KeyVCR",
+	"KEY_VCR2":                     "KeyVCR2",
+	"KEY_SAT":                      "KeySAT",
+	"KEY_SAT2":                     "KeySAT2",
+	"KEY_CD":                       "KeyCD",
+	"KEY_DVD":                      "KeyDVD",
+	"KEY_AUX":                      "KeyAUX",
+	"KEY_MP3":                      "KeyMP3",
+	"KEY_CHANNELUP":                "KeyChannelUp",
+	"KEY_CHANNELDOWN":              "KeyChannelDown",
+	"KEY_AB":                       "KeyAB",
+	"KEY_VIDEOPHONE":               "KeyVideoPhone",
+	"KEY_ZOOMIN":                   "KeyZoomIn",
+	"KEY_ZOOMOUT":                  "KeyZoomOut",
+	"KEY_ZOOMRESET":                "KeyZoomReset",
+	"KEY_WORDPROCESSOR":            "KeyWordProcessor",
+	"KEY_GRAPHICSEDITOR":           "KeyGraphicsEditor",
+	"KEY_VOICEMAIL":                "KeyVoiceMail",
+	"KEY_ADDRESSBOOK":              "KeyAddressBook",
+	"KEY_DISPLAYTOGGLE":            "KeyDisplayToggle",
+	"KEY_SPELLCHECK":               "KeySpellCheck",
+	"KEY_FRAMEBACK":                "KeyFrameBack",
+	"KEY_FRAMEFORWARD":             "KeyFrameForward",
+	"KEY_10CHANNELSUP":             "Key10ChannelsUp",
+	"KEY_10CHANNELSDOWN":           "Key10ChannelsDown",
+	"KEY_DEL_EOL":                  "KeyDelEOL",
+	"KEY_DEL_EOS":                  "KeyDelEOS",
+	"KEY_FN":                       "KeyFN",
+	"KEY_FN_ESC":                   "KeyFNEsc",
+	"KEY_FN_F1":                    "KeyFNF1",
+	"KEY_FN_F2":                    "KeyFNF2",
+	"KEY_FN_F3":                    "KeyFNF3",
+	"KEY_FN_F4":                    "KeyFNF4",
+	"KEY_FN_F5":                    "KeyFNF5",
+	"KEY_FN_F6":                    "KeyFNF6",
+	"KEY_FN_F7":                    "KeyFNF7",
+	"KEY_FN_F8":                    "KeyFNF8",
+	"KEY_FN_F9":                    "KeyFNF9",
+	"KEY_FN_F10":                   "KeyFNF10",
+	"KEY_FN_F11":                   "KeyFNF11",
+	"KEY_FN_F12":                   "KeyFNF12",
+	"KEY_FN_1":                     "KeyFN1",
+	"KEY_FN_2":                     "KeyFN2",
+	"KEY_FN_D":                     "KeyFND",
+	"KEY_FN_E":                     "KeyFNE",
+	"KEY_FN_F":                     "KeyFNF",
+	"KEY_FN_S":                     "KeyFNS",
+	"KEY_FN_B":                     "KeyFNB",
+	"KEY_FN_RIGHT_SHIFT":           "KeyFNRightShift",
+	"KEY_BRL_DOT1":                 "KeyBRLDot1",
+	"KEY_BRL_DOT2":                 "KeyBRLDot2",
+	"KEY_BRL_DOT3":                 "KeyBRLDot3",
+	"KEY_BRL_DOT4":                 "KeyBRLDot4",
+	"KEY_BRL_DOT5":                 "KeyBRLDot5",
+	"KEY_BRL_DOT6":                 "KeyBRLDot6",
+	"KEY_BRL_DOT7":                 "KeyBRLDot7",
+	"KEY_BRL_DOT8":                 "KeyBRLDot8",
+	"KEY_BRL_DOT9":                 "KeyBRLDot9",
+	"KEY_BRL_DOT10":                "KeyBRLDot10",
+	"KEY_WPS_BUTTON":               "KeyWPSButton",
+	"KEY_CAMERA_ZOOMIN":            "KeyCameraZoomIn",
+	"KEY_CAMERA_ZOOMOUT":           "KeyCameraZoomOut",
+	"KEY_ALS_TOGGLE":               "KeyALSToggle",
+	"KEY_BUTTONCONFIG":             "KeyButtonConfig",
+	"KEY_TASKMANAGER":              "KeyTaskManager",
+	"KEY_CONTROLPANEL":             "KeyControlPanel",
+	"KEY_APPSELECT":                "KeyAppSelect",
+	"KEY_SCREENSAVER":              "KeyScreenSaver",
+	"KEY_VOICECOMMAND":             "KeyVoiceCommand",
+	"KEY_KBD_LAYOUT_NEXT":          "KeyKBDLayoutNext",
+	"KEY_KBDINPUTASSIST_PREV":      "KeyKBDInputAssistPrev",
+	"KEY_KBDINPUTASSIST_NEXT":      "KeyKBDInputAssistNext",
+	"KEY_KBDINPUTASSIST_PREVGROUP": "KeyKBDInputAssistPrevGroup",
+	"KEY_KBDINPUTASSIST_NEXTGROUP": "KeyKBDInputAssistNextGroup",
+	"KEY_KBDINPUTASSIST_ACCEPT":    "KeyKBDInputAssistAccept",
+	"KEY_KBDINPUTASSIST_CANCEL":    "KeyKBDInputAssistCancel",
+	"KEY_3D_MODE":                  "Key3DMode",
+	"KEY_VOD":                      "KeyVOD",
+	"KEY_FASTREVERSE":              "KeyFastReverse",
+	"KEY_SLOWREVERSE":              "KeySlowReverse",
+	"KEY_ONSCREEN_KEYBOARD":        "KeyOnScreenKeyboard",
+	"KEY_SOS":                      "KeySOS",
+	"KEY_CLEARVU_SONAR":            "KeyClearVuSonar",
+	"KEY_SIDEVU_SONAR":             "KeySideVuSonar",
+	"KEY_KBD_LCD_MENU1":            "KeyKBDLCDMenu1",
+	"KEY_KBD_LCD_MENU2":            "KeyKBDLCDMenu2",
+	"KEY_KBD_LCD_MENU3":            "KeyKBDLCDMenu3",
+	"KEY_KBD_LCD_MENU4":            "KeyKBDLCDMenu4",
+	"KEY_KBD_LCD_MENU5":            "KeyKBDLCDMenu5",
+	"REL_RX":                       "RelRX",
+	"REL_RY":                       "RelRY",
+	"REL_RZ":                       "RelRZ",
+	"REL_HWHEEL":                   "RelHWheel",
+	"REL_HWHEEL_HI_RES":            "RelHWheelHiRes",
+	"ABS_RX":                       "AbsRX",
+	"ABS_RY":                       "AbsRY",
+	"ABS_RZ":                       "AbsRZ",
+	"ABS_HAT0X":                    "AbsHat0X",
+	"ABS_HAT0Y":                    "AbsHat0Y",
+	"ABS_HAT1X":                    "AbsHat1X",
+	"ABS_HAT1Y":                    "AbsHat1Y",
+	"ABS_HAT2X":                    "AbsHat2X",
+	"ABS_HAT2Y":                    "AbsHat2Y",
+	"ABS_HAT3X":                    "AbsHat3X",
+	"ABS_HAT3Y":                    "AbsHat3Y",
+	"ABS_MT_SLOT":                  "AbsMTSlot",
+	"ABS_MT_TOUCH_MAJOR":           "AbsMTTouchMajor",
+	"ABS_MT_TOUCH_MINOR":           "AbsMTTouchMinor",
+	"ABS_MT_WIDTH_MAJOR":           "AbsMTWidthMajor",
+	"ABS_MT_WIDTH_MINOR":           "AbsMTWidthMinor",
+	"ABS_MT_ORIENTATION":           "AbsMTOrientation",
+	"ABS_MT_POSITION_X":            "AbsMTPositionX",
+	"ABS_MT_POSITION_Y":            "AbsMTPositionY",
+	"ABS_MT_TOOL_TYPE":             "AbsMTToolType",
+	"ABS_MT_BLOB_ID":               "AbsMTBlobId",
+	"ABS_MT_TRACKING_ID":           "AbsMTTrackingId",
+	"ABS_MT_PRESSURE":              "AbsMTPressure",
+	"ABS_MT_DISTANCE":              "AbsMTDistance",
+	"ABS_MT_TOOL_X":                "AbsMTToolX",
+	"ABS_MT_TOOL_Y":                "AbsMTToolY",
+	"SW_RFKILL_ALL":                "SwRFKillAll",
+	"SW_VIDEOOUT_INSERT":           "SwVideoOutInsert",
+	"SW_LINEIN_INSERT":             "SwLineInInsert",
+	"MSC_PULSELED":                 "MiscPulseLed",
+	"LED_NUML":                     "LedNumLock",
+	"LED_CAPSL":                    "LedCapsLock",
+	"LED_SCROLLL":                  "LedScrollLock",
+	"BUS_PCI":                      "BusPCI",
+	"BUS_ISAPNP":                   "BusISAPNP",
+	"BUS_USB":                      "BusUSB",
+	"BUS_HIL":                      "BusHIL",
+	"BUS_ISA":                      "BusISA",
+	"BUS_XTKBD":                    "BusXTKBD",
+	"BUS_RS232":                    "BusRS232",
+	"BUS_GAMEPORT":                 "BusGamePort",
+	"BUS_PARPORT":                  "BusParPort",
+	"BUS_ADB":                      "BusADB",
+	"BUS_I2C":                      "BusI2C",
+	"BUS_GSC":                      "BusGSC",
+	"BUS_SPI":                      "BusSPI",
+	"BUS_RMI":                      "BusRMI",
+	"BUS_CEC":                      "BusCEC",
+	"BUS_INTEL_ISHTP":              "BusIntelISHTP",
+	"BUS_AMD_SFH":                  "BusAMDSFH",
+	"FF_AUTOCENTER":                "FFAutoCenter",
+}
+
+// deprecated maps misspelled Go names, which predate the
+// generator, to the names which replace them.
+var deprecated = map[string]string{
+	"KeyCanera":         "KeyCamera",
+	"KeyCalender":       "KeyCalendar",
+	"BtnToolTrippleTap": "BtnToolTripleTap",
+	"BtnTooLRubber":     "BtnToolRubber",
+	"KeyframeForward":   "KeyFrameForward",
+	"AbsMTToolTYPE":     "AbsMTToolType",
+}
@@ -0,0 +1,15 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+//go:build !mips && !mipsle && !mips64 && !mips64le && !ppc64 && !ppc64le
+
+package evdev
+
+// The ioctl encoding shared by most architectures, such as x86 and arm.
+const (
+	_IOC_NONE     = 0x0
+	_IOC_WRITE    = 0x1
+	_IOC_READ     = 0x2
+	_IOC_SIZEBITS = 14
+	_IOC_DIRBITS  = 2
+)
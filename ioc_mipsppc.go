@@ -0,0 +1,16 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+//go:build mips || mipsle || mips64 || mips64le || ppc64 || ppc64le
+
+package evdev
+
+// The ioctl encoding of mips and powerpc, which have a narrower size
+// field and a separate direction bit for ioctls without data.
+const (
+	_IOC_NONE     = 0x1
+	_IOC_READ     = 0x2
+	_IOC_WRITE    = 0x4
+	_IOC_SIZEBITS = 13
+	_IOC_DIRBITS  = 3
+)
@@ -34,6 +34,15 @@ func ioctl(fd, name uintptr, data interface{}) error {
 	return errno
 }
 
+// fcntl performs the given fcntl command and returns its result.
+func fcntl(fd uintptr, cmd, arg int) (int, error) {
+	r, _, errno := syscall.Syscall(syscall.SYS_FCNTL, fd, uintptr(cmd), uintptr(arg))
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(r), nil
+}
+
 var (
 	_EVIOCGVERSION    uintptr
 	_EVIOCGID         uintptr
@@ -54,13 +63,12 @@ func init() {
 	var i int32
 	var id Id
 	var ke KeymapEntry
-	var ffe Effect
 
 	sizeof_int := int(unsafe.Sizeof(i))
 	sizeof_int2 := sizeof_int << 1
 	sizeof_id := int(unsafe.Sizeof(id))
 	sizeof_keymap_entry := int(unsafe.Sizeof(ke))
-	sizeof_effect := int(unsafe.Sizeof(ffe))
+	sizeof_effect := nativeABI.effectSize()
 
 	_EVIOCGVERSION = _IOR('E', 0x01, sizeof_int)
 	_EVIOCGID = _IOR('E', 0x02, sizeof_id)
@@ -120,23 +128,19 @@ func _EVIOCGBIT(ev, len int) uintptr {
 }
 
 func _EVIOCGABS(abs int) uintptr {
-	var v AbsInfo
-	return _IOR('E', 0x40+abs, int(unsafe.Sizeof(v)))
+	return _IOR('E', 0x40+abs, absInfoSize)
 }
 
 func _EVIOCSABS(abs int) uintptr {
-	var v AbsInfo
-	return _IOW('E', 0xc0+abs, int(unsafe.Sizeof(v)))
+	return _IOW('E', 0xc0+abs, absInfoSize)
 }
 
+// The direction values and the widths of the size and direction
+// fields depend on the architecture. See ioc_generic.go and
+// ioc_mipsppc.go.
 const (
-	_IOC_NONE      = 0x0
-	_IOC_WRITE     = 0x1
-	_IOC_READ      = 0x2
 	_IOC_NRBITS    = 8
 	_IOC_TYPEBITS  = 8
-	_IOC_SIZEBITS  = 14
-	_IOC_DIRBITS   = 2
 	_IOC_NRSHIFT   = 0
 	_IOC_NRMASK    = (1 << _IOC_NRBITS) - 1
 	_IOC_TYPEMASK  = (1 << _IOC_TYPEBITS) - 1
@@ -0,0 +1,219 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"time"
+)
+
+// Events returns an iterator over the incoming events. E.g.:
+//
+//	for evt, err := range dev.Events(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(evt)
+//	}
+//
+// The iteration ends without an error once the device is closed.
+// Other errors, such as the device being unplugged or ctx being
+// done, are yielded once as the last element.
+//
+// Events reads from Device.Inbox if the reader is enabled.
+// Otherwise it reads from the device directly.
+func (d *Device) Events(ctx context.Context) iter.Seq2[Event, error] {
+	if d.Inbox != nil {
+		return d.inboxEvents(ctx)
+	}
+	return d.readEvents(ctx)
+}
+
+// inboxEvents iterates over the events received through Device.Inbox.
+func (d *Device) inboxEvents(ctx context.Context) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				yield(Event{}, ctx.Err())
+				return
+
+			case e, ok := <-d.Inbox:
+				if !ok {
+					if err := d.Err(); err != nil && !errors.Is(err, ErrClosed) {
+						yield(Event{}, err)
+					}
+					return
+				}
+
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// readEvents iterates over the events read from the device.
+// Reads are interrupted through a deadline once ctx is done.
+func (d *Device) readEvents(ctx context.Context) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		interrupted := make(chan struct{})
+		stop := context.AfterFunc(ctx, func() {
+			d.fd.SetReadDeadline(time.Now())
+			close(interrupted)
+		})
+
+		defer func() {
+			// If the deadline was set, wait until it was, before clearing it.
+			if !stop() {
+				<-interrupted
+				d.fd.SetReadDeadline(time.Time{})
+			}
+		}()
+
+		buf := make([]Event, eventBufferSize)
+
+		for {
+			n, err := d.read(buf, false)
+
+			switch {
+			case ctx.Err() != nil:
+				yield(Event{}, ctx.Err())
+				return
+			case errors.Is(err, ErrClosed):
+				return
+			case err != nil:
+				yield(Event{}, err)
+				return
+			}
+
+			for _, e := range buf[:n] {
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Frames returns an iterator over the incoming events, grouped into
+// frames. Each frame holds the events up to and including a SynReport.
+// The frame is a new slice, which the caller may keep. E.g.:
+//
+//	for frame, err := range dev.Frames(ctx) {
+//
+// After a SynDropped event, the kernel's queue overflowed. The events up
+// to and including the next SynReport are incomplete and are discarded.
+// Instead, a frame holding only the SynDropped event is yielded, so the
+// caller knows to query the device state again.
+//
+// Errors end the iteration, as described for Device.Events.
+func (d *Device) Frames(ctx context.Context) iter.Seq2[[]Event, error] {
+	return frames(d.Events(ctx))
+}
+
+// Events returns an iterator over the events read from src, such as a
+// Decoder. The iteration ends without an error at the end of src,
+// through io.EOF or ErrClosed. Other errors are yielded once as the
+// last element. E.g.:
+//
+//	for evt, err := range evdev.Events(evdev.NewDecoder(os.Stdin)) {
+func Events(src EventSource) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		buf := make([]Event, eventBufferSize)
+
+		for {
+			n, err := src.ReadEvents(buf)
+
+			for _, e := range buf[:n] {
+				if !yield(e, nil) {
+					return
+				}
+			}
+
+			switch {
+			case errors.Is(err, io.EOF), errors.Is(err, ErrClosed):
+				return
+			case err != nil:
+				yield(Event{}, err)
+				return
+			}
+		}
+	}
+}
+
+// Frames returns an iterator over the events read from src, grouped
+// into frames, as described for Device.Frames. Errors end the
+// iteration, as described for Events.
+func Frames(src EventSource) iter.Seq2[[]Event, error] {
+	return frames(Events(src))
+}
+
+// frames groups the events of the given iterator into frames.
+func frames(events iter.Seq2[Event, error]) iter.Seq2[[]Event, error] {
+	return func(yield func([]Event, error) bool) {
+		var frame []Event
+		var dropped bool
+
+		for e, err := range events {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if e.Type == EvSync {
+				switch e.Code {
+				case SynDropped:
+					frame, dropped = nil, true
+					if !yield([]Event{e}, nil) {
+						return
+					}
+					continue
+
+				case SynReport:
+					if dropped {
+						dropped = false
+						continue
+					}
+
+					frame = append(frame, e)
+					if !yield(frame, nil) {
+						return
+					}
+					frame = nil
+					continue
+				}
+			}
+
+			if !dropped {
+				frame = append(frame, e)
+			}
+		}
+	}
+}
+
+// All returns an iterator over the event types and codes in the
+// capabilities. E.g.: EvKeys and KeyA, if the device has an A key.
+// Event types without codes, such as EvSync, are not listed.
+//
+//	for evtype, code := range caps.All() {
+func (c *Capabilities) All() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for evtype := range c.Events.All() {
+			if evtype == EvSync {
+				continue
+			}
+
+			for code := range c.Bits(evtype).All() {
+				if !yield(evtype, code) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// testFrames holds two frames, the first of which is cut short by a
+// SynDropped event. Its remainder, up to the next SynReport, is stale.
+var testFrames = []Event{
+	{Type: EvRelative, Code: RelX, Value: 1},
+	{Type: EvSync, Code: SynDropped},
+	{Type: EvRelative, Code: RelY, Value: 2},
+	{Type: EvSync, Code: SynReport},
+	{Type: EvKeys, Code: BtnLeft, Value: 1},
+	{Type: EvSync, Code: SynReport},
+}
+
+func TestEvents(t *testing.T) {
+	for _, opts := range [][]Option{nil, {NoReader()}} {
+		dev, w := pipeDevice(t, opts...)
+		writeEvents(t, w, testFrames...)
+		w.Close()
+
+		var have []Event
+		var last error
+
+		for e, err := range dev.Events(context.Background()) {
+			if err != nil {
+				last = err
+				continue
+			}
+			have = append(have, e)
+		}
+
+		if len(have) != len(testFrames) {
+			t.Fatalf("Want %d events, have %d", len(testFrames), len(have))
+		}
+
+		if last != io.EOF {
+			t.Fatalf("Want io.EOF once the writer is gone, have %v", last)
+		}
+	}
+}
+
+func TestEventsClosed(t *testing.T) {
+	for _, opts := range [][]Option{nil, {NoReader()}} {
+		dev, _ := pipeDevice(t, opts...)
+		time.AfterFunc(10*time.Millisecond, func() { dev.Close() })
+
+		for _, err := range dev.Events(context.Background()) {
+			t.Fatalf("Want no elements after close, have %v", err)
+		}
+
+		if opts == nil && !errors.Is(dev.Err(), ErrClosed) {
+			t.Fatalf("Want ErrClosed, have %v", dev.Err())
+		}
+	}
+}
+
+func TestEventsContext(t *testing.T) {
+	for _, opts := range [][]Option{nil, {NoReader()}} {
+		dev, w := pipeDevice(t, opts...)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		writeEvents(t, w, testFrames[0])
+
+		var n int
+		for _, err := range dev.Events(ctx) {
+			if err != nil {
+				if err != context.Canceled {
+					t.Fatalf("Want context.Canceled, have %v", err)
+				}
+				break
+			}
+
+			n++
+			cancel()
+		}
+
+		if n != 1 {
+			t.Fatalf("Want 1 event before cancellation, have %d", n)
+		}
+
+		// The device remains usable.
+		writeEvents(t, w, testFrames[0])
+		for e, err := range dev.Events(context.Background()) {
+			if err != nil || e != testFrames[0] {
+				t.Fatalf("Want %v, have %v, %v", testFrames[0], e, err)
+			}
+			break
+		}
+	}
+}
+
+func TestEventsCancelRace(t *testing.T) {
+	dev, w := pipeDevice(t, NoReader())
+
+	for i := 0; i < 200; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		writeEvents(t, w, testFrames[0])
+
+		// Cancel and stop at once, so the deadline is set concurrently.
+		for range dev.Events(ctx) {
+			cancel()
+			break
+		}
+		cancel()
+
+		// The deadline must not outlive the iteration.
+		writeEvents(t, w, testFrames[0])
+		for e, err := range dev.Events(context.Background()) {
+			if err != nil || e != testFrames[0] {
+				t.Fatalf("Iteration %d: Want %v, have %v, %v", i, testFrames[0], e, err)
+			}
+			break
+		}
+	}
+}
+
+func TestFrames(t *testing.T) {
+	dev, w := pipeDevice(t, NoReader())
+	writeEvents(t, w, testFrames...)
+	w.Close()
+
+	var frames [][]Event
+	for frame, err := range dev.Frames(context.Background()) {
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		frames = append(frames, frame)
+	}
+
+	if len(frames) != 2 {
+		t.Fatalf("Want 2 frames, have %v", frames)
+	}
+
+	if len(frames[0]) != 1 || frames[0][0] != testFrames[1] {
+		t.Fatalf("Want SynDropped frame, have %v", frames[0])
+	}
+
+	if len(frames[1]) != 2 || frames[1][0] != testFrames[4] {
+		t.Fatalf("Want BtnLeft frame, have %v", frames[1])
+	}
+}
+
+func TestCapabilitiesAll(t *testing.T) {
+	caps := testKeyboard().Capabilities
+
+	var have [][2]int
+	for evtype, code := range caps.All() {
+		have = append(have, [2]int{evtype, code})
+	}
+
+	want := [][2]int{{EvKeys, KeyA}, {EvKeys, KeyB}, {EvLed, LedCapsLock}}
+	if len(have) != len(want) {
+		t.Fatalf("Want %v, have %v", want, have)
+	}
+
+	for i := range want {
+		if have[i] != want[i] {
+			t.Fatalf("Want %v, have %v", want, have)
+		}
+	}
+}
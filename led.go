@@ -3,32 +3,16 @@
 
 package evdev
 
-import "unsafe"
-
-// LED events are used for input and output to set and query the state of
-// various LEDs on devices.
-const (
-	LedNumLock    = 0x00
-	LedCapsLock   = 0x01
-	LedScrollLock = 0x02
-	LedCompose    = 0x03
-	LedKana       = 0x04
-	LedSleep      = 0x05
-	LedSuspend    = 0x06
-	LedMute       = 0x07
-	LedMisc       = 0x08
-	LedMail       = 0x09
-	LedCharging   = 0x0a
-	LedMax        = 0x0f
-	LedCount      = LedMax + 1
-)
-
 // LEDState returns the current, global LED state.
 //
 // This is only applicable to devices with EvLed event support.
 func (d *Device) LEDState() Bitset {
-	bs := NewBitset(LedMax)
-	buf := bs.Bytes()
-	ioctl(d.fd.Fd(), _EVIOCGLED(len(buf)), unsafe.Pointer(&buf[0]))
-	return bs
+	return d.bits(_EVIOCGLED, LedCount)
+}
+
+// SoundState returns the current state of the sounds. E.g.: a bell.
+//
+// This is only applicable to devices with EvSound event support.
+func (d *Device) SoundState() Bitset {
+	return d.bits(_EVIOCGSND, SndCount)
 }
@@ -0,0 +1,344 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Session holds several devices recorded together, such as a touchpad
+// and a keyboard. The timestamps of all their events share one clock.
+type Session struct {
+	Devices []*Recording
+}
+
+// ReadLibinput reads a session in the YAML format written by
+// libinput record. Each device is reconstructed from its name, id,
+// codes, absinfo and properties, along with its own list of events.
+// Other sections, such as udev properties or libinput events, are
+// ignored.
+func ReadLibinput(r io.Reader) (*Session, error) {
+	root, err := parseYAML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := root.Get("version"); v == nil || v.Scalar != "1" {
+		return nil, fmt.Errorf("libinput: unsupported version")
+	}
+
+	devices := root.Get("devices")
+	if devices == nil || !devices.IsList {
+		return nil, fmt.Errorf("libinput: no devices")
+	}
+
+	var s Session
+
+	for _, node := range devices.List {
+		rec, err := parseLibinputDevice(node)
+		if err != nil {
+			return nil, fmt.Errorf("libinput: %v", err)
+		}
+		s.Devices = append(s.Devices, rec)
+	}
+
+	return &s, nil
+}
+
+// parseLibinputDevice reads one entry of the devices list.
+func parseLibinputDevice(node *yamlNode) (*Recording, error) {
+	evdev := node.Get("evdev")
+	if evdev == nil {
+		return nil, fmt.Errorf("line %d: device without evdev section", node.Line)
+	}
+
+	desc := newEmptyDescription()
+	rec := &Recording{Description: desc}
+
+	if n := node.Get("node"); n != nil {
+		rec.Node = n.Scalar
+	}
+
+	if n := evdev.Get("name"); n != nil {
+		desc.Name = n.Scalar
+	}
+
+	id, err := evdev.Get("id").Ints()
+	if err != nil {
+		return nil, err
+	}
+	if len(id) == 4 {
+		desc.Id = Id{BusType: uint16(id[0]), Vendor: uint16(id[1]), Product: uint16(id[2]), Version: uint16(id[3])}
+	}
+
+	if codes := evdev.Get("codes"); codes != nil {
+		for _, key := range codes.Keys {
+			evtype, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad event type %q", codes.Line, key)
+			}
+
+			list, err := codes.Map[key].Ints()
+			if err != nil {
+				return nil, err
+			}
+
+			desc.Events.Set(evtype)
+			if evtype == EvSync {
+				continue
+			}
+
+			bs := desc.Bits(evtype)
+			for _, code := range list {
+				bs.Set(int(code))
+			}
+		}
+	}
+
+	if absinfo := evdev.Get("absinfo"); absinfo != nil {
+		for _, key := range absinfo.Keys {
+			axis, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad axis %q", absinfo.Line, key)
+			}
+
+			v, err := absinfo.Map[key].Ints()
+			if err != nil {
+				return nil, err
+			}
+			if len(v) < 5 {
+				return nil, fmt.Errorf("line %d: want 5 values for axis %d", absinfo.Map[key].Line, axis)
+			}
+
+			desc.Abs[axis] = AbsInfo{
+				Minimum:    int32(v[0]),
+				Maximum:    int32(v[1]),
+				Fuzz:       int32(v[2]),
+				Flat:       int32(v[3]),
+				Resolution: int32(v[4]),
+			}
+		}
+	}
+
+	props, err := evdev.Get("properties").Ints()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range props {
+		desc.Properties.Set(int(p))
+	}
+
+	events := node.Get("events")
+	if events == nil {
+		return rec, nil
+	}
+
+	for _, frame := range events.List {
+		// Frames of libinput events carry no evdev list.
+		evdev := frame.Get("evdev")
+		if evdev == nil {
+			continue
+		}
+
+		for _, item := range evdev.List {
+			v, err := item.Ints()
+			if err != nil {
+				return nil, err
+			}
+			if len(v) != 5 {
+				return nil, fmt.Errorf("line %d: want [sec, usec, type, code, value]", item.Line)
+			}
+
+			rec.Events = append(rec.Events, Event{
+				Time:  syscall.NsecToTimeval(v[0]*1e9 + v[1]*1e3),
+				Type:  uint16(v[2]),
+				Code:  uint16(v[3]),
+				Value: int32(v[4]),
+			})
+		}
+	}
+
+	return rec, nil
+}
+
+// WriteLibinput writes the session in the YAML format used by
+// libinput record. The timestamps are written relative to the
+// earliest event of any device, so they stay aligned.
+func WriteLibinput(w io.Writer, s *Session) error {
+	bw := bufio.NewWriter(w)
+
+	start := s.start()
+
+	fmt.Fprintf(bw, "# libinput record\n")
+	fmt.Fprintf(bw, "version: 1\n")
+	fmt.Fprintf(bw, "ndevices: %d\n", len(s.Devices))
+	fmt.Fprintf(bw, "devices:\n")
+
+	for _, rec := range s.Devices {
+		writeLibinputDevice(bw, rec, start)
+	}
+
+	return bw.Flush()
+}
+
+// start returns the timestamp of the earliest event in the session.
+func (s *Session) start() time.Duration {
+	var start time.Duration
+	var found bool
+
+	for _, rec := range s.Devices {
+		for _, e := range rec.Events {
+			t := time.Duration(syscall.TimevalToNsec(e.Time))
+			if !found || t < start {
+				start, found = t, true
+			}
+			break
+		}
+	}
+
+	return start
+}
+
+func writeLibinputDevice(w *bufio.Writer, rec *Recording, start time.Duration) {
+	desc := rec.Description
+	id := desc.Id
+
+	fmt.Fprintf(w, "- node: %s\n", rec.Node)
+	fmt.Fprintf(w, "  evdev:\n")
+	fmt.Fprintf(w, "    # Name: %s\n", desc.Name)
+	fmt.Fprintf(w, "    # ID: bus 0x%x vendor 0x%x product 0x%x version 0x%x\n", id.BusType, id.Vendor, id.Product, id.Version)
+	fmt.Fprintf(w, "    name: %s\n", yamlQuote(desc.Name))
+	fmt.Fprintf(w, "    id: [%d, %d, %d, %d]\n", id.BusType, id.Vendor, id.Product, id.Version)
+	fmt.Fprintf(w, "    codes:\n")
+
+	for evtype := range desc.Events.All() {
+		var codes []string
+		if evtype == EvSync {
+			// Like libevdev, list the synchronization codes.
+			codes = []string{"0", "1", "2", "3"}
+		} else {
+			for code := range desc.Bits(evtype).All() {
+				codes = append(codes, fmt.Sprint(code))
+			}
+		}
+		fmt.Fprintf(w, "      %d: [%s] # %s\n", evtype, strings.Join(codes, ", "), TypeName(evtype))
+	}
+
+	if desc.Absolute.Count() > 0 {
+		fmt.Fprintf(w, "    absinfo:\n")
+		for axis := range desc.Absolute.All() {
+			info := desc.Abs[axis]
+			fmt.Fprintf(w, "      %d: [%d, %d, %d, %d, %d]\n", axis,
+				info.Minimum, info.Maximum, info.Fuzz, info.Flat, info.Resolution)
+		}
+	}
+
+	var props []string
+	for p := range desc.Properties.All() {
+		props = append(props, fmt.Sprint(p))
+	}
+	fmt.Fprintf(w, "    properties: [%s]\n", strings.Join(props, ", "))
+
+	fmt.Fprintf(w, "  events:\n")
+
+	var last time.Duration
+	frame := true
+
+	for _, e := range rec.Events {
+		t := time.Duration(syscall.TimevalToNsec(e.Time)) - start
+		if frame {
+			fmt.Fprintf(w, "  - evdev:\n")
+			frame = false
+		}
+
+		fmt.Fprintf(w, "    - [%3d, %6d, %3d, %3d, %7d] # ", t/time.Second,
+			t%time.Second/time.Microsecond, e.Type, e.Code, e.Value)
+
+		if e.Type == EvSync && e.Code == SynReport {
+			fmt.Fprintf(w, "------------ SYN_REPORT (%d) ---------- %+dms\n", e.Value, (t-last)/time.Millisecond)
+			last = t
+			frame = true
+		} else {
+			fmt.Fprintf(w, "%s / %-20s %d\n", evemuName(TypeName(int(e.Type)), e.Type),
+				evemuName(CodeName(int(e.Type), int(e.Code)), e.Code), e.Value)
+		}
+	}
+}
+
+// RecordLibinput records the given devices into one session, until ctx is
+// done or all devices are closed, and writes it to w in the format used by
+// libinput record. The events are held in memory until then, since the
+// format lists the events of each device separately. An error reading
+// from a device, other than ctx being done, is returned after writing
+// the events recorded so far.
+func RecordLibinput(ctx context.Context, w io.Writer, devs ...*Device) error {
+	s := &Session{Devices: make([]*Recording, len(devs))}
+	errs := make([]error, len(devs))
+
+	var wg sync.WaitGroup
+
+	for i, dev := range devs {
+		s.Devices[i] = &Recording{Description: dev.Describe()}
+
+		wg.Add(1)
+		go func(rec *Recording, dev *Device, err *error) {
+			defer wg.Done()
+
+			for e, rerr := range dev.Events(ctx) {
+				if rerr != nil {
+					if ctx.Err() == nil {
+						*err = rerr
+					}
+					return
+				}
+				rec.Events = append(rec.Events, e)
+			}
+		}(s.Devices[i], dev, &errs[i])
+	}
+
+	wg.Wait()
+
+	if err := WriteLibinput(w, s); err != nil {
+		return err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Interleaved returns the events of all devices, ordered by their
+// timestamps. Each event is paired with the index of its device.
+// Events with equal timestamps keep the order of the devices.
+func (s *Session) Interleaved() []SessionEvent {
+	var list []SessionEvent
+	for i, rec := range s.Devices {
+		for _, e := range rec.Events {
+			list = append(list, SessionEvent{Device: i, Event: e})
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return syscall.TimevalToNsec(list[i].Event.Time) < syscall.TimevalToNsec(list[j].Event.Time)
+	})
+	return list
+}
+
+// SessionEvent is an event of one of the devices in a Session.
+type SessionEvent struct {
+	Device int // Index in Session.Devices.
+	Event  Event
+}
@@ -0,0 +1,196 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func readSession(t *testing.T) *Session {
+	fd, err := os.Open("testdata/session.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	s, err := ReadLibinput(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestReadLibinput(t *testing.T) {
+	s := readSession(t)
+
+	if len(s.Devices) != 2 {
+		t.Fatalf("Want 2 devices, have %d", len(s.Devices))
+	}
+
+	pad, kbd := s.Devices[0], s.Devices[1]
+
+	if pad.Node != "/dev/input/event5" || pad.Description.Name != "SynPS/2 Synaptics TouchPad" {
+		t.Fatalf("Want touchpad, have %q at %s", pad.Description.Name, pad.Node)
+	}
+
+	if want := (Id{BusType: BusI8042, Vendor: 2, Product: 7, Version: 433}); pad.Description.Id != want {
+		t.Fatalf("Want %+v, have %+v", want, pad.Description.Id)
+	}
+
+	checks := []struct {
+		Name string
+		Have Bitset
+		Want string
+	}{
+		{"pad properties", pad.Description.Properties, "{0, 2}"},
+		{"pad events", pad.Description.Events, "{0, 1, 3}"},
+		{"pad keys", pad.Description.Keys, "{272, 325, 330}"},
+		{"pad axes", pad.Description.Absolute, "{0, 1, 47, 53, 54, 57}"},
+		{"kbd events", kbd.Description.Events, "{0, 1, 4, 17, 20}"},
+		{"kbd leds", kbd.Description.LEDs, "{0, 1, 2}"},
+	}
+
+	for _, c := range checks {
+		if c.Have.String() != c.Want {
+			t.Fatalf("%s: want %s, have %s", c.Name, c.Want, c.Have)
+		}
+	}
+
+	if want := (AbsInfo{Minimum: 1096, Maximum: 4758, Resolution: 66}); pad.Description.Abs[AbsMTPositionY] != want {
+		t.Fatalf("Want %+v, have %+v", want, pad.Description.Abs[AbsMTPositionY])
+	}
+
+	if len(pad.Events) != 15 || len(kbd.Events) != 5 {
+		t.Fatalf("Want 15 and 5 events, have %d and %d", len(pad.Events), len(kbd.Events))
+	}
+
+	if e := pad.Events[11]; e.Code != AbsMTTrackingId || e.Value != -1 || e.Time.Sec != 1 || e.Time.Usec != 500000 {
+		t.Fatalf("Want end of touch at 1.5s, have %+v", e)
+	}
+}
+
+func TestSessionInterleaved(t *testing.T) {
+	list := readSession(t).Interleaved()
+
+	if len(list) != 20 {
+		t.Fatalf("Want 20 events, have %d", len(list))
+	}
+
+	// The key press happens between the touchpad's second and last frames.
+	var order []int
+	for _, se := range list {
+		if n := len(order); n == 0 || order[n-1] != se.Device {
+			order = append(order, se.Device)
+		}
+	}
+
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 0 {
+		t.Fatalf("Want devices 0, 1, 0, have %v", order)
+	}
+}
+
+func TestWriteLibinput(t *testing.T) {
+	s := readSession(t)
+
+	// Shift all timestamps. The output starts at zero again.
+	for _, rec := range s.Devices {
+		for i := range rec.Events {
+			rec.Events[i].Time.Sec += 1700000000
+		}
+	}
+
+	var out bytes.Buffer
+	if err := WriteLibinput(&out, s); err != nil {
+		t.Fatal(err)
+	}
+
+	back, err := ReadLibinput(&out)
+	if err != nil {
+		t.Fatalf("%v:\n%s", err, out.String())
+	}
+
+	want := readSession(t)
+
+	for i, rec := range back.Devices {
+		w := want.Devices[i]
+
+		if rec.Node != w.Node || rec.Description.Name != w.Description.Name || rec.Description.Id != w.Description.Id {
+			t.Fatalf("Device %d: want %s, have %s", i, w.Description.Name, rec.Description.Name)
+		}
+
+		for _, evtype := range []int{EvSync, EvKeys, EvAbsolute, EvLed} {
+			if !rec.Description.Bits(evtype).Equal(w.Description.Bits(evtype)) {
+				t.Fatalf("Device %d: type %d: want %v, have %v", i, evtype,
+					w.Description.Bits(evtype), rec.Description.Bits(evtype))
+			}
+		}
+
+		if len(rec.Events) != len(w.Events) {
+			t.Fatalf("Device %d: want %d events, have %d", i, len(w.Events), len(rec.Events))
+		}
+
+		for n := range rec.Events {
+			if rec.Events[n] != w.Events[n] {
+				t.Fatalf("Device %d: event %d: want %v, have %v", i, n, w.Events[n], rec.Events[n])
+			}
+		}
+	}
+}
+
+func TestReadLibinputMalformed(t *testing.T) {
+	for _, text := range []string{
+		"version: 2\ndevices: []\n",
+		"version: 1\n",
+		"version: 1\ndevices:\n- node: /dev/input/event0\n",
+		"version: 1\ndevices:\n- evdev:\n    id: [1, x, 3, 4]\n",
+		"version: 1\ndevices:\n- evdev:\n    name: \"pad\"\n  events:\n  - evdev:\n    - [0, 0, 1]\n",
+	} {
+		if _, err := ReadLibinput(strings.NewReader(text)); err == nil {
+			t.Fatalf("Want error for:\n%s", text)
+		}
+	}
+}
+
+func TestRecordLibinput(t *testing.T) {
+	pad, pw := pipeDevice(t)
+	kbd, kw := pipeDevice(t)
+
+	tv := func(us int64) syscall.Timeval { return syscall.NsecToTimeval(5e9 + us*1e3) }
+
+	writeEvents(t, pw,
+		Event{tv(1000), EvAbsolute, AbsX, 10},
+		Event{tv(1000), EvSync, SynReport, 0},
+	)
+	writeEvents(t, kw,
+		Event{tv(0), EvKeys, KeyA, 1},
+		Event{tv(0), EvSync, SynReport, 0},
+	)
+	pw.Close()
+	kw.Close()
+
+	var out bytes.Buffer
+	if err := RecordLibinput(context.Background(), &out, pad, kbd); err != io.EOF {
+		t.Fatalf("Want io.EOF, have %v", err)
+	}
+
+	s, err := ReadLibinput(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The keyboard's event comes first, so the touchpad starts at 1ms.
+	if e := s.Devices[0].Events[0]; e.Time.Sec != 0 || e.Time.Usec != 1000 {
+		t.Fatalf("Want touchpad event at 1ms, have %v", e)
+	}
+
+	if e := s.Devices[1].Events[0]; e.Time.Sec != 0 || e.Time.Usec != 0 || e.Code != KeyA {
+		t.Fatalf("Want KeyA at 0, have %v", e)
+	}
+}
@@ -0,0 +1,131 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+//go:generate go run ./internal/cmd/gencodes -headers internal/cmd/gencodes/linux -o zcodes.go
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeName returns the kernel name of the given event type. E.g.: EV_KEY.
+// This returns an empty string if the type is unknown.
+func TypeName(evtype int) string {
+	return typeNames[evtype]
+}
+
+// CodeName returns the kernel name of the given code for the given
+// event type. E.g.: KEY_A, BTN_LEFT or ABS_MT_SLOT.
+// This returns an empty string if the code is unknown.
+func CodeName(evtype, code int) string {
+	return codeNames[evtype][code]
+}
+
+// PropName returns the kernel name of the given device property.
+// E.g.: INPUT_PROP_DIRECT. This returns an empty string if the
+// property is unknown.
+func PropName(prop int) string {
+	return propNames[prop]
+}
+
+// BusName returns the kernel name of the given bus type. E.g.: BUS_USB.
+// This returns an empty string if the bus type is unknown.
+func BusName(bus int) string {
+	return busNames[bus]
+}
+
+// ParseType returns the event type with the given kernel name. E.g.: EV_KEY.
+func ParseType(name string) (int, error) {
+	for evtype, n := range typeNames {
+		if n == name {
+			return evtype, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown event type %q", name)
+}
+
+// codePrefixes maps the prefixes of code names to their event types.
+// Longer prefixes come first, so FF_STATUS_ wins over FF_.
+var codePrefixes = []struct {
+	Prefix string
+	Type   int
+}{
+	{"FF_STATUS_", EvForceFeedbackStatus},
+	{"SYN_", EvSync},
+	{"KEY_", EvKeys},
+	{"BTN_", EvKeys},
+	{"REL_", EvRelative},
+	{"ABS_", EvAbsolute},
+	{"MSC_", EvMisc},
+	{"SW_", EvSwitch},
+	{"LED_", EvLed},
+	{"SND_", EvSound},
+	{"REP_", EvRepeat},
+	{"FF_", EvForceFeedback},
+}
+
+// ParseCode returns the event type and code with the given kernel name.
+// E.g.: BTN_LEFT yields EvKeys and BtnLeft. The event type follows
+// from the name's prefix. Alternative names, such as BTN_A for
+// BTN_SOUTH, are accepted too.
+func ParseCode(name string) (evtype, code int, err error) {
+	for _, p := range codePrefixes {
+		if !strings.HasPrefix(name, p.Prefix) {
+			continue
+		}
+
+		if code, ok := codeAliases[name]; ok {
+			return p.Type, code, nil
+		}
+
+		for code, n := range codeNames[p.Type] {
+			if n == name {
+				return p.Type, code, nil
+			}
+		}
+		break
+	}
+
+	return 0, 0, fmt.Errorf("unknown event code %q", name)
+}
+
+// String returns the event in the form: <time> <type> <code> <value>.
+// E.g.: 1700000000.123456 EV_KEY KEY_A 1
+// Unknown types and codes are printed as hexadecimal numbers.
+func (e Event) String() string {
+	sec, nsec := e.Time.Unix()
+
+	evtype := TypeName(int(e.Type))
+	if evtype == "" {
+		evtype = fmt.Sprintf("0x%02x", e.Type)
+	}
+
+	code := CodeName(int(e.Type), int(e.Code))
+	if code == "" {
+		code = fmt.Sprintf("0x%02x", e.Code)
+	}
+
+	return fmt.Sprintf("%d.%06d %s %s %d", sec, nsec/1e3, evtype, code, e.Value)
+}
+
+// String returns the identity in the form: <bus> <vendor>:<product> v<version>.
+// E.g.: BUS_USB 046d:c52b v0111
+func (id Id) String() string {
+	bus := BusName(int(id.BusType))
+	if bus == "" {
+		bus = fmt.Sprintf("0x%02x", id.BusType)
+	}
+	return fmt.Sprintf("%s %04x:%04x v%04x", bus, id.Vendor, id.Product, id.Version)
+}
+
+// String returns the indices of the bits which are set. E.g.: {0, 1, 4}
+// Use TypeName or CodeName to name them.
+func (b Bitset) String() string {
+	var list []string
+	b.Each(func(i int) {
+		list = append(list, fmt.Sprint(i))
+	})
+	return "{" + strings.Join(list, ", ") + "}"
+}
@@ -0,0 +1,120 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestCodeName(t *testing.T) {
+	tests := []struct {
+		Type, Code int
+		Want       string
+	}{
+		{EvSync, SynReport, "SYN_REPORT"},
+		{EvKeys, KeyA, "KEY_A"},
+		{EvKeys, KeyEscape, "KEY_ESC"},
+		{EvKeys, BtnLeft, "BTN_LEFT"},
+		{EvKeys, Btn0, "BTN_0"},
+		{EvKeys, BtnA, "BTN_SOUTH"},
+		{EvRelative, RelHWheel, "REL_HWHEEL"},
+		{EvAbsolute, AbsMTSlot, "ABS_MT_SLOT"},
+		{EvMisc, MiscScan, "MSC_SCAN"},
+		{EvSwitch, SwLid, "SW_LID"},
+		{EvLed, LedCapsLock, "LED_CAPSL"},
+		{EvForceFeedback, FFRumble, "FF_RUMBLE"},
+		{EvForceFeedbackStatus, FFStatusPlaying, "FF_STATUS_PLAYING"},
+		{EvKeys, 0x2ff, ""},
+		{EvPower, 0, ""},
+	}
+
+	for _, test := range tests {
+		if have := CodeName(test.Type, test.Code); have != test.Want {
+			t.Fatalf("Code %d/%d: want %q, have %q", test.Type, test.Code, test.Want, have)
+		}
+	}
+
+	if have := TypeName(EvKeys); have != "EV_KEY" {
+		t.Fatalf("Want EV_KEY, have %q", have)
+	}
+}
+
+func TestParseCode(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Type, Code int
+	}{
+		{"BTN_LEFT", EvKeys, BtnLeft},
+		{"KEY_A", EvKeys, KeyA},
+		{"BTN_A", EvKeys, BtnA},
+		{"BTN_MISC", EvKeys, BtnMisc},
+		{"ABS_MT_SLOT", EvAbsolute, AbsMTSlot},
+		{"FF_STATUS_STOPPED", EvForceFeedbackStatus, FFStatusStopped},
+		{"FF_GAIN", EvForceFeedback, FFGain},
+		{"SW_RADIO", EvSwitch, SwRadio},
+	}
+
+	for _, test := range tests {
+		evtype, code, err := ParseCode(test.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if evtype != test.Type || code != test.Code {
+			t.Fatalf("%s: want %d/%d, have %d/%d", test.Name, test.Type, test.Code, evtype, code)
+		}
+	}
+
+	for _, name := range []string{"", "KEY_", "KEY_NOPE", "EV_KEY", "KEY_MAX", "BTN_LEFTY"} {
+		if _, _, err := ParseCode(name); err == nil {
+			t.Fatalf("%q: want error", name)
+		}
+	}
+
+	// Every named code must survive a round trip.
+	for evtype, names := range codeNames {
+		for code, name := range names {
+			t2, c2, err := ParseCode(name)
+			if err != nil || t2 != evtype || c2 != code {
+				t.Fatalf("%s: want %d/%d, have %d/%d, %v", name, evtype, code, t2, c2, err)
+			}
+		}
+	}
+
+	if evtype, err := ParseType("EV_ABS"); err != nil || evtype != EvAbsolute {
+		t.Fatalf("Want EvAbsolute, have %d, %v", evtype, err)
+	}
+}
+
+func TestStringers(t *testing.T) {
+	e := Event{
+		Time:  syscall.NsecToTimeval(1700000000e9 + 123456e3),
+		Type:  EvKeys,
+		Code:  KeyA,
+		Value: 1,
+	}
+
+	if have, want := e.String(), "1700000000.123456 EV_KEY KEY_A 1"; have != want {
+		t.Fatalf("Want %q, have %q", want, have)
+	}
+
+	e = Event{Type: 0x1f, Code: 0x42}
+	if have, want := e.String(), "0.000000 0x1f 0x42 0"; have != want {
+		t.Fatalf("Want %q, have %q", want, have)
+	}
+
+	id := Id{BusType: BusUSB, Vendor: 0x046d, Product: 0xc52b, Version: 0x111}
+	if have, want := id.String(), "BUS_USB 046d:c52b v0111"; have != want {
+		t.Fatalf("Want %q, have %q", want, have)
+	}
+
+	bs := NewBitset(EvCount)
+	bs.Set(EvSync)
+	bs.Set(EvKeys)
+	bs.Set(EvLed)
+	if have, want := bs.String(), "{0, 1, 17}"; have != want {
+		t.Fatalf("Want %q, have %q", want, have)
+	}
+}
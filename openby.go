@@ -0,0 +1,160 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DevInputRoot is the directory holding the event nodes and
+// the by-id and by-path directories maintained by udev.
+// It can be pointed at a fixture tree for testing purposes.
+var DevInputRoot = "/dev/input"
+
+var (
+	// ErrNoMatch is returned when no device matches the given identity.
+	ErrNoMatch = errors.New("no matching device")
+
+	// ErrAmbiguous is returned when more than one device matches the given identity.
+	ErrAmbiguous = errors.New("ambiguous device match")
+)
+
+// Match describes the identity of a device.
+// Fields with a zero value are ignored when matching.
+type Match struct {
+	Vendor  uint16 // See Id.Vendor.
+	Product uint16 // See Id.Product.
+	Serial  string // See Device.Serial().
+	Name    string // See Device.Name().
+}
+
+// matches returns true if the given device qualifies.
+func (m *Match) matches(s *SysfsInput) bool {
+	return (m.Vendor == 0 || m.Vendor == s.Id.Vendor) &&
+		(m.Product == 0 || m.Product == s.Id.Product) &&
+		(m.Serial == "" || m.Serial == s.Uniq) &&
+		(m.Name == "" || m.Name == s.Name)
+}
+
+// String returns a description of the match, listing only the fields in use.
+func (m Match) String() string {
+	var list []string
+	if m.Vendor != 0 {
+		list = append(list, fmt.Sprintf("vendor=%04x", m.Vendor))
+	}
+	if m.Product != 0 {
+		list = append(list, fmt.Sprintf("product=%04x", m.Product))
+	}
+	if m.Serial != "" {
+		list = append(list, fmt.Sprintf("serial=%q", m.Serial))
+	}
+	if m.Name != "" {
+		list = append(list, fmt.Sprintf("name=%q", m.Name))
+	}
+	return strings.Join(list, " ")
+}
+
+// OpenByID opens the device with the given name in /dev/input/by-id.
+// E.g.: usb-Logitech_USB_Receiver-event-mouse.
+//
+// The name may contain glob patterns, as understood by filepath.Match.
+// E.g.: usb-Logitech_*-event-kbd. It is an error if the pattern
+// matches more than one device.
+func OpenByID(name string, opts ...Option) (*Device, error) {
+	node, err := ResolveLink("by-id", name)
+	if err != nil {
+		return nil, err
+	}
+	return Open(node, opts...)
+}
+
+// OpenByPath opens the device with the given name in /dev/input/by-path.
+// E.g.: pci-0000:00:14.0-usb-0:2:1.0-event-mouse.
+//
+// The name may contain glob patterns, as understood by filepath.Match.
+// It is an error if the pattern matches more than one device.
+func OpenByPath(name string, opts ...Option) (*Device, error) {
+	node, err := ResolveLink("by-path", name)
+	if err != nil {
+		return nil, err
+	}
+	return Open(node, opts...)
+}
+
+// OpenByMatch opens the single device matching the given identity.
+// See FindMatch for details.
+func OpenByMatch(m Match, opts ...Option) (*Device, error) {
+	node, err := FindMatch(m)
+	if err != nil {
+		return nil, err
+	}
+	return Open(node, opts...)
+}
+
+// ResolveLink resolves the given name in one of the symbolic link
+// directories in /dev/input, such as by-id and by-path, to an event node.
+// The name may contain glob patterns. Links to non-event nodes,
+// such as those for js* and mouse* nodes, are ignored.
+//
+// It returns an error wrapping ErrNoMatch or ErrAmbiguous
+// if the name does not resolve to exactly one event node.
+func ResolveLink(dir, name string) (string, error) {
+	links, err := filepath.Glob(filepath.Join(DevInputRoot, dir, name))
+	if err != nil {
+		return "", fmt.Errorf("%s/%s: %w", dir, name, err)
+	}
+
+	var nodes []string
+	for _, link := range links {
+		node, err := filepath.EvalSymlinks(link)
+		if err != nil || !strings.HasPrefix(filepath.Base(node), "event") {
+			continue
+		}
+
+		if !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return singleNode(fmt.Sprintf("%s/%s", dir, name), nodes)
+}
+
+// FindMatch returns the event node of the single device matching the
+// given identity. The devices are found through sysfs, so no device
+// needs to be opened to find a match. Devices which are unplugged or
+// cannot be read meanwhile are not considered.
+//
+// It returns an error wrapping ErrNoMatch or ErrAmbiguous
+// if the identity does not match exactly one device.
+func FindMatch(m Match) (string, error) {
+	list, err := SysfsInputs()
+	if err != nil {
+		return "", err
+	}
+
+	var nodes []string
+	for _, s := range list {
+		if node := s.Node(); node != "" && m.matches(s) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return singleNode(m.String(), nodes)
+}
+
+// singleNode returns the only entry in the given list of nodes
+// or an error describing why there is not exactly one.
+func singleNode(what string, nodes []string) (string, error) {
+	switch len(nodes) {
+	case 0:
+		return "", fmt.Errorf("%s: %w", what, ErrNoMatch)
+	case 1:
+		return nodes[0], nil
+	}
+	return "", fmt.Errorf("%s: %w: %s", what, ErrAmbiguous, strings.Join(nodes, ", "))
+}
@@ -0,0 +1,147 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// devInputFixture builds a /dev/input tree with by-id and by-path
+// links in a temporary directory and points DevInputRoot at it for
+// the duration of the test. The nodes are regular files.
+func devInputFixture(t *testing.T) string {
+	root := t.TempDir()
+
+	for _, dir := range []string{"by-id", "by-path"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, node := range []string{"event3", "event5", "event6", "mouse0"} {
+		if err := os.WriteFile(filepath.Join(root, node), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	links := map[string]string{
+		"by-id/usb-Logitech_USB_Receiver-event-mouse":        "event5",
+		"by-id/usb-Logitech_USB_Receiver-if01-event-kbd":     "event6",
+		"by-id/usb-Logitech_USB_Receiver-mouse":              "mouse0",
+		"by-path/platform-i8042-serio-0-event-kbd":           "event3",
+		"by-path/pci-0000:00:14.0-usb-0:2:1.0-event-mouse":   "event5",
+		"by-path/pci-0000:00:14.0-usb-0:2:1.1-event-kbd":     "event6",
+		"by-path/pci-0000:00:14.0-usb-0:2:1.0-mouse":         "mouse0",
+		"by-path/pci-0000:00:14.0-usbv2-0:2:1.0-event-mouse": "event5",
+	}
+
+	for link, node := range links {
+		if err := os.Symlink(filepath.Join("..", node), filepath.Join(root, link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := DevInputRoot
+	DevInputRoot = root
+	t.Cleanup(func() { DevInputRoot = old })
+
+	return root
+}
+
+func TestResolveLink(t *testing.T) {
+	root := devInputFixture(t)
+
+	want := []struct {
+		Dir  string
+		Name string
+		Node string
+		Err  error
+	}{
+		{"by-id", "usb-Logitech_USB_Receiver-event-mouse", "event5", nil},
+		{"by-id", "usb-Logitech_*-event-kbd", "event6", nil},
+		{"by-id", "usb-Logitech_*", "", ErrAmbiguous},
+		{"by-id", "usb-Logitech_USB_Receiver-mouse", "", ErrNoMatch},
+		{"by-id", "usb-Razer*", "", ErrNoMatch},
+		{"by-path", "platform-i8042-serio-0-event-kbd", "event3", nil},
+		{"by-path", "*-0:2:1.0-event-mouse", "event5", nil},
+		{"by-path", "pci-*-event-*", "", ErrAmbiguous},
+	}
+
+	for _, w := range want {
+		node, err := ResolveLink(w.Dir, w.Name)
+
+		if w.Err != nil {
+			if !errors.Is(err, w.Err) {
+				t.Fatalf("%s/%s: Want error %v, have %v", w.Dir, w.Name, w.Err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%s/%s: %v", w.Dir, w.Name, err)
+		}
+
+		if node != filepath.Join(root, w.Node) {
+			t.Fatalf("%s/%s: Want %s, have %s", w.Dir, w.Name, w.Node, node)
+		}
+	}
+}
+
+func TestFindMatch(t *testing.T) {
+	sysfsFixture(t)
+	root := devInputFixture(t)
+
+	want := []struct {
+		Match Match
+		Node  string
+		Err   error
+	}{
+		{Match{Vendor: 0x046d, Product: 0xc52b}, "event5", nil},
+		{Match{Name: "AT Translated Set 2 keyboard"}, "event3", nil},
+		{Match{Vendor: 0x0001, Name: "Logitech USB Receiver"}, "", ErrNoMatch},
+		{Match{Serial: "1234"}, "", ErrNoMatch},
+		{Match{}, "", ErrAmbiguous},
+	}
+
+	for _, w := range want {
+		node, err := FindMatch(w.Match)
+
+		if w.Err != nil {
+			if !errors.Is(err, w.Err) {
+				t.Fatalf("%v: Want error %v, have %v", w.Match, w.Err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%v: %v", w.Match, err)
+		}
+
+		if node != filepath.Join(root, w.Node) {
+			t.Fatalf("%v: Want %s, have %s", w.Match, w.Node, node)
+		}
+	}
+}
+
+func TestFindMatchUnreadable(t *testing.T) {
+	sys := sysfsFixture(t)
+	root := devInputFixture(t)
+
+	// The keyboard is unplugged while the devices are scanned.
+	if err := os.RemoveAll(filepath.Join(sys, fixtureKbd)); err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := FindMatch(Match{Vendor: 0x046d})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := filepath.Join(root, "event5"); node != want {
+		t.Fatalf("Want %s, have %s", want, node)
+	}
+}
@@ -0,0 +1,121 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+// Pipeline reads frames from a source, passes them through a filter
+// and writes the result to a sink. E.g.:
+//
+//	p, err := evdev.Intercept(dev, evdev.Remap(map[evdev.EventCode]evdev.EventCode{
+//		{evdev.EvKeys, evdev.KeyCapsLock}: {evdev.EvKeys, evdev.KeyEscape},
+//	}))
+//	if err != nil {
+//		return err
+//	}
+//	defer p.Close()
+//
+//	err = p.Run(ctx)
+//
+// Frames holding a SynDropped event are not passed on, since the
+// events around them are incomplete.
+type Pipeline struct {
+	src    EventSource
+	dst    EventSink
+	filter Filter
+	dev    *Device // Grabbed by Intercept.
+	out    *UInput // Created by Intercept.
+
+	// resync returns frames to write after a SynDropped frame,
+	// to catch up on the state lost with it.
+	resync func() [][]Event
+}
+
+// NewPipeline creates a pipeline from src to dst, through the given
+// filters in order.
+func NewPipeline(src EventSource, dst EventSink, filters ...Filter) *Pipeline {
+	return &Pipeline{src: src, dst: dst, filter: Chain(filters...)}
+}
+
+// Intercept grabs the device and creates a virtual copy of it, to which
+// the pipeline writes. The description of the copy is updated by the
+// filters implementing FilterDescriber, so it can emit remapped codes.
+// Close releases the device and destroys the copy.
+func Intercept(dev *Device, filters ...Filter) (*Pipeline, error) {
+	p := NewPipeline(dev, nil, filters...)
+
+	desc := dev.Describe()
+	describeFilter(p.filter, desc)
+
+	out, err := NewUInput(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dev.Grab() {
+		out.Close()
+		return nil, errors.New("intercept: failed to grab the device")
+	}
+
+	p.dst, p.dev, p.out = out, dev, out
+	return p, nil
+}
+
+// Run passes frames through the pipeline, until the source ends or ctx
+// is done. The end of the source is not reported as an error. If the
+// source is a Device, a pending read is interrupted once ctx is done.
+// Other sources are only checked for ctx between frames.
+func (p *Pipeline) Run(ctx context.Context) error {
+	var frames iter.Seq2[[]Event, error]
+	if dev, ok := p.src.(*Device); ok {
+		frames = dev.Frames(ctx)
+	} else {
+		frames = Frames(p.src)
+	}
+
+	for frame, err := range frames {
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var out [][]Event
+		if frame[0].Type != EvSync || frame[0].Code != SynDropped {
+			out = p.filter.Filter(frame)
+		} else if p.resync != nil {
+			out = p.resync()
+		}
+
+		for _, f := range out {
+			if err := p.dst.WriteEvents(f...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// Close releases the device grabbed by Intercept and destroys its
+// virtual copy. The device itself is left open.
+func (p *Pipeline) Close() error {
+	if p.dev != nil {
+		p.dev.Release()
+	}
+	if p.out != nil {
+		return p.out.Close()
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ProcDevicesPath is the location of the kernel's input device listing.
+const ProcDevicesPath = "/proc/bus/input/devices"
+
+// ProcDevice describes a single input device, as listed
+// in /proc/bus/input/devices. Each device is described by
+// a block of lines like this:
+//
+//	I: Bus=0011 Vendor=0001 Product=0001 Version=ab41
+//	N: Name="AT Translated Set 2 keyboard"
+//	P: Phys=isa0060/serio0/input0
+//	S: Sysfs=/devices/platform/i8042/serio0/input/input3
+//	U: Uniq=
+//	H: Handlers=sysrq kbd leds event3
+//	B: PROP=0
+//	B: EV=120013
+//	B: KEY=402000000 3803078f800d001 feffffdfffefffff fffffffffffffffe
+//	B: MSC=10
+//	B: LED=7
+//
+// Older kernels may omit some of the lines.
+type ProcDevice struct {
+	Id       Id       // Device identity. See Device.Id().
+	Name     string   // Name of the device. See Device.Name().
+	Phys     string   // Physical path of the device. See Device.Path().
+	Sysfs    string   // Sysfs path, relative to the sysfs root. E.g.: /devices/.../input/input3
+	Uniq     string   // Unique serial code of the device. See Device.Serial().
+	Handlers []string // Handler names. E.g.: sysrq, kbd, leds, event3
+	Capabilities
+}
+
+// Event returns the name of the event handler for this device.
+// E.g.: event3. This returns an empty string if the device has none.
+func (p *ProcDevice) Event() string {
+	for _, h := range p.Handlers {
+		if strings.HasPrefix(h, "event") {
+			return h
+		}
+	}
+	return ""
+}
+
+// Node returns the event node for this device. E.g.: /dev/input/event3.
+// This returns an empty string if the device has no event handler.
+func (p *ProcDevice) Node() string {
+	if h := p.Event(); h != "" {
+		return filepath.Join(DevInputRoot, h)
+	}
+	return ""
+}
+
+// ProcDevices reads and parses /proc/bus/input/devices.
+func ProcDevices() ([]*ProcDevice, error) {
+	fd, err := os.Open(ProcDevicesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+	return ParseProcDevices(fd)
+}
+
+// ParseProcDevices parses the contents of /proc/bus/input/devices.
+func ParseProcDevices(r io.Reader) ([]*ProcDevice, error) {
+	var list []*ProcDevice
+	var dev *ProcDevice
+	var line int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if len(text) == 0 {
+			dev = nil
+			continue
+		}
+
+		if len(text) < 2 || text[1] != ':' {
+			return nil, fmt.Errorf("proc devices: line %d: malformed line %q", line, text)
+		}
+
+		if dev == nil {
+			dev = newProcDevice()
+			list = append(list, dev)
+		}
+
+		var err error
+		value := strings.TrimSpace(text[2:])
+
+		switch text[0] {
+		case 'I':
+			err = dev.parseId(value)
+		case 'N':
+			dev.Name, err = procValue(value, "Name")
+			if name, ok := strings.CutPrefix(dev.Name, `"`); ok {
+				if name, ok = strings.CutSuffix(name, `"`); ok {
+					dev.Name = name
+				}
+			}
+		case 'P':
+			dev.Phys, err = procValue(value, "Phys")
+		case 'S':
+			dev.Sysfs, err = procValue(value, "Sysfs")
+		case 'U':
+			dev.Uniq, err = procValue(value, "Uniq")
+		case 'H':
+			var h string
+			h, err = procValue(value, "Handlers")
+			dev.Handlers = strings.Fields(h)
+		case 'B':
+			err = dev.parseBitmap(value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("proc devices: line %d: %v", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// newProcDevice creates a device with empty capability sets.
+func newProcDevice() *ProcDevice {
+	p := new(ProcDevice)
+	for _, b := range procBitmaps {
+		*b.Set(p) = NewBitset(b.Bits)
+	}
+	return p
+}
+
+// parseId parses the value of an I: line.
+//
+//	Bus=0011 Vendor=0001 Product=0001 Version=ab41
+func (p *ProcDevice) parseId(value string) error {
+	for _, field := range strings.Fields(value) {
+		key, v, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("malformed id field %q", field)
+		}
+
+		n, err := strconv.ParseUint(v, 16, 16)
+		if err != nil {
+			return fmt.Errorf("malformed id field %q", field)
+		}
+
+		switch key {
+		case "Bus":
+			p.Id.BusType = uint16(n)
+		case "Vendor":
+			p.Id.Vendor = uint16(n)
+		case "Product":
+			p.Id.Product = uint16(n)
+		case "Version":
+			p.Id.Version = uint16(n)
+		}
+	}
+	return nil
+}
+
+// procBitmaps maps the names used on B: lines to capability sets.
+var procBitmaps = []struct {
+	Name string
+	Bits int
+	Set  func(*ProcDevice) *Bitset
+}{
+	{"PROP", InputPropCount, func(p *ProcDevice) *Bitset { return &p.Properties }},
+	{"EV", EvCount, func(p *ProcDevice) *Bitset { return &p.Events }},
+	{"KEY", KeyCount, func(p *ProcDevice) *Bitset { return &p.Keys }},
+	{"REL", RelCount, func(p *ProcDevice) *Bitset { return &p.Relative }},
+	{"ABS", AbsCount, func(p *ProcDevice) *Bitset { return &p.Absolute }},
+	{"MSC", MiscCount, func(p *ProcDevice) *Bitset { return &p.Misc }},
+	{"SW", SwCount, func(p *ProcDevice) *Bitset { return &p.Switches }},
+	{"LED", LedCount, func(p *ProcDevice) *Bitset { return &p.LEDs }},
+	{"SND", SndCount, func(p *ProcDevice) *Bitset { return &p.Sounds }},
+	{"FF", FFCount, func(p *ProcDevice) *Bitset { return &p.ForceFeedback }},
+}
+
+// parseBitmap parses the value of a B: line.
+//
+//	KEY=402000000 3803078f800d001 feffffdfffefffff fffffffffffffffe
+//
+// Unknown bitmap names are ignored.
+func (p *ProcDevice) parseBitmap(value string) error {
+	key, v, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("malformed bitmap %q", value)
+	}
+
+	for _, b := range procBitmaps {
+		if b.Name == key {
+			if err := parseHexBitset(*b.Set(p), v); err != nil {
+				return fmt.Errorf("bitmap %s: %v", key, err)
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// procValue strips the given key from a Key=value pair.
+func procValue(value, key string) (string, error) {
+	v, ok := strings.CutPrefix(value, key+"=")
+	if !ok {
+		return "", fmt.Errorf("want %s=, have %q", key, value)
+	}
+	return v, nil
+}
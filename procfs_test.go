@@ -0,0 +1,140 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseProcDevices(t *testing.T) {
+	pinTextABI(t, "amd64")
+
+	type device struct {
+		Id       Id
+		Name     string
+		Sysfs    string
+		Event    string
+		Handlers int
+		Events   []int
+		Keys     []int
+	}
+
+	want := map[string][]device{
+		"linux-2.6.18.txt": {
+			{Id{BusI8042, 0x0001, 0x0001, 0xab41}, "AT Translated Set 2 keyboard", "/class/input/input0", "event0", 2,
+				[]int{EvSync, EvKeys, EvMisc, EvLed, EvRepeat}, []int{KeyEscape, KeyA, KeyF12}},
+			{Id{BusI8042, 0x0002, 0x0001, 0x0000}, "PS/2 Generic Mouse", "/class/input/input1", "event1", 2,
+				[]int{EvSync, EvKeys, EvRelative}, []int{BtnLeft, BtnRight, BtnMiddle}},
+		},
+		"linux-4.19.txt": {
+			{Id{BusHost, 0x0000, 0x0001, 0x0000}, "Power Button", "/devices/LNXSYSTM:00/LNXSYBUS:00/PNP0C0C:00/input/input0", "event0", 2,
+				[]int{EvSync, EvKeys}, []int{KeyPower}},
+			{Id{BusHost, 0x0000, 0x0005, 0x0000}, "Lid Switch", "/devices/LNXSYSTM:00/LNXSYBUS:00/PNP0C0D:00/input/input2", "event2", 1,
+				[]int{EvSync, EvSwitch}, nil},
+			{Id{BusI8042, 0x0002, 0x0007, 0x01b1}, "SynPS/2 Synaptics TouchPad", "/devices/platform/i8042/serio1/input/input5", "event5", 2,
+				[]int{EvSync, EvKeys, EvAbsolute}, []int{BtnLeft, BtnToolFinger, BtnTouch}},
+		},
+		"linux-6.6.txt": {
+			{Id{BusUSB, 0x046d, 0xc52b, 0x0111}, "Logitech USB Receiver", "/devices/pci0000:00/0000:00:14.0/usb1/1-2/1-2:1.0/0003:046D:C52B.0001/input/input5", "event5", 3,
+				[]int{EvSync, EvKeys, EvRelative, EvMisc}, []int{BtnLeft, BtnExtra}},
+			{Id{BusUSB, 0x045e, 0x028e, 0x0114}, "Microsoft X-Box 360 pad", "/devices/pci0000:00/0000:00:14.0/usb1/1-3/1-3:1.0/input/input7", "event7", 2,
+				[]int{EvSync, EvKeys, EvAbsolute, EvForceFeedback}, []int{BtnA, BtnB, BtnMode, BtnThumbR}},
+		},
+	}
+
+	files, err := filepath.Glob("testdata/proc-bus-input-devices/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != len(want) {
+		t.Fatalf("Want %d samples, have %d", len(want), len(files))
+	}
+
+	for _, file := range files {
+		fd, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		list, err := ParseProcDevices(fd)
+		fd.Close()
+
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		wl := want[filepath.Base(file)]
+		if len(list) != len(wl) {
+			t.Fatalf("%s: Want %d devices, have %d", file, len(wl), len(list))
+		}
+
+		for i, w := range wl {
+			have := list[i]
+
+			if have.Id != w.Id || have.Name != w.Name || have.Sysfs != w.Sysfs {
+				t.Fatalf("%s: device %d: Want %+v, have %+v", file, i, w, have)
+			}
+
+			if have.Event() != w.Event || len(have.Handlers) != w.Handlers {
+				t.Fatalf("%s: device %d: Want handler %s of %d, have %v", file, i, w.Event, w.Handlers, have.Handlers)
+			}
+
+			if have.Node() != "/dev/input/"+w.Event {
+				t.Fatalf("%s: device %d: Want node for %s, have %q", file, i, w.Event, have.Node())
+			}
+
+			for n := 0; n < have.Events.Len(); n++ {
+				if have.Events.Test(n) != contains(w.Events, n) {
+					t.Fatalf("%s: device %d: event type 0x%02x: Want %v", file, i, n, !have.Events.Test(n))
+				}
+			}
+
+			for _, k := range w.Keys {
+				if !have.Keys.Test(k) {
+					t.Fatalf("%s: device %d: Want key 0x%02x", file, i, k)
+				}
+			}
+		}
+	}
+}
+
+func TestParseProcDevicesQuotedName(t *testing.T) {
+	// Only the quotes added by the kernel are stripped.
+	list, err := ParseProcDevices(strings.NewReader("I: Bus=0003 Vendor=046d Product=c52b Version=0111\nN: Name=\"\"Quoted\" Pad\"\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `"Quoted" Pad"`; len(list) != 1 || list[0].Name != want {
+		t.Fatalf("Want name %q, have %+v", want, list)
+	}
+}
+
+func TestParseProcDevicesMalformed(t *testing.T) {
+	samples := []string{
+		"I: Bus=0003 Vendor=046d Product=c52b Version=0111\nB EV=17\n",
+		"I: Bus=0003 Vendor=046d Product=zzzz Version=0111\n",
+		"I: Bus=0003 Vendor=046d Product=c52b Version=0111\nN: \"No key\"\n",
+		"I: Bus=0003 Vendor=046d Product=c52b Version=0111\nB: EV=xyz\n",
+	}
+
+	for _, s := range samples {
+		if _, err := ParseProcDevices(strings.NewReader(s)); err == nil {
+			t.Fatalf("Want error for %q", s)
+		}
+	}
+}
+
+func contains(list []int, v int) bool {
+	for _, n := range list {
+		if n == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,338 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// KeyAction is what a key is remapped to. E.g.:
+//
+//	evdev.KeyAction{Keys: []int{evdev.KeyEscape}}                  // Another key.
+//	evdev.KeyAction{Keys: []int{evdev.KeyLeftCtrl, evdev.KeyC}}    // A combination.
+//	evdev.KeyAction{Keys: []int{evdev.KeyH, evdev.KeyI}, Sequence: true}
+//	evdev.KeyAction{Keys: []int{evdev.BtnLeft}}                    // A mouse button.
+//
+// An action without keys disables the key.
+type KeyAction struct {
+	// Keys or buttons emitted instead of the key. They are pressed in
+	// order while the key is held, and released in reverse order.
+	Keys []int
+
+	// Sequence taps the keys one after another when the key is
+	// pressed, instead of holding them. Keys held for another key
+	// are left out, so the tap does not release them.
+	Sequence bool
+}
+
+// KeyRemap is a filter replacing keys according to a table of actions.
+// Keys missing from the table are passed on unchanged.
+//
+// KeyRemap keeps track of the keys it pressed for each held key, so a
+// release always undoes its press, even if the table has changed in
+// between. Keys pressed for more than one held key are only released
+// with the last of them.
+type KeyRemap struct {
+	mu    sync.Mutex
+	table map[int]KeyAction
+	held  map[int][]int // Keys pressed for each held key.
+	count map[int]int   // Number of held keys each key is pressed for.
+}
+
+// NewKeyRemap creates a filter remapping keys according to table.
+func NewKeyRemap(table map[int]KeyAction) *KeyRemap {
+	return &KeyRemap{
+		table: table,
+		held:  make(map[int][]int),
+		count: make(map[int]int),
+	}
+}
+
+// SetTable replaces the table of actions. Keys held at that time are
+// released as they were pressed. It is safe to call SetTable while the
+// filter is in use.
+func (r *KeyRemap) SetTable(table map[int]KeyAction) {
+	r.mu.Lock()
+	r.table = table
+	r.mu.Unlock()
+}
+
+// Filter remaps the key events of the frame. Sequences are emitted as
+// frames of their own, so each tap is seen by applications.
+func (r *KeyRemap) Filter(frame []Event) [][]Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var frames [][]Event
+	var cur []Event
+	syn := frame[len(frame)-1]
+
+	for _, e := range frame[:len(frame)-1] {
+		if e.Type != EvKeys {
+			cur = append(cur, e)
+			continue
+		}
+
+		code := int(e.Code)
+
+		switch e.Value {
+		case 1:
+			if _, ok := r.held[code]; ok {
+				continue
+			}
+
+			action, ok := r.table[code]
+			if !ok {
+				action = KeyAction{Keys: []int{code}}
+			}
+
+			if action.Sequence {
+				if len(cur) > 0 {
+					frames = append(frames, append(cur, syn))
+					cur = nil
+				}
+				for _, k := range action.Keys {
+					if r.count[k] > 0 {
+						continue
+					}
+					frames = append(frames,
+						[]Event{keyEvent(e, k, 1), syn},
+						[]Event{keyEvent(e, k, 0), syn})
+				}
+				continue
+			}
+
+			r.held[code] = action.Keys
+			for _, k := range action.Keys {
+				r.count[k]++
+				if r.count[k] == 1 {
+					cur = append(cur, keyEvent(e, k, 1))
+				}
+			}
+
+		case 0:
+			keys, ok := r.held[code]
+			if !ok {
+				continue // Pressed before the filter, or a sequence.
+			}
+			delete(r.held, code)
+			cur = r.release(cur, e, keys)
+
+		default:
+			if keys := r.held[code]; len(keys) > 0 {
+				cur = append(cur, keyEvent(e, keys[len(keys)-1], e.Value))
+			}
+		}
+	}
+
+	if len(cur) > 0 {
+		frames = append(frames, append(cur, syn))
+	}
+	return frames
+}
+
+// release appends the release events for keys to events, in reverse
+// order, skipping keys still pressed for other held keys.
+func (r *KeyRemap) release(events []Event, e Event, keys []int) []Event {
+	for _, k := range slices.Backward(keys) {
+		r.count[k]--
+		if r.count[k] == 0 {
+			delete(r.count, k)
+			events = append(events, keyEvent(e, k, 0))
+		}
+	}
+	return events
+}
+
+// ReleaseAll returns a frame releasing every key the filter holds
+// pressed, and forgets about them. It returns nil if no key is held.
+// E.g.: to release the keys of a virtual device before destroying it.
+func (r *KeyRemap) ReleaseAll() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []Event
+	for code, keys := range r.held {
+		events = r.release(events, Event{}, keys)
+		delete(r.held, code)
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	return append(events, Event{Type: EvSync, Code: SynReport})
+}
+
+// Resync returns a frame releasing the keys held for keys which are
+// no longer down, according to the given key state. E.g.: after a
+// SynDropped event, which may have swallowed their release. It returns
+// nil if no key needs to be released.
+func (r *KeyRemap) Resync(down Bitset) []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []Event
+	for code, keys := range r.held {
+		if !down.Test(code) {
+			events = r.release(events, Event{}, keys)
+			delete(r.held, code)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	return append(events, Event{Type: EvSync, Code: SynReport})
+}
+
+// FilterDescription adds the keys and buttons of the table. A device
+// emitting mouse buttons also gets relative X and Y axes, since most
+// applications only treat a device with those as a mouse.
+func (r *KeyRemap) FilterDescription(desc *Description) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, action := range r.table {
+		for _, k := range action.Keys {
+			desc.Events.Set(EvKeys)
+			desc.Bits(EvKeys).Set(k)
+
+			if k >= BtnMouse && k <= BtnTask {
+				desc.Events.Set(EvRelative)
+				desc.Bits(EvRelative).Set(RelX)
+				desc.Bits(EvRelative).Set(RelY)
+			}
+		}
+	}
+}
+
+// keyEvent returns a key event with the given code and value, at the
+// time of e.
+func keyEvent(e Event, code int, value int32) Event {
+	return Event{Time: e.Time, Type: EvKeys, Code: uint16(code), Value: value}
+}
+
+// Remapper grabs a keyboard and re-emits its keys from a virtual copy,
+// remapped according to a table of actions. The LEDs the system sets on
+// the copy, such as caps lock, are mirrored on the keyboard. E.g.:
+//
+//	r, err := evdev.NewRemapper(dev, map[int]evdev.KeyAction{
+//		evdev.KeyCapsLock: {Keys: []int{evdev.KeyEscape}},
+//		evdev.KeyRightAlt: {Keys: []int{evdev.BtnLeft}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer r.Close()
+//
+//	err = r.Run(ctx)
+//
+// The copy declares every keyboard key and mouse button, along with
+// relative X and Y axes, so SetTable may map keys to any of them.
+// The device must be open for writing to mirror the LEDs.
+type Remapper struct {
+	*KeyRemap
+
+	p  *Pipeline
+	wg sync.WaitGroup
+}
+
+// NewRemapper grabs the device and creates its remapped copy.
+// Further filters, if any, are applied after remapping.
+func NewRemapper(dev *Device, table map[int]KeyAction, filters ...Filter) (*Remapper, error) {
+	r := &Remapper{KeyRemap: NewKeyRemap(table)}
+
+	p, err := Intercept(dev, append([]Filter{r.KeyRemap, allKeys{}}, filters...)...)
+	if err != nil {
+		return nil, err
+	}
+	r.p = p
+
+	// Releases lost to a SynDropped are caught up on from the key state.
+	after := Chain(filters...)
+	p.resync = func() [][]Event {
+		if frame := r.Resync(dev.KeyState()); frame != nil {
+			return after.Filter(frame)
+		}
+		return nil
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		mirrorLEDs(dev, p.out)
+	}()
+
+	return r, nil
+}
+
+// allKeys passes frames on unchanged. It declares every keyboard key
+// and mouse button, since the virtual device cannot be changed later.
+type allKeys struct{}
+
+func (allKeys) Filter(frame []Event) [][]Event {
+	return [][]Event{frame}
+}
+
+func (allKeys) FilterDescription(desc *Description) {
+	desc.Events.Set(EvKeys)
+	for _, r := range [][2]int{{KeyEscape, BtnMisc}, {BtnMouse, BtnTask + 1}, {KeyOk, BtnTriggerHappy}} {
+		for k := r[0]; k < r[1]; k++ {
+			desc.Bits(EvKeys).Set(k)
+		}
+	}
+
+	desc.Events.Set(EvRelative)
+	desc.Bits(EvRelative).Set(RelX)
+	desc.Bits(EvRelative).Set(RelY)
+}
+
+// mirrorLEDs writes the LED events sent to src to dst, until src is
+// closed. Failed writes are ignored, so the keyboard keeps working.
+func mirrorLEDs(dst EventSink, src EventSource) {
+	buf := make([]Event, eventBufferSize)
+
+	for {
+		n, err := src.ReadEvents(buf)
+
+		var leds []Event
+		for _, e := range buf[:n] {
+			if e.Type == EvLed {
+				leds = append(leds, e)
+			}
+		}
+		if len(leds) > 0 {
+			dst.WriteEvents(append(leds, Event{Type: EvSync, Code: SynReport})...)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Run remaps keys until the device ends or ctx is done.
+// The end of the device is not reported as an error.
+func (r *Remapper) Run(ctx context.Context) error {
+	err := r.p.Run(ctx)
+	if errors.Is(err, ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// Close releases the keys held on the copy, releases the device and
+// destroys the copy. The device itself is left open.
+func (r *Remapper) Close() error {
+	if frame := r.ReleaseAll(); frame != nil {
+		r.p.out.WriteEvents(frame...)
+	}
+
+	err := r.p.Close()
+	r.wg.Wait()
+	return err
+}
@@ -0,0 +1,270 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// key returns a key event with the given code and value.
+func key(code int, value int32) Event {
+	return Event{Type: EvKeys, Code: uint16(code), Value: value}
+}
+
+func TestKeyRemap(t *testing.T) {
+	table := map[int]KeyAction{
+		KeyCapsLock: {Keys: []int{KeyEscape}},
+		KeyF1:       {Keys: []int{KeyLeftCtrl, KeyC}},
+		KeyF2:       {Keys: []int{KeyH, KeyI}, Sequence: true},
+		KeyF3:       {Keys: []int{KeyA, KeyB}, Sequence: true},
+		KeyF4:       {Keys: []int{KeyA}},
+		KeyRightAlt: {Keys: []int{BtnLeft}},
+		KeyInsert:   {},
+	}
+
+	tests := []struct {
+		Name string
+		In   [][]Event
+		Want [][]Event
+	}{
+		{
+			"key",
+			[][]Event{frame(key(KeyCapsLock, 1)), frame(key(KeyCapsLock, 2)), frame(key(KeyCapsLock, 0))},
+			[][]Event{frame(key(KeyEscape, 1)), frame(key(KeyEscape, 2)), frame(key(KeyEscape, 0))},
+		},
+		{
+			"unmapped",
+			[][]Event{frame(Event{Type: EvMisc, Code: MiscScan, Value: 0x1e}, key(KeyA, 1)), frame(key(KeyA, 0))},
+			[][]Event{frame(Event{Type: EvMisc, Code: MiscScan, Value: 0x1e}, key(KeyA, 1)), frame(key(KeyA, 0))},
+		},
+		{
+			"combination",
+			[][]Event{frame(key(KeyF1, 1)), frame(key(KeyF1, 0))},
+			[][]Event{frame(key(KeyLeftCtrl, 1), key(KeyC, 1)), frame(key(KeyC, 0), key(KeyLeftCtrl, 0))},
+		},
+		{
+			"sequence",
+			[][]Event{frame(key(KeyA, 1), key(KeyF2, 1)), frame(key(KeyF2, 2)), frame(key(KeyF2, 0), key(KeyA, 0))},
+			[][]Event{frame(key(KeyA, 1)), frame(key(KeyH, 1)), frame(key(KeyH, 0)), frame(key(KeyI, 1)), frame(key(KeyI, 0)), frame(key(KeyA, 0))},
+		},
+		{
+			"sequence with held key",
+			[][]Event{frame(key(KeyF4, 1)), frame(key(KeyF3, 1)), frame(key(KeyF3, 0)), frame(key(KeyF4, 0))},
+			[][]Event{frame(key(KeyA, 1)), frame(key(KeyB, 1)), frame(key(KeyB, 0)), frame(key(KeyA, 0))},
+		},
+		{
+			"button",
+			[][]Event{frame(key(KeyRightAlt, 1)), frame(key(KeyRightAlt, 0))},
+			[][]Event{frame(key(BtnLeft, 1)), frame(key(BtnLeft, 0))},
+		},
+		{
+			"disabled",
+			[][]Event{frame(key(KeyInsert, 1)), frame(key(KeyInsert, 0))},
+			nil,
+		},
+		{
+			"shared",
+			[][]Event{frame(key(KeyF1, 1)), frame(key(KeyLeftCtrl, 1)), frame(key(KeyF1, 0)), frame(key(KeyLeftCtrl, 0))},
+			[][]Event{frame(key(KeyLeftCtrl, 1), key(KeyC, 1)), frame(key(KeyC, 0)), frame(key(KeyLeftCtrl, 0))},
+		},
+		{
+			"released before",
+			[][]Event{frame(key(KeyB, 0))},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r := NewKeyRemap(table)
+
+			var have [][]Event
+			for _, f := range tt.In {
+				have = append(have, r.Filter(f)...)
+			}
+
+			if !reflect.DeepEqual(have, tt.Want) {
+				t.Fatalf("Want %v, have %v", tt.Want, have)
+			}
+
+			if frame := r.ReleaseAll(); frame != nil {
+				t.Fatalf("Want no held keys, have %v", frame)
+			}
+		})
+	}
+}
+
+func TestKeyRemapSetTable(t *testing.T) {
+	r := NewKeyRemap(map[int]KeyAction{KeyCapsLock: {Keys: []int{KeyEscape}}})
+
+	have := r.Filter(frame(key(KeyCapsLock, 1), key(KeyA, 1)))
+	r.SetTable(map[int]KeyAction{KeyCapsLock: {Keys: []int{KeyLeftCtrl}}, KeyA: {Keys: []int{KeyB}}})
+	have = append(have, r.Filter(frame(key(KeyCapsLock, 0), key(KeyA, 0)))...)
+
+	// The keys are released as they were pressed.
+	want := [][]Event{frame(key(KeyEscape, 1), key(KeyA, 1)), frame(key(KeyEscape, 0), key(KeyA, 0))}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("Want %v, have %v", want, have)
+	}
+
+	r.Filter(frame(key(KeyCapsLock, 1)))
+	if want, have := frame(key(KeyLeftCtrl, 0)), r.ReleaseAll(); !reflect.DeepEqual(have, want) {
+		t.Fatalf("Want %v, have %v", want, have)
+	}
+}
+
+func TestKeyRemapResync(t *testing.T) {
+	r := NewKeyRemap(map[int]KeyAction{KeyF1: {Keys: []int{KeyLeftCtrl, KeyC}}})
+	r.Filter(frame(key(KeyF1, 1), key(KeyLeftCtrl, 1)))
+
+	// The release of F1 was lost, while the left control key is still down.
+	down := NewBitset(KeyCount)
+	down.Set(KeyLeftCtrl)
+
+	if want, have := frame(key(KeyC, 0)), r.Resync(down); !reflect.DeepEqual(have, want) {
+		t.Fatalf("Want %v, have %v", want, have)
+	}
+
+	if have := r.Resync(down); have != nil {
+		t.Fatalf("Want nothing left to release, have %v", have)
+	}
+
+	if want, have := frame(key(KeyLeftCtrl, 0)), r.ReleaseAll(); !reflect.DeepEqual(have, want) {
+		t.Fatalf("Want %v, have %v", want, have)
+	}
+}
+
+func TestPipelineResync(t *testing.T) {
+	r := NewKeyRemap(map[int]KeyAction{KeyCapsLock: {Keys: []int{KeyEscape}}})
+
+	var buf bytes.Buffer
+	NewEncoder(&buf).Encode(
+		key(KeyCapsLock, 1), Event{Type: EvSync, Code: SynReport},
+		Event{Type: EvSync, Code: SynDropped},
+		key(KeyCapsLock, 0), Event{Type: EvSync, Code: SynReport},
+	)
+
+	var rec frameRecorder
+	p := NewPipeline(NewDecoder(&buf), &rec, r)
+	p.resync = func() [][]Event {
+		return [][]Event{r.Resync(NewBitset(KeyCount))}
+	}
+
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The release lost with the SynDropped frame is caught up on.
+	want := [][]Event{frame(key(KeyEscape, 1)), frame(key(KeyEscape, 0))}
+	if !reflect.DeepEqual(rec.frames, want) {
+		t.Fatalf("Want %v, have %v", want, rec.frames)
+	}
+}
+
+func TestKeyRemapDescription(t *testing.T) {
+	desc := newEmptyDescription()
+	desc.Events.Set(EvKeys)
+	desc.Keys.Set(KeyCapsLock)
+
+	describeFilter(NewKeyRemap(map[int]KeyAction{
+		KeyCapsLock: {Keys: []int{KeyEscape}},
+		KeyF2:       {Keys: []int{KeyH, KeyI}, Sequence: true},
+	}), desc)
+
+	for _, k := range []int{KeyCapsLock, KeyEscape, KeyH, KeyI} {
+		if !desc.Keys.Test(k) {
+			t.Fatalf("Want %s, have %s", CodeName(EvKeys, k), desc.Keys)
+		}
+	}
+	if desc.Events.Test(EvRelative) {
+		t.Fatalf("Want no relative axes for keys, have %s", desc.Events)
+	}
+
+	describeFilter(NewKeyRemap(map[int]KeyAction{KeyRightAlt: {Keys: []int{BtnLeft}}}), desc)
+
+	if !desc.Keys.Test(BtnLeft) || !desc.Events.Test(EvRelative) || !desc.Relative.Test(RelX) || !desc.Relative.Test(RelY) {
+		t.Fatalf("Want BtnLeft with relative axes, have %s and %s", desc.Events, desc.Relative)
+	}
+}
+
+func TestRemapperSetTable(t *testing.T) {
+	kbd, dev := uinputDevice(t, testKeyboard(), NoReader())
+
+	r, err := NewRemapper(dev, map[int]KeyAction{KeyA: {Keys: []int{KeyB}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	out := openNode(t, r.p.out, NoReader())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Run(ctx)
+
+	// Neither the keyboard nor the first table declares KeyF13 or BtnLeft.
+	r.SetTable(map[int]KeyAction{KeyA: {Keys: []int{KeyF13}}, KeyB: {Keys: []int{BtnLeft}}})
+
+	kbd.WriteEvents(frame(key(KeyA, 1), key(KeyB, 1))...)
+	kbd.WriteEvents(frame(key(KeyA, 0), key(KeyB, 0))...)
+
+	var have []Event
+	for e, err := range out.Events(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Type == EvKeys {
+			have = append(have, Event{Type: e.Type, Code: e.Code, Value: e.Value})
+		}
+		if len(have) == 4 {
+			break
+		}
+	}
+
+	want := []Event{key(KeyF13, 1), key(BtnLeft, 1), key(KeyF13, 0), key(BtnLeft, 0)}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("Want %v, have %v", want, have)
+	}
+}
+
+func TestAllKeysDescription(t *testing.T) {
+	desc := newEmptyDescription()
+	describeFilter(allKeys{}, desc)
+
+	for _, k := range []int{KeyEscape, KeyF13, KeyMicMute, BtnLeft, BtnTask, KeyOk} {
+		if !desc.Keys.Test(k) {
+			t.Fatalf("Want %s, have %s", CodeName(EvKeys, k), desc.Keys)
+		}
+	}
+
+	for _, k := range []int{BtnTrigger, BtnTouch, BtnTriggerHappy} {
+		if desc.Keys.Test(k) {
+			t.Fatalf("Want no %s", CodeName(EvKeys, k))
+		}
+	}
+
+	if !desc.Relative.Test(RelX) || !desc.Relative.Test(RelY) {
+		t.Fatalf("Want relative axes, have %s", desc.Relative)
+	}
+}
+
+func TestMirrorLEDs(t *testing.T) {
+	var buf bytes.Buffer
+	NewEncoder(&buf).Encode(
+		Event{Type: EvLed, Code: LedCapsLock, Value: 1},
+		Event{Type: EvForceFeedback, Code: 0, Value: 1},
+		Event{Type: EvLed, Code: LedNumLock, Value: 0},
+	)
+
+	var rec frameRecorder
+	mirrorLEDs(&rec, NewDecoder(&buf))
+
+	want := [][]Event{frame(Event{Type: EvLed, Code: LedCapsLock, Value: 1}, Event{Type: EvLed, Code: LedNumLock, Value: 0})}
+	if !reflect.DeepEqual(rec.frames, want) {
+		t.Fatalf("Want %v, have %v", want, rec.frames)
+	}
+}
@@ -5,14 +5,6 @@ package evdev
 
 import "unsafe"
 
-// Repeat events are used for specifying autorepeating events.
-const (
-	RepDelay  = 0x00
-	RepPeriod = 0x01
-	RepMax    = 0x01
-	RepCount  = RepMax + 1
-)
-
 // RepeatState returns the current, global repeat state.
 // This applies only to devices which have the EvRepeat capability defined.
 // This can be determined through `Device.EventTypes()`.
@@ -23,7 +15,7 @@ const (
 // This is only applicable to devices with EvRepeat event support.
 func (d *Device) RepeatState() (uint, uint) {
 	var rep [2]int32
-	ioctl(d.fd.Fd(), _EVIOCGREP, unsafe.Pointer(&rep[0]))
+	d.ioctl(_EVIOCGREP, unsafe.Pointer(&rep[0]))
 	return uint(rep[0]), uint(rep[1])
 }
 
@@ -47,5 +39,5 @@ func (d *Device) SetRepeatState(initial, subsequent uint) bool {
 	var rep [2]int32
 	rep[0] = int32(initial)
 	rep[1] = int32(subsequent)
-	return ioctl(d.fd.Fd(), _EVIOCSREP, unsafe.Pointer(&rep[0])) == nil
+	return d.ioctl(_EVIOCSREP, unsafe.Pointer(&rep[0])) == nil
 }
@@ -0,0 +1,272 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"syscall"
+	"time"
+)
+
+// ReplayFrame is a frame of events of one device in a replayed session.
+type ReplayFrame struct {
+	Device int           // Index in Session.Devices.
+	At     time.Duration // Offset from the start of the session.
+	Events []Event       // The events, up to and including a SynReport.
+}
+
+// Jitter describes how late frames were written, compared
+// to the time they were due according to the recording.
+type Jitter struct {
+	Frames int           // Number of frames written by Replayer.Run.
+	Mean   time.Duration // Average delay.
+	Max    time.Duration // Largest delay.
+}
+
+// ReplayOption configures a Replayer.
+type ReplayOption func(*Replayer)
+
+// ReplaySpeed plays the session at the given factor of its original
+// speed. E.g.: 2 plays twice as fast, 0.5 at half speed.
+// Factors of zero or less are ignored.
+func ReplaySpeed(factor float64) ReplayOption {
+	return func(r *Replayer) {
+		if factor > 0 {
+			r.speed = factor
+		}
+	}
+}
+
+// ReplayLoop starts the session over once its last frame was written.
+// Replayer.Run then only returns once ctx is done or a write fails.
+func ReplayLoop() ReplayOption {
+	return func(r *Replayer) {
+		r.loop = true
+	}
+}
+
+// Replayer writes the frames of a recorded session to a set of devices,
+// at the offsets at which they were recorded. E.g.:
+//
+//	rp, err := evdev.NewReplayer(session, evdev.ReplaySpeed(2))
+//	if err != nil {
+//		return err
+//	}
+//	defer rp.Close()
+//
+//	err = rp.Run(ctx)
+//
+// Frames can also be written one at a time with Replayer.Step. A Replayer
+// is not safe for concurrent use.
+type Replayer struct {
+	frames []ReplayFrame
+	devs   []EventSink
+	owned  []*UInput // Devices created by NewReplayer.
+	pos    int       // Index of the next frame.
+	speed  float64
+	loop   bool
+	jitter Jitter
+	total  time.Duration // Sum of the delays, for Jitter.Mean.
+}
+
+// NewReplayer creates a virtual uinput device for every device in the
+// session, based on its description. Close destroys them again.
+//
+// Programs watching for new devices, such as a desktop session, may
+// need a moment to open them. Events written before that are lost to them.
+func NewReplayer(s *Session, opts ...ReplayOption) (*Replayer, error) {
+	var owned []*UInput
+
+	for _, rec := range s.Devices {
+		u, err := NewUInput(rec.Description)
+		if err != nil {
+			for _, u := range owned {
+				u.Close()
+			}
+			return nil, err
+		}
+		owned = append(owned, u)
+	}
+
+	devs := make([]EventSink, len(owned))
+	for i, u := range owned {
+		devs[i] = u
+	}
+
+	r := NewReplayerTo(s, devs, opts...)
+	r.owned = owned
+	return r, nil
+}
+
+// NewReplayerTo creates a replayer writing the events of the n-th device
+// in the session to the n-th given device. Devices missing from the
+// list are skipped. Unlike NewReplayer, no devices are created.
+func NewReplayerTo(s *Session, devs []EventSink, opts ...ReplayOption) *Replayer {
+	r := &Replayer{
+		frames: replayFrames(s),
+		devs:   devs,
+		speed:  1,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// replayFrames splits the events of each device into frames and
+// orders these by their offsets. A trailing incomplete frame is kept.
+func replayFrames(s *Session) []ReplayFrame {
+	start := s.start()
+
+	var frames []ReplayFrame
+	for i, rec := range s.Devices {
+		var events []Event
+
+		for n, e := range rec.Events {
+			events = append(events, e)
+
+			if (e.Type == EvSync && e.Code == SynReport) || n == len(rec.Events)-1 {
+				at := time.Duration(syscall.TimevalToNsec(events[0].Time)) - start
+				frames = append(frames, ReplayFrame{Device: i, At: at, Events: events})
+				events = nil
+			}
+		}
+	}
+
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].At < frames[j].At
+	})
+	return frames
+}
+
+// Frames returns the frames of the session, in the order they are played.
+func (r *Replayer) Frames() []ReplayFrame {
+	return r.frames
+}
+
+// Duration returns the offset of the last frame.
+func (r *Replayer) Duration() time.Duration {
+	if len(r.frames) == 0 {
+		return 0
+	}
+	return r.frames[len(r.frames)-1].At
+}
+
+// Position returns the offset of the next frame to be written.
+// This equals Duration once all frames have been written.
+func (r *Replayer) Position() time.Duration {
+	if r.pos >= len(r.frames) {
+		return r.Duration()
+	}
+	return r.frames[r.pos].At
+}
+
+// Seek moves to the first frame at or after the given offset.
+// The frames skipped are not written, so the devices do not learn
+// about state changes, such as keys held down, made in them.
+func (r *Replayer) Seek(offset time.Duration) {
+	r.pos = sort.Search(len(r.frames), func(i int) bool {
+		return r.frames[i].At >= offset
+	})
+}
+
+// Step writes the next frame at once, regardless of its offset,
+// and returns it. This returns io.EOF after the last frame, unless
+// the replayer loops.
+func (r *Replayer) Step() (ReplayFrame, error) {
+	if r.pos >= len(r.frames) {
+		if !r.loop || len(r.frames) == 0 {
+			return ReplayFrame{}, io.EOF
+		}
+		r.pos = 0
+	}
+
+	f := r.frames[r.pos]
+	r.pos++
+
+	if f.Device < len(r.devs) && r.devs[f.Device] != nil {
+		if err := r.devs[f.Device].WriteEvents(f.Events...); err != nil {
+			return f, err
+		}
+	}
+
+	return f, nil
+}
+
+// Run writes the frames from the current position onwards, each at its
+// offset relative to the first one, scaled by the speed factor. It
+// returns nil after the last frame, or ctx.Err() if ctx is done first.
+func (r *Replayer) Run(ctx context.Context) error {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	base := time.Now()
+	origin := r.Position()
+
+	for {
+		if r.pos >= len(r.frames) {
+			if !r.loop || len(r.frames) == 0 {
+				return nil
+			}
+
+			// Start over right away.
+			r.pos = 0
+			base, origin = time.Now(), 0
+		}
+
+		due := base.Add(time.Duration(float64(r.frames[r.pos].At-origin) / r.speed))
+
+		if wait := time.Until(due); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, err := r.Step(); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		r.record(time.Since(due))
+	}
+}
+
+// record adds the delay of a frame to the jitter statistics.
+func (r *Replayer) record(late time.Duration) {
+	if late < 0 {
+		late = 0
+	}
+
+	r.jitter.Frames++
+	r.total += late
+	r.jitter.Mean = r.total / time.Duration(r.jitter.Frames)
+	if late > r.jitter.Max {
+		r.jitter.Max = late
+	}
+}
+
+// Jitter returns the timing statistics of the frames written by Run.
+func (r *Replayer) Jitter() Jitter {
+	return r.jitter
+}
+
+// Close destroys the devices created by NewReplayer.
+func (r *Replayer) Close() error {
+	var first error
+	for _, u := range r.owned {
+		if err := u.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,108 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestReplayerFrames(t *testing.T) {
+	rp := NewReplayerTo(readSession(t), nil)
+
+	want := []struct {
+		Device int
+		At     time.Duration
+	}{
+		{0, 0},
+		{0, 12211 * time.Microsecond},
+		{1, 600 * time.Millisecond},
+		{1, 700 * time.Millisecond},
+		{0, 1500 * time.Millisecond},
+	}
+
+	frames := rp.Frames()
+	if len(frames) != len(want) {
+		t.Fatalf("Want %d frames, have %d", len(want), len(frames))
+	}
+
+	for i, w := range want {
+		if frames[i].Device != w.Device || frames[i].At != w.At {
+			t.Fatalf("Frame %d: want device %d at %v, have %d at %v", i, w.Device, w.At, frames[i].Device, frames[i].At)
+		}
+	}
+
+	if rp.Duration() != 1500*time.Millisecond {
+		t.Fatalf("Want duration 1.5s, have %v", rp.Duration())
+	}
+}
+
+func TestReplayerStep(t *testing.T) {
+	var pad, kbd frameRecorder
+	rp := NewReplayerTo(readSession(t), []EventSink{&pad, &kbd})
+
+	rp.Seek(650 * time.Millisecond)
+	if rp.Position() != 700*time.Millisecond {
+		t.Fatalf("Want position 700ms, have %v", rp.Position())
+	}
+
+	f, err := rp.Step()
+	if err != nil || f.Device != 1 || len(kbd.frames) != 1 || kbd.frames[0][0].Value != 0 {
+		t.Fatalf("Want key release, have %+v, %v", f, err)
+	}
+
+	if _, err = rp.Step(); err != nil || len(pad.frames) != 1 {
+		t.Fatalf("Want touchpad frame, have %v", err)
+	}
+
+	if _, err = rp.Step(); err != io.EOF {
+		t.Fatalf("Want io.EOF, have %v", err)
+	}
+}
+
+func TestReplayerRun(t *testing.T) {
+	var pad, kbd frameRecorder
+	rp := NewReplayerTo(readSession(t), []EventSink{&pad, &kbd}, ReplaySpeed(10))
+
+	start := time.Now()
+	if err := rp.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pad.frames) != 3 || len(kbd.frames) != 2 {
+		t.Fatalf("Want 3 and 2 frames, have %d and %d", len(pad.frames), len(kbd.frames))
+	}
+
+	// At ten times the speed, the key press comes 60ms in.
+	if d := kbd.times[0].Sub(start); d < 60*time.Millisecond || d > 500*time.Millisecond {
+		t.Fatalf("Want key press after 60ms, have %v", d)
+	}
+
+	if d := pad.times[2].Sub(start); d < 150*time.Millisecond {
+		t.Fatalf("Want last frame after 150ms, have %v", d)
+	}
+
+	if j := rp.Jitter(); j.Frames != 5 || j.Max < j.Mean {
+		t.Fatalf("Want jitter of 5 frames, have %+v", j)
+	}
+}
+
+func TestReplayerLoop(t *testing.T) {
+	var pad, kbd frameRecorder
+	rp := NewReplayerTo(readSession(t), []EventSink{&pad, &kbd}, ReplaySpeed(100), ReplayLoop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := rp.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Want context.DeadlineExceeded, have %v", err)
+	}
+
+	// Each pass takes 15ms.
+	if n := len(kbd.frames); n < 4 {
+		t.Fatalf("Want several passes, have %d key frames", n)
+	}
+}
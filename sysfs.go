@@ -0,0 +1,426 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// SysfsRoot is the mount point of the sysfs file system.
+// It can be pointed at a fixture tree for testing purposes.
+var SysfsRoot = "/sys"
+
+// siblingClasses lists the sysfs classes searched by SysfsDevice.Siblings.
+var siblingClasses = []string{"input", "hidraw", "leds", "power_supply"}
+
+// SysfsDevice describes the sysfs node of an event device,
+// along with the physical device it belongs to.
+//
+// All paths are resolved, so they point into the
+// /sys/devices hierarchy rather than into /sys/class.
+type SysfsDevice struct {
+	Name   string // Kernel name of the event node. E.g.: event5
+	Path   string // The event node. E.g.: /sys/devices/.../input/input5/event5
+	Input  string // The input device owning the event node. E.g.: /sys/devices/.../input/input5
+	HID    string // The HID device, if any. E.g.: /sys/devices/.../0003:046D:C52B.0001
+	USB    string // The USB device, if any. E.g.: /sys/devices/pci0000:00/0000:00:14.0/usb1/1-2
+	Parent string // The physical device; the USB device, the HID device or the input's parent.
+}
+
+// SysfsSibling describes a sysfs node which belongs to the
+// same physical device as a given event node.
+type SysfsSibling struct {
+	Class string // The sysfs class. E.g.: input, hidraw, leds, power_supply
+	Name  string // The kernel name. E.g.: js0, mouse1, hidraw2, input5::capslock
+	Path  string // The resolved sysfs path.
+}
+
+// Node returns the device node for the sibling, if it has one.
+// E.g.: /dev/input/js0 or /dev/hidraw2.
+// LEDs and power supplies have no device node and yield an empty string.
+func (s SysfsSibling) Node() string {
+	switch s.Class {
+	case "input":
+		return filepath.Join(DevInputRoot, s.Name)
+	case "hidraw":
+		return filepath.Join("/dev", s.Name)
+	}
+	return ""
+}
+
+// NewSysfsDevice returns the sysfs description for the given event node.
+// The node can be given as a kernel name (event5) or as a device
+// node path (/dev/input/event5). Symbolic links, such as those found
+// in /dev/input/by-id, are resolved first.
+func NewSysfsDevice(node string) (*SysfsDevice, error) {
+	if strings.ContainsRune(node, '/') {
+		if p, err := filepath.EvalSymlinks(node); err == nil {
+			node = p
+		}
+	}
+
+	name := filepath.Base(node)
+	path, err := filepath.EvalSymlinks(filepath.Join(SysfsRoot, "class", "input", name))
+	if err != nil {
+		return nil, err
+	}
+
+	return newSysfsDevice(name, path)
+}
+
+// Sysfs returns the sysfs description for this device.
+// The node is located through the device number of the open file,
+// so this works regardless of the name the device was opened with.
+func (d *Device) Sysfs() (*SysfsDevice, error) {
+	fd, err := d.rawFd()
+	if err != nil {
+		return nil, err
+	}
+
+	var st syscall.Stat_t
+	if err := syscall.Fstat(fd, &st); err != nil {
+		return nil, err
+	}
+
+	if st.Mode&syscall.S_IFMT != syscall.S_IFCHR {
+		return nil, fmt.Errorf("sysfs: %s is not a character device", d.fd.Name())
+	}
+
+	major := (st.Rdev >> 8) & 0xfff
+	minor := (st.Rdev & 0xff) | ((st.Rdev >> 12) & 0xfff00)
+	link := filepath.Join(SysfsRoot, "dev", "char", fmt.Sprintf("%d:%d", major, minor))
+
+	path, err := filepath.EvalSymlinks(link)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSysfsDevice(filepath.Base(path), path)
+}
+
+// newSysfsDevice fills in the parent devices for the given,
+// resolved event node path.
+func newSysfsDevice(name, path string) (*SysfsDevice, error) {
+	input, err := filepath.EvalSymlinks(filepath.Join(path, "device"))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SysfsDevice{
+		Name:  name,
+		Path:  path,
+		Input: input,
+	}
+
+	devices := filepath.Join(SysfsRoot, "devices")
+	if p, err := filepath.EvalSymlinks(devices); err == nil {
+		devices = p
+	}
+
+	for dir := filepath.Dir(input); len(dir) > len(devices); dir = filepath.Dir(dir) {
+		if s.HID == "" && sysfsSubsystem(dir) == "hid" {
+			s.HID = dir
+		}
+
+		if s.USB == "" && sysfsExists(dir, "idVendor") {
+			s.USB = dir
+			break
+		}
+	}
+
+	switch {
+	case s.USB != "":
+		s.Parent = s.USB
+	case s.HID != "":
+		s.Parent = s.HID
+	default:
+		// Skip the "input" directory holding the input devices of a parent.
+		s.Parent = filepath.Dir(input)
+		if filepath.Base(s.Parent) == "input" {
+			s.Parent = filepath.Dir(s.Parent)
+		}
+
+		// Virtual devices, such as uinput ones, have no parent of their
+		// own. Their nodes are grouped under the input device instead.
+		if s.Parent == filepath.Join(devices, "virtual") || len(s.Parent) <= len(devices) {
+			s.Parent = input
+		}
+	}
+
+	return s, nil
+}
+
+// Attr returns the value of the named attribute of the input device.
+// E.g.: name, phys, uniq, modalias or id/vendor.
+func (s *SysfsDevice) Attr(name string) (string, error) {
+	return sysfsAttr(s.Input, name)
+}
+
+// ParentAttr returns the value of the named attribute of the physical device.
+// E.g.: manufacturer, product, serial or idVendor for USB devices.
+func (s *SysfsDevice) ParentAttr(name string) (string, error) {
+	return sysfsAttr(s.Parent, name)
+}
+
+// Manufacturer returns the manufacturer string of the physical device.
+// Only USB devices provide this; others yield an empty string.
+func (s *SysfsDevice) Manufacturer() string {
+	if s.USB == "" {
+		return ""
+	}
+
+	v, _ := sysfsAttr(s.USB, "manufacturer")
+	return v
+}
+
+// Product returns the product string of the physical device.
+// Devices which do not provide one yield the name of the input device.
+func (s *SysfsDevice) Product() string {
+	if s.USB != "" {
+		if v, err := sysfsAttr(s.USB, "product"); err == nil {
+			return v
+		}
+	}
+
+	v, _ := s.Attr("name")
+	return v
+}
+
+// Driver returns the name of the driver bound to the device
+// closest to the input device. E.g.: hid-generic, usbhid or atkbd.
+func (s *SysfsDevice) Driver() string {
+	for dir := filepath.Dir(s.Input); strings.HasPrefix(dir, s.Parent); dir = filepath.Dir(dir) {
+		if link, err := os.Readlink(filepath.Join(dir, "driver")); err == nil {
+			return filepath.Base(link)
+		}
+	}
+	return ""
+}
+
+// Modalias returns the module alias of the input device.
+// E.g.: input:b0003v046DpC52Be0111-e0,1,2,4,...
+func (s *SysfsDevice) Modalias() string {
+	v, _ := s.Attr("modalias")
+	return v
+}
+
+// Siblings returns the sysfs nodes which belong to the same physical
+// device as this event node. These include the js*, mouse* and other
+// event* nodes, hidraw* nodes, LEDs and power supplies.
+//
+// The event node itself and the input* nodes are not included.
+func (s *SysfsDevice) Siblings() ([]SysfsSibling, error) {
+	var list []SysfsSibling
+
+	for _, class := range siblingClasses {
+		dir := filepath.Join(SysfsRoot, "class", class)
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, e := range entries {
+			name := e.Name()
+			if class == "input" && (name == s.Name || strings.HasPrefix(name, "input")) {
+				continue
+			}
+
+			path, err := filepath.EvalSymlinks(filepath.Join(dir, name))
+			if err != nil || !strings.HasPrefix(path, s.Parent+"/") {
+				continue
+			}
+
+			list = append(list, SysfsSibling{
+				Class: class,
+				Name:  name,
+				Path:  path,
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// sysfsAttr reads the given attribute file, stripped of trailing whitespace.
+func sysfsAttr(dir, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n\x00 "), nil
+}
+
+// sysfsSubsystem returns the name of the subsystem the given node belongs to.
+func sysfsSubsystem(dir string) string {
+	link, err := os.Readlink(filepath.Join(dir, "subsystem"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(link)
+}
+
+// sysfsExists returns true if the given attribute exists.
+func sysfsExists(dir, name string) bool {
+	_, err := os.Lstat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// SysfsInput describes an input device as listed in /sys/class/input.
+// Reading it requires no special privileges, so it can be used
+// to inspect devices before opening them.
+type SysfsInput struct {
+	Path     string   // Resolved sysfs path. E.g.: /sys/devices/.../input/input5
+	Name     string   // Name of the device. See Device.Name().
+	Phys     string   // Physical path of the device. See Device.Path().
+	Uniq     string   // Unique serial code of the device. See Device.Serial().
+	Id       Id       // Device identity. See Device.Id().
+	Handlers []string // Kernel names of the handler nodes. E.g.: event5, js0, mouse0
+	Capabilities
+}
+
+// SysfsInputs lists all input devices found in /sys/class/input.
+// Devices which vanish while the directory is being scanned, such as
+// when they are unplugged, are left out.
+func SysfsInputs() ([]*SysfsInput, error) {
+	dir := filepath.Join(SysfsRoot, "class", "input")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*SysfsInput
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "input") {
+			continue
+		}
+
+		s, err := NewSysfsInput(filepath.Join(dir, e.Name()))
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENODEV) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, s)
+	}
+
+	return list, nil
+}
+
+// NewSysfsInput reads the input device at the given sysfs path.
+// E.g.: /sys/class/input/input5
+func NewSysfsInput(path string) (*SysfsInput, error) {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SysfsInput{Path: path}
+
+	if s.Name, err = sysfsAttr(path, "name"); err != nil {
+		return nil, err
+	}
+
+	s.Phys, _ = sysfsAttr(path, "phys")
+	s.Uniq, _ = sysfsAttr(path, "uniq")
+
+	ids := []struct {
+		Name  string
+		Value *uint16
+	}{
+		{"id/bustype", &s.Id.BusType},
+		{"id/vendor", &s.Id.Vendor},
+		{"id/product", &s.Id.Product},
+		{"id/version", &s.Id.Version},
+	}
+
+	for _, id := range ids {
+		v, err := sysfsAttr(path, id.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := strconv.ParseUint(v, 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("sysfs: %s: %v", id.Name, err)
+		}
+
+		*id.Value = uint16(n)
+	}
+
+	caps := []struct {
+		Name string
+		Bits int
+		Set  *Bitset
+	}{
+		{"properties", InputPropCount, &s.Properties},
+		{"capabilities/ev", EvCount, &s.Events},
+		{"capabilities/key", KeyCount, &s.Keys},
+		{"capabilities/rel", RelCount, &s.Relative},
+		{"capabilities/abs", AbsCount, &s.Absolute},
+		{"capabilities/msc", MiscCount, &s.Misc},
+		{"capabilities/sw", SwCount, &s.Switches},
+		{"capabilities/led", LedCount, &s.LEDs},
+		{"capabilities/snd", SndCount, &s.Sounds},
+		{"capabilities/ff", FFCount, &s.ForceFeedback},
+	}
+
+	for _, c := range caps {
+		*c.Set = NewBitset(c.Bits)
+
+		v, err := sysfsAttr(path, c.Name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if err = parseHexBitset(*c.Set, v); err != nil {
+			return nil, fmt.Errorf("sysfs: %s: %v", c.Name, err)
+		}
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() && sysfsExists(filepath.Join(path, e.Name()), "dev") {
+			s.Handlers = append(s.Handlers, e.Name())
+		}
+	}
+
+	return s, nil
+}
+
+// Node returns the event node for this device. E.g.: /dev/input/event5.
+// This returns an empty string if the device has no event handler.
+func (s *SysfsInput) Node() string {
+	for _, h := range s.Handlers {
+		if strings.HasPrefix(h, "event") {
+			return filepath.Join(DevInputRoot, h)
+		}
+	}
+	return ""
+}
+
+// SysfsInput reads the input device owning this event node.
+func (s *SysfsDevice) SysfsInput() (*SysfsInput, error) {
+	return NewSysfsInput(s.Input)
+}
@@ -0,0 +1,403 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Device paths in the sysfs fixture tree.
+const (
+	fixtureUSB = "devices/pci0000:00/0000:00:14.0/usb1/1-2"
+	fixtureHID = fixtureUSB + "/1-2:1.0/0003:046D:C52B.0001"
+	fixtureKbd = "devices/platform/i8042/serio0/input/input3"
+)
+
+// sysfsFixture builds a small sysfs tree in a temporary directory
+// and points SysfsRoot at it for the duration of the test.
+//
+// It holds a USB mouse (input5/event5) with a js, mouse and
+// hidraw node, an LED and a battery; as well as a PS/2
+// keyboard (input3/event3).
+func sysfsFixture(t *testing.T) string {
+	root := t.TempDir()
+	pinTextABI(t, "amd64")
+
+	file := func(path, data string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	link := func(path, target string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		rel, err := filepath.Rel(filepath.Dir(path), filepath.Join(root, target))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(rel, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mkdir := func(path string) {
+		if err := os.MkdirAll(filepath.Join(root, path), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mkdir("bus/hid/drivers/hid-generic")
+	mkdir("bus/usb/drivers/usbhid")
+	mkdir("bus/serio/drivers/atkbd")
+
+	file(fixtureUSB+"/idVendor", "046d")
+	file(fixtureUSB+"/idProduct", "c52b")
+	file(fixtureUSB+"/manufacturer", "Logitech")
+	file(fixtureUSB+"/product", "USB Receiver")
+	link(fixtureUSB+"/subsystem", "bus/usb")
+	link(fixtureUSB+"/1-2:1.0/driver", "bus/usb/drivers/usbhid")
+	file(fixtureUSB+"/1-2:1.0/modalias", "usb:v046DpC52Bd1211dc00dsc00dp00ic03isc01ip02in00")
+
+	link(fixtureHID+"/subsystem", "bus/hid")
+	link(fixtureHID+"/driver", "bus/hid/drivers/hid-generic")
+	file(fixtureHID+"/modalias", "hid:b0003g0000v0000046Dp0000C52B")
+	mkdir(fixtureHID + "/hidraw/hidraw0")
+	mkdir(fixtureHID + "/power_supply/hid-0003:046D:C52B.0001-battery")
+
+	input := fixtureHID + "/input/input5"
+	file(input+"/name", "Logitech USB Receiver")
+	file(input+"/phys", "usb-0000:00:14.0-2/input0")
+	file(input+"/uniq", "")
+	file(input+"/modalias", "input:b0003v046DpC52Be0111-e0,1,2,4,k110,111,112,r0,1,8,m4,lsfw")
+	file(input+"/id/bustype", "0003")
+	file(input+"/id/vendor", "046d")
+	file(input+"/id/product", "c52b")
+	file(input+"/id/version", "0111")
+	file(input+"/properties", "0")
+	file(input+"/capabilities/ev", "17")
+	file(input+"/capabilities/key", "1f0000 0 0 0 0")
+	file(input+"/capabilities/rel", "1943")
+	file(input+"/capabilities/abs", "0")
+	file(input+"/capabilities/msc", "10")
+	file(input+"/capabilities/led", "0")
+	file(input+"/capabilities/snd", "0")
+	file(input+"/capabilities/ff", "0")
+	file(input+"/capabilities/sw", "0")
+	file(input+"/event5/dev", "13:69")
+	file(input+"/mouse0/dev", "13:33")
+	file(input+"/js0/dev", "13:0")
+	mkdir(input + "/input5::scrolllock")
+	link(input+"/event5/device", input)
+	link(input+"/mouse0/device", input)
+	link(input+"/js0/device", input)
+
+	file(fixtureKbd+"/name", "AT Translated Set 2 keyboard")
+	file(fixtureKbd+"/phys", "isa0060/serio0/input0")
+	file(fixtureKbd+"/uniq", "")
+	file(fixtureKbd+"/modalias", "input:b0011v0001p0001eAB41-e0,1,4,11,14,k71,72,73,ram4,l0,1,2,sfw")
+	file(fixtureKbd+"/id/bustype", "0011")
+	file(fixtureKbd+"/id/vendor", "0001")
+	file(fixtureKbd+"/id/product", "0001")
+	file(fixtureKbd+"/id/version", "ab41")
+	file(fixtureKbd+"/properties", "0")
+	file(fixtureKbd+"/capabilities/ev", "120013")
+	file(fixtureKbd+"/capabilities/key", "402000000 3803078f800d001 feffffdfffefffff fffffffffffffffe")
+	file(fixtureKbd+"/capabilities/rel", "0")
+	file(fixtureKbd+"/capabilities/abs", "0")
+	file(fixtureKbd+"/capabilities/msc", "10")
+	file(fixtureKbd+"/capabilities/led", "7")
+	file(fixtureKbd+"/capabilities/snd", "0")
+	file(fixtureKbd+"/capabilities/ff", "0")
+	file(fixtureKbd+"/capabilities/sw", "0")
+	link("devices/platform/i8042/serio0/driver", "bus/serio/drivers/atkbd")
+	file(fixtureKbd+"/event3/dev", "13:67")
+	link(fixtureKbd+"/event3/device", fixtureKbd)
+
+	link("class/input/input5", input)
+	link("class/input/event5", input+"/event5")
+	link("class/input/mouse0", input+"/mouse0")
+	link("class/input/js0", input+"/js0")
+	link("class/input/input3", fixtureKbd)
+	link("class/input/event3", fixtureKbd+"/event3")
+	link("class/hidraw/hidraw0", fixtureHID+"/hidraw/hidraw0")
+	link("class/leds/input5::scrolllock", input+"/input5::scrolllock")
+	link("class/power_supply/hid-0003:046D:C52B.0001-battery", fixtureHID+"/power_supply/hid-0003:046D:C52B.0001-battery")
+	link("dev/char/13:69", input+"/event5")
+
+	old := SysfsRoot
+	SysfsRoot = root
+	t.Cleanup(func() { SysfsRoot = old })
+
+	return root
+}
+
+func TestSysfsDevice(t *testing.T) {
+	root := sysfsFixture(t)
+
+	s, err := NewSysfsDevice("/dev/input/event5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := SysfsDevice{
+		Name:   "event5",
+		Path:   filepath.Join(root, fixtureHID, "input/input5/event5"),
+		Input:  filepath.Join(root, fixtureHID, "input/input5"),
+		HID:    filepath.Join(root, fixtureHID),
+		USB:    filepath.Join(root, fixtureUSB),
+		Parent: filepath.Join(root, fixtureUSB),
+	}
+
+	if !reflect.DeepEqual(*s, want) {
+		t.Fatalf("Want %+v\nhave %+v", want, *s)
+	}
+
+	attrs := []struct {
+		Name string
+		Want string
+		Have string
+	}{
+		{"Manufacturer", "Logitech", s.Manufacturer()},
+		{"Product", "USB Receiver", s.Product()},
+		{"Driver", "hid-generic", s.Driver()},
+		{"Modalias", "input:b0003v046DpC52Be0111-e0,1,2,4,k110,111,112,r0,1,8,m4,lsfw", s.Modalias()},
+	}
+
+	for _, a := range attrs {
+		if a.Have != a.Want {
+			t.Fatalf("%s: Want %q, have %q", a.Name, a.Want, a.Have)
+		}
+	}
+
+	if v, err := s.ParentAttr("idVendor"); err != nil || v != "046d" {
+		t.Fatalf("ParentAttr: Want %q, have %q (%v)", "046d", v, err)
+	}
+}
+
+func TestSysfsDeviceSiblings(t *testing.T) {
+	sysfsFixture(t)
+
+	s, err := NewSysfsDevice("event5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := s.Siblings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		Class string
+		Name  string
+		Node  string
+	}{
+		{"input", "js0", "/dev/input/js0"},
+		{"input", "mouse0", "/dev/input/mouse0"},
+		{"hidraw", "hidraw0", "/dev/hidraw0"},
+		{"leds", "input5::scrolllock", ""},
+		{"power_supply", "hid-0003:046D:C52B.0001-battery", ""},
+	}
+
+	if len(list) != len(want) {
+		t.Fatalf("Want %d siblings, have %d: %+v", len(want), len(list), list)
+	}
+
+	for i, w := range want {
+		if list[i].Class != w.Class || list[i].Name != w.Name || list[i].Node() != w.Node {
+			t.Fatalf("Sibling %d: Want %+v, have %+v", i, w, list[i])
+		}
+	}
+}
+
+func TestSysfsDeviceSerio(t *testing.T) {
+	root := sysfsFixture(t)
+
+	s, err := NewSysfsDevice("event3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.USB != "" || s.HID != "" {
+		t.Fatalf("Want no USB or HID parent, have %q and %q", s.USB, s.HID)
+	}
+
+	if want := filepath.Join(root, "devices/platform/i8042/serio0"); s.Parent != want {
+		t.Fatalf("Parent: Want %q, have %q", want, s.Parent)
+	}
+
+	if s.Driver() != "atkbd" {
+		t.Fatalf("Driver: Want %q, have %q", "atkbd", s.Driver())
+	}
+
+	if s.Product() != "AT Translated Set 2 keyboard" {
+		t.Fatalf("Product: Want %q, have %q", "AT Translated Set 2 keyboard", s.Product())
+	}
+
+	list, err := s.Siblings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 0 {
+		t.Fatalf("Want no siblings, have %+v", list)
+	}
+}
+
+func TestSysfsDeviceVirtual(t *testing.T) {
+	root := sysfsFixture(t)
+
+	// Two uinput devices, which share no physical parent.
+	for _, n := range []string{"20", "21"} {
+		input := "devices/virtual/input/input" + n
+		for _, dir := range []string{"event" + n, "input" + n + "::capslock"} {
+			if err := os.MkdirAll(filepath.Join(root, input, dir), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for path, target := range map[string]string{
+			"class/input/input" + n:               input,
+			"class/input/event" + n:               input + "/event" + n,
+			"class/leds/input" + n + "::capslock": input + "/input" + n + "::capslock",
+			input + "/event" + n + "/device":      input,
+		} {
+			if err := os.Symlink(filepath.Join(root, target), filepath.Join(root, path)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	for _, n := range []string{"20", "21"} {
+		s, err := NewSysfsDevice("event" + n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		input := filepath.Join(root, "devices/virtual/input/input"+n)
+		if s.Parent != input {
+			t.Fatalf("Parent: Want %q, have %q", input, s.Parent)
+		}
+
+		list, err := s.Siblings()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(list) != 1 || list[0].Name != "input"+n+"::capslock" {
+			t.Fatalf("Want only the LED of input%s, have %+v", n, list)
+		}
+	}
+}
+
+func TestSysfsInputs(t *testing.T) {
+	root := sysfsFixture(t)
+
+	// A device unplugged while the directory is being scanned.
+	if err := os.Symlink(filepath.Join(root, "devices/virtual/input/input9"), filepath.Join(root, "class/input/input9")); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := SysfsInputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("Want 2 devices, have %d", len(list))
+	}
+
+	kbd, mouse := list[0], list[1]
+
+	if kbd.Name != "AT Translated Set 2 keyboard" || kbd.Phys != "isa0060/serio0/input0" {
+		t.Fatalf("Unexpected keyboard: %+v", kbd)
+	}
+
+	if want := (Id{BusI8042, 0x0001, 0x0001, 0xab41}); kbd.Id != want {
+		t.Fatalf("Id: Want %+v, have %+v", want, kbd.Id)
+	}
+
+	if kbd.Node() != "/dev/input/event3" {
+		t.Fatalf("Node: Want %q, have %q", "/dev/input/event3", kbd.Node())
+	}
+
+	old := DevInputRoot
+	DevInputRoot = "/tmp/input"
+	if kbd.Node() != "/tmp/input/event3" {
+		t.Fatalf("Node: Want %q, have %q", "/tmp/input/event3", kbd.Node())
+	}
+	DevInputRoot = old
+
+	if !kbd.IsKeyboard() || kbd.IsMouse() {
+		t.Fatalf("Want keyboard, have %+v", kbd.Events)
+	}
+
+	for _, key := range []int{KeyEscape, KeyA, KeyLeftShift, KeyF12} {
+		if !kbd.Keys.Test(key) {
+			t.Fatalf("Want key 0x%02x", key)
+		}
+	}
+
+	if !kbd.LEDs.Test(LedCapsLock) || kbd.LEDs.Test(LedMute) {
+		t.Fatalf("Unexpected LEDs: %v", kbd.LEDs)
+	}
+
+	if !reflect.DeepEqual(mouse.Handlers, []string{"event5", "js0", "mouse0"}) {
+		t.Fatalf("Handlers: Want [event5 js0 mouse0], have %v", mouse.Handlers)
+	}
+
+	if !mouse.IsMouse() || mouse.IsKeyboard() {
+		t.Fatalf("Want mouse, have %+v", mouse.Events)
+	}
+
+	for _, btn := range []int{BtnLeft, BtnRight, BtnMiddle, BtnSide, BtnExtra} {
+		if !mouse.Keys.Test(btn) {
+			t.Fatalf("Want button 0x%02x", btn)
+		}
+	}
+
+	for _, rel := range []int{RelX, RelY, RelHWheel, RelWheel} {
+		if !mouse.Relative.Test(rel) {
+			t.Fatalf("Want relative axis 0x%02x", rel)
+		}
+	}
+
+	s, err := NewSysfsDevice("event5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := s.SysfsInput()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(in, mouse) {
+		t.Fatalf("Want %+v, have %+v", mouse, in)
+	}
+}
+
+func TestSysfsInputsUnreadable(t *testing.T) {
+	root := sysfsFixture(t)
+
+	// A device whose name cannot be read, unlike one which vanished.
+	dir := filepath.Join(root, "class/input/input9")
+	if err := os.MkdirAll(filepath.Join(dir, "name"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SysfsInputs(); err == nil {
+		t.Fatalf("Want an error for an unreadable device")
+	}
+}
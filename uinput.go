@@ -0,0 +1,248 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+)
+
+// UInputPath is the location of the uinput device node.
+var UInputPath = "/dev/uinput"
+
+// <linux/uinput.h>
+
+const uinputMaxNameSize = 80
+
+// uinputSetup is the kernel's struct uinput_setup.
+type uinputSetup struct {
+	Id           Id
+	Name         [uinputMaxNameSize]byte
+	FFEffectsMax uint32
+}
+
+// uinputAbsSetup is the kernel's struct uinput_abs_setup.
+type uinputAbsSetup struct {
+	Code    uint16
+	_       uint16
+	AbsInfo AbsInfo
+}
+
+var (
+	_UI_DEV_CREATE  = _IO('U', 1)
+	_UI_DEV_DESTROY = _IO('U', 2)
+	_UI_DEV_SETUP   = _IOW('U', 3, int(unsafe.Sizeof(uinputSetup{})))
+	_UI_ABS_SETUP   = _IOW('U', 4, int(unsafe.Sizeof(uinputAbsSetup{})))
+	_UI_SET_EVBIT   = _IOW('U', 100, 4)
+	_UI_SET_KEYBIT  = _IOW('U', 101, 4)
+	_UI_SET_RELBIT  = _IOW('U', 102, 4)
+	_UI_SET_ABSBIT  = _IOW('U', 103, 4)
+	_UI_SET_MSCBIT  = _IOW('U', 104, 4)
+	_UI_SET_LEDBIT  = _IOW('U', 105, 4)
+	_UI_SET_SNDBIT  = _IOW('U', 106, 4)
+	_UI_SET_FFBIT   = _IOW('U', 107, 4)
+	_UI_SET_PHYS    = _IOW('U', 108, int(unsafe.Sizeof(uintptr(0))))
+	_UI_SET_SWBIT   = _IOW('U', 109, 4)
+	_UI_SET_PROPBIT = _IOW('U', 110, 4)
+)
+
+// uinputBitIoctls maps event types to the ioctls enabling their codes.
+var uinputBitIoctls = map[int]uintptr{
+	EvKeys:          _UI_SET_KEYBIT,
+	EvRelative:      _UI_SET_RELBIT,
+	EvAbsolute:      _UI_SET_ABSBIT,
+	EvMisc:          _UI_SET_MSCBIT,
+	EvLed:           _UI_SET_LEDBIT,
+	EvSound:         _UI_SET_SNDBIT,
+	EvForceFeedback: _UI_SET_FFBIT,
+	EvSwitch:        _UI_SET_SWBIT,
+}
+
+func _UI_GET_SYSNAME(len int) uintptr {
+	return _IOC(_IOC_READ, 'U', 44, len)
+}
+
+// UInput is a virtual input device, created through uinput.
+// Events written to it are emitted by its event node,
+// as if they came from a physical device.
+type UInput struct {
+	fd        *os.File
+	dec       *Decoder // Decodes events sent to the device.
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewUInput creates a virtual device with the given identity and capabilities.
+// This usually requires write access to /dev/uinput.
+func NewUInput(desc *Description) (*UInput, error) {
+	fd, err := os.OpenFile(UInputPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &UInput{fd: fd, dec: NewDecoder(fd)}
+	if err = u.setup(desc); err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// setup declares the device's capabilities and creates it.
+func (u *UInput) setup(desc *Description) error {
+	for evtype := 0; evtype < desc.Events.Len(); evtype++ {
+		if !desc.Events.Test(evtype) {
+			continue
+		}
+
+		if err := u.ioctl(_UI_SET_EVBIT, evtype); err != nil {
+			return err
+		}
+
+		name, ok := uinputBitIoctls[evtype]
+		if !ok {
+			continue
+		}
+
+		set := desc.Bits(evtype)
+		for code := 0; code < set.Len(); code++ {
+			if !set.Test(code) {
+				continue
+			}
+
+			if err := u.ioctl(name, code); err != nil {
+				return err
+			}
+
+			if evtype != EvAbsolute {
+				continue
+			}
+
+			abs := uinputAbsSetup{Code: uint16(code), AbsInfo: desc.Abs[code]}
+			if err := u.ioctl(_UI_ABS_SETUP, unsafe.Pointer(&abs)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for prop := 0; prop < desc.Properties.Len(); prop++ {
+		if desc.Properties.Test(prop) {
+			if err := u.ioctl(_UI_SET_PROPBIT, prop); err != nil {
+				return err
+			}
+		}
+	}
+
+	if desc.Phys != "" {
+		phys := append([]byte(desc.Phys), 0)
+		if err := u.ioctl(_UI_SET_PHYS, unsafe.Pointer(&phys[0])); err != nil {
+			return err
+		}
+	}
+
+	var setup uinputSetup
+	setup.Id = desc.Id
+	copy(setup.Name[:uinputMaxNameSize-1], desc.Name)
+
+	if err := u.ioctl(_UI_DEV_SETUP, unsafe.Pointer(&setup)); err != nil {
+		return err
+	}
+
+	return u.ioctl(_UI_DEV_CREATE, 0)
+}
+
+// WriteEvents emits the given events from the virtual device.
+// A frame should be terminated with a SynReport event.
+func (u *UInput) WriteEvents(events ...Event) error {
+	buf := nativeABI.encodeEvents(events)
+
+	n, err := u.fd.Write(buf)
+	if err != nil {
+		if errors.Is(err, os.ErrClosed) {
+			return ErrClosed
+		}
+		return err
+	}
+
+	if n < len(buf) {
+		return io.ErrShortWrite
+	}
+
+	return nil
+}
+
+// ReadEvents reads events sent to the virtual device, such as the
+// EvLed events written to its event node when the state of its LEDs
+// changes. It blocks until events arrive, and returns ErrClosed once
+// the device has been closed.
+func (u *UInput) ReadEvents(buf []Event) (int, error) {
+	n, err := u.dec.ReadEvents(buf)
+	if errors.Is(err, os.ErrClosed) {
+		return n, ErrClosed
+	}
+	return n, err
+}
+
+// SysName returns the kernel name of the virtual input device. E.g.: input17.
+func (u *UInput) SysName() (string, error) {
+	var str [64]byte
+	if err := u.ioctl(_UI_GET_SYSNAME(len(str)), unsafe.Pointer(&str[0])); err != nil {
+		return "", err
+	}
+	return cstring(str[:]), nil
+}
+
+// Node returns the event node of the virtual device. E.g.: /dev/input/event17.
+// The node is found through sysfs.
+func (u *UInput) Node() (string, error) {
+	name, err := u.SysName()
+	if err != nil {
+		return "", err
+	}
+
+	s, err := NewSysfsInput(filepath.Join(SysfsRoot, "class", "input", name))
+	if err != nil {
+		return "", err
+	}
+
+	if node := s.Node(); node != "" {
+		return node, nil
+	}
+
+	return "", errors.New("uinput: no event node for " + name)
+}
+
+// Close destroys the virtual device.
+// It is safe to call Close more than once.
+func (u *UInput) Close() error {
+	u.closeOnce.Do(func() {
+		u.ioctl(_UI_DEV_DESTROY, 0)
+		u.closeErr = u.fd.Close()
+	})
+	return u.closeErr
+}
+
+// ioctl performs the given ioctl on the uinput node.
+// This returns ErrClosed if the device has been closed.
+func (u *UInput) ioctl(name uintptr, data interface{}) error {
+	sc, err := u.fd.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	cerr := sc.Control(func(fd uintptr) {
+		err = ioctl(fd, name, data)
+	})
+
+	if cerr != nil {
+		return ErrClosed
+	}
+
+	return err
+}
@@ -0,0 +1,167 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// testKeyboard describes the virtual keyboard used by the uinput tests.
+func testKeyboard() *Description {
+	desc := &Description{
+		Name: "evdev test keyboard",
+		Id:   Id{BusType: BusVirtual, Vendor: 0x1234, Product: 0x5678, Version: 1},
+		Capabilities: Capabilities{
+			Events: NewBitset(EvCount),
+			Keys:   NewBitset(KeyCount),
+			LEDs:   NewBitset(LedCount),
+		},
+	}
+
+	desc.Events.Set(EvSync)
+	desc.Events.Set(EvKeys)
+	desc.Events.Set(EvLed)
+	desc.Keys.Set(KeyA)
+	desc.Keys.Set(KeyB)
+	desc.LEDs.Set(LedCapsLock)
+	return desc
+}
+
+// uinputDevice creates a virtual device and opens its event node.
+// The test is skipped if uinput is not available.
+func uinputDevice(t *testing.T, desc *Description, opts ...Option) (*UInput, *Device) {
+	u, err := NewUInput(desc)
+	if err != nil {
+		t.Skipf("uinput not available: %v", err)
+	}
+
+	t.Cleanup(func() { u.Close() })
+
+	return u, openNode(t, u, opts...)
+}
+
+// openNode opens the event node of the virtual device, which is
+// closed when the test ends.
+func openNode(t *testing.T, u *UInput, opts ...Option) *Device {
+	node, err := u.Node()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Give udev a moment to set up the node.
+	var dev *Device
+	for i := 0; ; i++ {
+		dev, err = Open(node, opts...)
+		if err == nil {
+			break
+		}
+
+		if i == 100 {
+			t.Fatal(err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Cleanup(func() { dev.Close() })
+	return dev
+}
+
+func TestUInput(t *testing.T) {
+	desc := testKeyboard()
+	u, dev := uinputDevice(t, desc)
+
+	if name := dev.Name(); name != desc.Name {
+		t.Fatalf("Name: Want %q, have %q", desc.Name, name)
+	}
+
+	if id := dev.Id(); id != desc.Id {
+		t.Fatalf("Id: Want %+v, have %+v", desc.Id, id)
+	}
+
+	if !dev.Test(dev.EventTypes(), EvKeys, EvLed) {
+		t.Fatalf("Want key and LED support")
+	}
+
+	want := []Event{
+		{Type: EvKeys, Code: KeyA, Value: 1},
+		{Type: EvSync, Code: SynReport},
+	}
+
+	if err := u.WriteEvents(want...); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, w := range want {
+		have := <-dev.Inbox
+		if have.Type != w.Type || have.Code != w.Code || have.Value != w.Value {
+			t.Fatalf("Event %d: Want %+v, have %+v", i, w, have)
+		}
+	}
+}
+
+func TestUInputClose(t *testing.T) {
+	u, dev := uinputDevice(t, testKeyboard())
+
+	var wg sync.WaitGroup
+
+	// Keep the reader busy.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 200; n++ {
+			if u.WriteEvents(
+				Event{Type: EvKeys, Code: KeyB, Value: int32(n & 1)},
+				Event{Type: EvSync, Code: SynReport},
+			) != nil {
+				return
+			}
+		}
+	}()
+
+	// Consume only part of the events.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 10; n++ {
+			<-dev.Inbox
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				if dev.WriteEvents(Event{Type: EvLed, Code: LedCapsLock, Value: int32(n & 1)}) == ErrClosed {
+					return
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			time.Sleep(5 * time.Millisecond)
+			dev.Close()
+		}()
+	}
+
+	wg.Wait()
+	closeWithin(t, dev)
+
+	if err := dev.WriteEvents(Event{Type: EvLed, Code: LedCapsLock}); err != ErrClosed {
+		t.Fatalf("Want %v, have %v", ErrClosed, err)
+	}
+
+	if err := u.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := u.WriteEvents(Event{Type: EvSync}); err != ErrClosed {
+		t.Fatalf("Want %v, have %v", ErrClosed, err)
+	}
+}
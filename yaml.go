@@ -0,0 +1,374 @@
+// This file is subject to a 1-clause BSD license.
+// Its contents can be found in the enclosed LICENSE file.
+
+package evdev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yamlNode is a value in the subset of YAML written by tools such as
+// libinput record: block mappings and sequences, single line flow
+// sequences and mappings, plain and quoted scalars and comments.
+type yamlNode struct {
+	Line   int
+	Scalar string               // Set for scalars.
+	IsList bool                 // Set for sequences.
+	List   []*yamlNode          // Items of a sequence.
+	Keys   []string             // Keys of a mapping, in order.
+	Map    map[string]*yamlNode // Values of a mapping.
+}
+
+// Get returns the value for the given key, or nil.
+func (n *yamlNode) Get(key string) *yamlNode {
+	if n == nil {
+		return nil
+	}
+	return n.Map[key]
+}
+
+// Int returns the scalar as an integer.
+func (n *yamlNode) Int() (int64, error) {
+	if n == nil {
+		return 0, fmt.Errorf("missing value")
+	}
+
+	v, err := strconv.ParseInt(n.Scalar, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("line %d: %v", n.Line, err)
+	}
+	return v, nil
+}
+
+// Ints returns the items of a sequence of integers.
+func (n *yamlNode) Ints() ([]int64, error) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if !n.IsList {
+		return nil, fmt.Errorf("line %d: want a list", n.Line)
+	}
+
+	list := make([]int64, len(n.List))
+	for i, item := range n.List {
+		v, err := item.Int()
+		if err != nil {
+			return nil, err
+		}
+		list[i] = v
+	}
+	return list, nil
+}
+
+// yamlLine is a line stripped of comments and indentation.
+type yamlLine struct {
+	Num    int
+	Indent int
+	Text   string
+}
+
+// yamlParser builds nodes from the lines of a document.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML reads a document whose root is a mapping or a sequence.
+func parseYAML(r io.Reader) (*yamlNode, error) {
+	var p yamlParser
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	for num := 1; scanner.Scan(); num++ {
+		raw := scanner.Text()
+		text := strings.TrimRight(yamlStripComment(raw), " \t")
+		trimmed := strings.TrimLeft(text, " ")
+
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		p.lines = append(p.lines, yamlLine{num, len(text) - len(trimmed), trimmed})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(p.lines) == 0 {
+		return &yamlNode{Map: map[string]*yamlNode{}}, nil
+	}
+
+	root, err := p.block(p.lines[0].Indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return root, nil
+}
+
+// yamlStripComment removes a comment from the line,
+// unless the hash is part of a quoted string.
+func yamlStripComment(s string) string {
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+
+	return s
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	num := 0
+	if p.pos < len(p.lines) {
+		num = p.lines[p.pos].Num
+	}
+	return fmt.Errorf("yaml: line %d: %s", num, fmt.Sprintf(format, args...))
+}
+
+// block parses the mapping or sequence starting at the current line.
+func (p *yamlParser) block(indent int) (*yamlNode, error) {
+	if yamlIsItem(p.lines[p.pos].Text) {
+		return p.sequence(indent)
+	}
+	return p.mapping(indent, "")
+}
+
+func yamlIsItem(s string) bool {
+	return s == "-" || strings.HasPrefix(s, "- ")
+}
+
+// sequence parses the items at the given indentation.
+func (p *yamlParser) sequence(indent int) (*yamlNode, error) {
+	node := &yamlNode{Line: p.lines[p.pos].Num, IsList: true}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.Indent != indent || !yamlIsItem(line.Text) {
+			break
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(line.Text, "-"), " ")
+
+		var item *yamlNode
+		var err error
+
+		switch {
+		case rest == "":
+			p.pos++
+			item, err = p.child(indent)
+		case yamlIsKey(rest):
+			// A mapping starting on the line of the dash.
+			item, err = p.mapping(indent+len(line.Text)-len(rest), rest)
+		default:
+			p.pos++
+			item, err = yamlValue(rest, line.Num)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		node.List = append(node.List, item)
+	}
+
+	return node, nil
+}
+
+// mapping parses the entries at the given indentation. If first is set,
+// it holds the first entry, which shares a line with a sequence dash.
+func (p *yamlParser) mapping(indent int, first string) (*yamlNode, error) {
+	node := &yamlNode{Line: p.lines[p.pos].Num, Map: make(map[string]*yamlNode)}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		text := line.Text
+
+		if first != "" {
+			text, first = first, ""
+		} else if line.Indent != indent || yamlIsItem(text) {
+			break
+		}
+
+		key, rest, ok := yamlCutKey(text)
+		if !ok {
+			return nil, p.errorf("want key: value, have %q", text)
+		}
+
+		p.pos++
+
+		var value *yamlNode
+		var err error
+
+		if rest == "" {
+			value, err = p.child(indent)
+		} else {
+			value, err = yamlValue(rest, line.Num)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := node.Map[key]; !ok {
+			node.Keys = append(node.Keys, key)
+		}
+		node.Map[key] = value
+	}
+
+	return node, nil
+}
+
+// child parses the block nested below an entry or a dash at the given
+// indentation. A sequence may be nested at the same indentation.
+func (p *yamlParser) child(indent int) (*yamlNode, error) {
+	if p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.Indent > indent || (line.Indent == indent && yamlIsItem(line.Text)) {
+			return p.block(line.Indent)
+		}
+	}
+	return &yamlNode{}, nil
+}
+
+func yamlIsKey(s string) bool {
+	_, _, ok := yamlCutKey(s)
+	return ok && !strings.ContainsAny(s[:1], "[{\"'")
+}
+
+// yamlCutKey splits "key: value" or "key:" into its key and value.
+func yamlCutKey(s string) (key, value string, ok bool) {
+	if i := strings.Index(s, ": "); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i+2:]), true
+	}
+	if strings.HasSuffix(s, ":") {
+		return s[:len(s)-1], "", true
+	}
+	return "", "", false
+}
+
+// yamlValue parses a scalar, or a flow sequence or mapping on a single line.
+func yamlValue(s string, num int) (*yamlNode, error) {
+	open, end := s[:min(len(s), 1)], ""
+	switch open {
+	case "[":
+		end = "]"
+	case "{":
+		end = "}"
+	default:
+		v, err := yamlScalar(s)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %v", num, err)
+		}
+		return &yamlNode{Line: num, Scalar: v}, nil
+	}
+
+	if !strings.HasSuffix(s, end) {
+		return nil, fmt.Errorf("yaml: line %d: unterminated %s", num, open)
+	}
+
+	node := &yamlNode{Line: num, IsList: open == "["}
+	if !node.IsList {
+		node.Map = make(map[string]*yamlNode)
+	}
+
+	for _, f := range yamlSplitFlow(s[1 : len(s)-1]) {
+		if node.IsList {
+			item, err := yamlValue(f, num)
+			if err != nil {
+				return nil, err
+			}
+			node.List = append(node.List, item)
+			continue
+		}
+
+		key, rest, ok := yamlCutKey(f)
+		if !ok {
+			return nil, fmt.Errorf("yaml: line %d: want key: value, have %q", num, f)
+		}
+
+		value, err := yamlValue(rest, num)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := node.Map[key]; !ok {
+			node.Keys = append(node.Keys, key)
+		}
+		node.Map[key] = value
+	}
+
+	return node, nil
+}
+
+// yamlSplitFlow splits the contents of a flow sequence or mapping at
+// the commas which are neither quoted nor part of a nested collection.
+func yamlSplitFlow(s string) []string {
+	var fields []string
+	var quote byte
+	depth, start := 0, 0
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			fields = append(fields, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(s[start:]); last != "" || len(fields) > 0 {
+		fields = append(fields, last)
+	}
+	return fields
+}
+
+// yamlScalar returns the value of a plain or quoted scalar.
+func yamlScalar(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, "\""):
+		return strconv.Unquote(s)
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return s, nil
+}
+
+// yamlQuote returns s as a double-quoted scalar.
+func yamlQuote(s string) string {
+	return strconv.Quote(s)
+}
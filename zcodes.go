@@ -0,0 +1,1931 @@
+// Code generated by gencodes from input-event-codes.h and input.h. DO NOT EDIT.
+
+package evdev
+
+// Event types
+const (
+	EvSync                = 0x00 // Synchronisation events.
+	EvKeys                = 0x01 // Absolute binary results, such as keys and buttons.
+	EvRelative            = 0x02 // Relative results, such as the axes on a mouse.
+	EvAbsolute            = 0x03 // Absolute integer results, such as the axes on a joystick or for a tablet.
+	EvMisc                = 0x04 // Miscellaneous uses that didn't fit anywhere else.
+	EvSwitch              = 0x05 // Used to describe stateful binary switches.
+	EvLed                 = 0x11 // LEDs and similar indications.
+	EvSound               = 0x12 // Sound output, such as buzzers.
+	EvRepeat              = 0x14 // Enables autorepeat of keys in the input core.
+	EvForceFeedback       = 0x15 // Sends force-feedback effects to a device.
+	EvPower               = 0x16 // Power management events.
+	EvForceFeedbackStatus = 0x17 // Device reporting of force-feedback effects back to the host.
+	EvMax                 = 0x1f
+	EvCount               = EvMax + 1
+)
+
+// Input device properties and quirks.
+//
+// Normally, userspace sets up an input device based on the data it emits,
+// i.e., the event types. In the case of two devices emitting the same event
+// types, additional information can be provided in the form of device
+// properties.
+const (
+
+	// The InputPropPointer property indicates that the device is not transposed
+	// on the screen and thus requires use of an on-screen pointer to trace user's
+	// movements. Typical pointer devices: touchpads, tablets, mice; non-pointer
+	// device: touchscreen.
+	//
+	// If neither InputPropDirect or InputPropPointer are set, the property is
+	// considered undefined and the device type should be deduced in the
+	// traditional way, using emitted event types.
+	InputPropPointer = 0x00 // needs a pointer
+
+	// The InputPropDirect property indicates that device coordinates should be
+	// directly mapped to screen coordinates (not taking into account trivial
+	// transformations, such as scaling, flipping and rotating). Non-direct input
+	// devices require non-trivial transformation, such as absolute to relative
+	// transformation for touchpads. Typical direct input devices: touchscreens,
+	// drawing tablets; non-direct devices: touchpads, mice.
+	//
+	// If neither InputPropDirect or InputPropPointer are set, the property is
+	// considered undefined and the device type should be deduced in the
+	// traditional way, using emitted event types.
+	InputPropDirect = 0x01 // direct input devices
+
+	// For touchpads where the button is placed beneath the surface, such that
+	// pressing down on the pad causes a button click, this property should be
+	// set. Common in clickpad notebooks and macbooks from 2009 and onwards.
+	//
+	// Originally, the buttonpad property was coded into the bcm5974 driver
+	// version field under the name integrated button. For backwards
+	// compatibility, both methods need to be checked in userspace.
+	InputPropButtonPad = 0x02 // has button(s) under pad
+
+	// Some touchpads, most common between 2008 and 2011, can detect the presence
+	// of multiple contacts without resolving the individual positions; only the
+	// number of contacts and a rectangular shape is known. For such
+	// touchpads, the semi-mt property should be set.
+	//
+	// Depending on the device, the rectangle may enclose all touches, like a
+	// bounding box, or just some of them, for instance the two most recent
+	// touches. The diversity makes the rectangle of limited use, but some
+	// gestures can normally be extracted from it.
+	//
+	// If InputPropSemiMT is not set, the device is assumed to be a true
+	// multi-touch device.
+	InputPropSemiMT        = 0x03 // touch rectangle only
+	InputPropTopButtonPad  = 0x04 // softbuttons at top of pad
+	InputPropPointingStick = 0x05 // is a pointing stick
+	InputPropAccelerometer = 0x06 // has accelerometer
+	InputPropMax           = 0x1f
+	InputPropCount         = InputPropMax + 1
+)
+
+// Synchronization event values are undefined.
+// Their usage is defined only by when they are
+// sent in the evdev event stream.
+//
+// SynReport is used to synchronize and separate
+// events into packets of input data changes occurring
+// at the same moment in time. For example, motion
+// of a mouse may set the RelX and RelY values for
+// one motion, then emit a SynReport. The next motion
+// will emit more RelX and RelY values and send
+// another SynReport.
+//
+// SynConfig: to be determined.
+//
+// SynMTReport is used to synchronize and separate
+// touch events. See the multi-touch-protocol.txt
+// document for more information.
+//
+// SynDropped is used to indicate buffer overrun
+// in the evdev client's event queue.
+// Client should ignore all events up to and
+// including next SynReport event and query the
+// device (using EVIOCG* ioctls) to obtain its current state.
+const (
+	SynReport   = 0
+	SynConfig   = 1
+	SynMTReport = 2
+	SynDropped  = 3
+	SynMax      = 0xf
+	SynCount    = SynMax + 1
+)
+
+// Keys and buttons
+//
+// Events take the form Key<name> or Btn<name>. For example, KeyA is used
+// to represent the 'A' key on a keyboard. When a key is depressed, an event with
+// the key's code is emitted with value 1. When the key is released, an event is
+// emitted with value 0. Some hardware send events when a key is repeated. These
+// events have a value of 2. In general, Key<name> is used for keyboard keys, and
+// Btn<name> is used for other types of momentary switch events.
+//
+// A few codes have special meanings:
+//
+//   - BtnTool<name>:
+//
+//   - These codes are used in conjunction with input trackpads, tablets, and
+//     touchscreens. These devices may be used with fingers, pens, or other tools.
+//     When an event occurs and a tool is used, the corresponding BtnTool<name>
+//     code should be set to a value of 1. When the tool is no longer interacting
+//     with the input device, the BtnTool<name> code should be reset to 0. All
+//     trackpads, tablets, and touchscreens should use at least one BtnTool<name>
+//     code when events are generated.
+//
+//   - BtnTouch:
+//     BtnTouch is used for touch contact. While an input tool is determined to be
+//     within meaningful physical contact, the value of this property must be set
+//     to 1. Meaningful physical contact may mean any contact, or it may mean
+//     contact conditioned by an implementation defined property. For example, a
+//     touchpad may set the value to 1 only when the touch pressure rises above a
+//     certain value. BtnTouch may be combined with BtnTool<name> codes. For
+//     example, a pen tablet may set BtnToolPen to 1 and BtnTouch to 0 while the
+//     pen is hovering over but not touching the tablet surface.
+//
+//   - BtnToolFinger, BtnToolDoubleTap, BtnToolTripleTap, BtnToolQuadTap:
+//
+//   - These codes denote one, two, three, and four finger interaction on a
+//     trackpad or touchscreen. For example, if the user uses two fingers and moves
+//     them on the touchpad in an effort to scroll content on screen,
+//     BtnToolDoubleTap should be set to value 1 for the duration of the motion.
+//     Note that all BtnTool<name> codes and the BtnTouch code are orthogonal in
+//     purpose. A trackpad event generated by finger touches should generate events
+//     for one code from each group. At most only one of these BtnTool<name>
+//     codes should have a value of 1 during any synchronization frame.
+const (
+	KeyReserved         = 0
+	KeyEscape           = 1
+	Key1                = 2
+	Key2                = 3
+	Key3                = 4
+	Key4                = 5
+	Key5                = 6
+	Key6                = 7
+	Key7                = 8
+	Key8                = 9
+	Key9                = 10
+	Key0                = 11
+	KeyMinus            = 12
+	KeyEqual            = 13
+	KeyBackSpace        = 14
+	KeyTab              = 15
+	KeyQ                = 16
+	KeyW                = 17
+	KeyE                = 18
+	KeyR                = 19
+	KeyT                = 20
+	KeyY                = 21
+	KeyU                = 22
+	KeyI                = 23
+	KeyO                = 24
+	KeyP                = 25
+	KeyLeftBrace        = 26
+	KeyRightBrace       = 27
+	KeyEnter            = 28
+	KeyLeftCtrl         = 29
+	KeyA                = 30
+	KeyS                = 31
+	KeyD                = 32
+	KeyF                = 33
+	KeyG                = 34
+	KeyH                = 35
+	KeyJ                = 36
+	KeyK                = 37
+	KeyL                = 38
+	KeySemiColon        = 39
+	KeyApostrophe       = 40
+	KeyGrave            = 41
+	KeyLeftShift        = 42
+	KeyBackSlash        = 43
+	KeyZ                = 44
+	KeyX                = 45
+	KeyC                = 46
+	KeyV                = 47
+	KeyB                = 48
+	KeyN                = 49
+	KeyM                = 50
+	KeyComma            = 51
+	KeyDot              = 52
+	KeySlash            = 53
+	KeyRightShift       = 54
+	KeyKPAsterisk       = 55
+	KeyLeftAlt          = 56
+	KeySpace            = 57
+	KeyCapsLock         = 58
+	KeyF1               = 59
+	KeyF2               = 60
+	KeyF3               = 61
+	KeyF4               = 62
+	KeyF5               = 63
+	KeyF6               = 64
+	KeyF7               = 65
+	KeyF8               = 66
+	KeyF9               = 67
+	KeyF10              = 68
+	KeyNumLock          = 69
+	KeyScrollLock       = 70
+	KeyKP7              = 71
+	KeyKP8              = 72
+	KeyKP9              = 73
+	KeyKPMinus          = 74
+	KeyKP4              = 75
+	KeyKP5              = 76
+	KeyKP6              = 77
+	KeyKPPlus           = 78
+	KeyKP1              = 79
+	KeyKP2              = 80
+	KeyKP3              = 81
+	KeyKP0              = 82
+	KeyKPDot            = 83
+	KeyZenkakuhankaku   = 85
+	Key102ND            = 86
+	KeyF11              = 87
+	KeyF12              = 88
+	KeyRO               = 89
+	KeyKatakana         = 90
+	KeyHiragana         = 91
+	KeyHenkan           = 92
+	KeyKatakanaHiragana = 93
+	KeyMuhenkan         = 94
+	KeyKPJPComma        = 95
+	KeyKPEnter          = 96
+	KeyRightCtrl        = 97
+	KeyKPSlash          = 98
+	KeySysRQ            = 99
+	KeyRightAlt         = 100
+	KeyLineFeed         = 101
+	KeyHome             = 102
+	KeyUp               = 103
+	KeyPageUp           = 104
+	KeyLeft             = 105
+	KeyRight            = 106
+	KeyEnd              = 107
+	KeyDown             = 108
+	KeyPageDown         = 109
+	KeyInsert           = 110
+	KeyDelete           = 111
+	KeyMacro            = 112
+	KeyMute             = 113
+	KeyVolumeDown       = 114
+	KeyVolumeUp         = 115
+	KeyPower            = 116 // SC System Power Down
+	KeyKPEqual          = 117
+	KeyKPPlusMinus      = 118
+	KeyPause            = 119
+	KeyScale            = 120 // AL Compiz Scale (Expose)
+	KeyKPComma          = 121
+	KeyHangeul          = 122
+	KeyHanguel          = KeyHangeul
+	KeyHanja            = 123
+	KeyYen              = 124
+	KeyLeftMeta         = 125
+	KeyRightMeta        = 126
+	KeyCompose          = 127
+	KeyStop             = 128 // AC Stop
+	KeyAgain            = 129
+	KeyProps            = 130 // AC Properties
+	KeyUndo             = 131 // AC Undo
+	KeyFront            = 132
+	KeyCopy             = 133 // AC Copy
+	KeyOpen             = 134 // AC Open
+	KeyPaste            = 135 // AC Paste
+	KeyFind             = 136 // AC Search
+	KeyCut              = 137 // AC Cut
+	KeyHelp             = 138 // AL Integrated Help Center
+	KeyMenu             = 139 // Menu (show menu)
+	KeyCalc             = 140 // AL Calculator
+	KeySetup            = 141
+	KeySleep            = 142 // SC System Sleep
+	KeyWakeup           = 143 // System Wake Up
+	KeyFile             = 144 // AL Local Machine Browser
+	KeySendFile         = 145
+	KeyDeleteFile       = 146
+	KeyXFer             = 147
+	KeyProg1            = 148
+	KeyProg2            = 149
+	KeyWWW              = 150 // AL Internet Browser
+	KeyMSDOS            = 151
+	KeyCoffee           = 152 // AL Terminal Lock/Screensaver
+	KeyScreenlock       = KeyCoffee
+	KeyRotateDisplay    = 153 // Display orientation for e.g. tablets
+	KeyDirection        = KeyRotateDisplay
+	KeyCycleWindows     = 154
+	KeyMail             = 155
+	KeyBookmarks        = 156 // AC Bookmarks
+	KeyComputer         = 157
+	KeyBack             = 158 // AC Back
+	KeyForward          = 159 // AC Forward
+	KeyCloseCD          = 160
+	KeyEjectCD          = 161
+	KeyEjectCloseCD     = 162
+	KeyNextSong         = 163
+	KeyPlayPause        = 164
+	KeyPreviousSong     = 165
+	KeyStopCD           = 166
+	KeyRecord           = 167
+	KeyRewind           = 168
+	KeyPhone            = 169 // Media Select Telephone
+	KeyISO              = 170
+	KeyConfig           = 171 // AL Consumer Control Configuration
+	KeyHomepage         = 172 // AC Home
+	KeyRefresh          = 173 // AC Refresh
+	KeyExit             = 174 // AC Exit
+	KeyMove             = 175
+	KeyEdit             = 176
+	KeyScrollUp         = 177
+	KeyScrollDown       = 178
+	KeyKPLeftParen      = 179
+	KeyKPRightParen     = 180
+	KeyNew              = 181 // AC New
+	KeyRedo             = 182 // AC Redo/Repeat
+	KeyF13              = 183
+	KeyF14              = 184
+	KeyF15              = 185
+	KeyF16              = 186
+	KeyF17              = 187
+	KeyF18              = 188
+	KeyF19              = 189
+	KeyF20              = 190
+	KeyF21              = 191
+	KeyF22              = 192
+	KeyF23              = 193
+	KeyF24              = 194
+	KeyPlayCD           = 200
+	KeyPauseCD          = 201
+	KeyProg3            = 202
+	KeyProg4            = 203
+	KeyAllApplications  = 204 // AC Desktop Show All Applications
+	KeyDashboard        = KeyAllApplications
+	KeySuspend          = 205
+	KeyClose            = 206 // AC Close
+	KeyPlay             = 207
+	KeyFastForward      = 208
+	KeyBassBoost        = 209
+	KeyPrint            = 210 // AC Print
+	KeyHP               = 211
+	KeyCamera           = 212
+
+	// Deprecated: Use KeyCamera instead.
+	KeyCanera = KeyCamera
+
+	KeySound           = 213
+	KeyQuestion        = 214
+	KeyEmail           = 215
+	KeyChat            = 216
+	KeySearch          = 217
+	KeyConnect         = 218
+	KeyFinance         = 219 // AL Checkbook/Finance
+	KeySport           = 220
+	KeyShop            = 221
+	KeyAltErase        = 222
+	KeyCancel          = 223 // AC Cancel
+	KeyBrightnessDown  = 224
+	KeyBrightnessUp    = 225
+	KeyMedia           = 226
+	KeySwitchVideoMode = 227 // Cycle between available video outputs (Monitor/LCD/TV-out/etc)
+	KeyKBDIllumToggle  = 228
+	KeyKBDIllumDown    = 229
+	KeyKBDIllumUp      = 230
+	KeySend            = 231 // AC Send
+	KeyReply           = 232 // AC Reply
+	KeyForwardMail     = 233 // AC Forward Msg
+	KeySave            = 234 // AC Save
+	KeyDocuments       = 235
+	KeyBattery         = 236
+	KeyBluetooth       = 237
+	KeyWLAN            = 238
+	KeyUWB             = 239
+	KeyUnknown         = 240
+	KeyVideoNext       = 241 // drive next video source
+	KeyVideoPrevious   = 242 // drive previous video source
+	KeyBrightnessCycle = 243 // brightness up, after max is min
+	KeyBrightnessAuto  = 244 // Set Auto Brightness: manual brightness control is off, rely on ambient
+	KeyBrightnessZero  = KeyBrightnessAuto
+	KeyDisplayOff      = 245 // display device to off state
+	KeyWWAN            = 246 // Wireless WAN (LTE, UMTS, GSM, etc.)
+	KeyWIMax           = KeyWWAN
+	KeyRFKill          = 247 // Key that controls all radios
+	KeyMicMute         = 248 // Mute / unmute the microphone
+	BtnMisc            = 0x100
+	Btn0               = 0x100
+	Btn1               = 0x101
+	Btn2               = 0x102
+	Btn3               = 0x103
+	Btn4               = 0x104
+	Btn5               = 0x105
+	Btn6               = 0x106
+	Btn7               = 0x107
+	Btn8               = 0x108
+	Btn9               = 0x109
+	BtnMouse           = 0x110
+	BtnLeft            = 0x110
+	BtnRight           = 0x111
+	BtnMiddle          = 0x112
+	BtnSide            = 0x113
+	BtnExtra           = 0x114
+	BtnForward         = 0x115
+	BtnBack            = 0x116
+	BtnTask            = 0x117
+	BtnJoystick        = 0x120
+	BtnTrigger         = 0x120
+	BtnThumb           = 0x121
+	BtnThumb2          = 0x122
+	BtnTop             = 0x123
+	BtnTop2            = 0x124
+	BtnPinkie          = 0x125
+	BtnBase            = 0x126
+	BtnBase2           = 0x127
+	BtnBase3           = 0x128
+	BtnBase4           = 0x129
+	BtnBase5           = 0x12a
+	BtnBase6           = 0x12b
+	BtnDead            = 0x12f
+	BtnGamepad         = 0x130
+	BtnSouth           = 0x130
+	BtnA               = BtnSouth
+	BtnEast            = 0x131
+	BtnB               = BtnEast
+	BtnC               = 0x132
+	BtnNorth           = 0x133
+	BtnX               = BtnNorth
+	BtnWest            = 0x134
+	BtnY               = BtnWest
+	BtnZ               = 0x135
+	BtnTL              = 0x136
+	BtnTR              = 0x137
+	BtnTL2             = 0x138
+	BtnTR2             = 0x139
+	BtnSelect          = 0x13a
+	BtnStart           = 0x13b
+	BtnMode            = 0x13c
+	BtnThumbL          = 0x13d
+	BtnThumbR          = 0x13e
+	BtnDigi            = 0x140
+	BtnToolPen         = 0x140
+	BtnToolRubber      = 0x141
+
+	// Deprecated: Use BtnToolRubber instead.
+	BtnTooLRubber = BtnToolRubber
+
+	BtnToolBrush     = 0x142
+	BtnToolPencil    = 0x143
+	BtnToolAirbrush  = 0x144
+	BtnToolFinger    = 0x145
+	BtnToolMouse     = 0x146
+	BtnToolLens      = 0x147
+	BtnToolQuintTap  = 0x148 // Five fingers on trackpad
+	BtnStylus3       = 0x149
+	BtnTouch         = 0x14a
+	BtnStylus        = 0x14b
+	BtnStylus2       = 0x14c
+	BtnToolDoubleTap = 0x14d
+	BtnToolTripleTap = 0x14e
+
+	// Deprecated: Use BtnToolTripleTap instead.
+	BtnToolTrippleTap = BtnToolTripleTap
+
+	BtnToolQuadTap = 0x14f // Four fingers on trackpad
+	BtnWheel       = 0x150
+	BtnGearDown    = 0x150
+	BtnGearUp      = 0x151
+	KeyOk          = 0x160
+	KeySelect      = 0x161
+	KeyGoto        = 0x162
+	KeyClear       = 0x163
+	KeyPower2      = 0x164
+	KeyOption      = 0x165
+	KeyInfo        = 0x166 // AL OEM Features/Tips/Tutorial
+	KeyTime        = 0x167
+	KeyVendor      = 0x168
+	KeyArchive     = 0x169
+	KeyProgram     = 0x16a // Media Select Program Guide
+	KeyChannel     = 0x16b
+	KeyFavorites   = 0x16c
+	KeyEPG         = 0x16d
+	KeyPVR         = 0x16e // Media Select Home
+	KeyMHP         = 0x16f
+	KeyLanguage    = 0x170
+	KeyTitle       = 0x171
+	KeySubtitle    = 0x172
+	KeyAngle       = 0x173
+	KeyFullScreen  = 0x174 // AC View Toggle
+	KeyZoom        = KeyFullScreen
+	KeyMode        = 0x175
+	KeyKeyboard    = 0x176
+	KeyAspectRatio = 0x177 // HUTRR37: Aspect
+	KeyScreen      = KeyAspectRatio
+	KeyPC          = 0x178 // Media Select Computer
+	KeyTV          = 0x179 // Media Select TV
+	KeyTV2         = 0x17a // Media Select Cable
+	KeyVCR         = 0x17b // Media Select VCR
+	KeyVCR2        = 0x17c // VCR Plus
+	KeySAT         = 0x17d // Media Select Satellite
+	KeySAT2        = 0x17e
+	KeyCD          = 0x17f // Media Select CD
+	KeyTape        = 0x180 // Media Select Tape
+	KeyRadio       = 0x181
+	KeyTuner       = 0x182 // Media Select Tuner
+	KeyPlayer      = 0x183
+	KeyText        = 0x184
+	KeyDVD         = 0x185 // Media Select DVD
+	KeyAUX         = 0x186
+	KeyMP3         = 0x187
+	KeyAudio       = 0x188 // AL Audio Browser
+	KeyVideo       = 0x189 // AL Movie Browser
+	KeyDirectory   = 0x18a
+	KeyList        = 0x18b
+	KeyMemo        = 0x18c // Media Select Messages
+	KeyCalendar    = 0x18d
+
+	// Deprecated: Use KeyCalendar instead.
+	KeyCalender = KeyCalendar
+
+	KeyRed              = 0x18e
+	KeyGreen            = 0x18f
+	KeyYellow           = 0x190
+	KeyBlue             = 0x191
+	KeyChannelUp        = 0x192 // Channel Increment
+	KeyChannelDown      = 0x193 // Channel Decrement
+	KeyFirst            = 0x194
+	KeyLast             = 0x195 // Recall Last
+	KeyAB               = 0x196
+	KeyNext             = 0x197
+	KeyRestart          = 0x198
+	KeySlow             = 0x199
+	KeyShuffle          = 0x19a
+	KeyBreak            = 0x19b
+	KeyPrevious         = 0x19c
+	KeyDigits           = 0x19d
+	KeyTeen             = 0x19e
+	KeyTwen             = 0x19f
+	KeyVideoPhone       = 0x1a0 // Media Select Video Phone
+	KeyGames            = 0x1a1 // Media Select Games
+	KeyZoomIn           = 0x1a2 // AC Zoom In
+	KeyZoomOut          = 0x1a3 // AC Zoom Out
+	KeyZoomReset        = 0x1a4 // AC Zoom
+	KeyWordProcessor    = 0x1a5 // AL Word Processor
+	KeyEditor           = 0x1a6 // AL Text Editor
+	KeySpreadsheet      = 0x1a7 // AL Spreadsheet
+	KeyGraphicsEditor   = 0x1a8 // AL Graphics Editor
+	KeyPresentation     = 0x1a9 // AL Presentation App
+	KeyDatabase         = 0x1aa // AL Database App
+	KeyNews             = 0x1ab // AL Newsreader
+	KeyVoiceMail        = 0x1ac // AL Voicemail
+	KeyAddressBook      = 0x1ad // AL Contacts/Address Book
+	KeyMessenger        = 0x1ae // AL Instant Messaging
+	KeyDisplayToggle    = 0x1af // Turn display (LCD) on and off
+	KeyBrightnessToggle = KeyDisplayToggle
+	KeySpellCheck       = 0x1b0 // AL Spell Check
+	KeyLogoff           = 0x1b1 // AL Logoff
+	KeyDollar           = 0x1b2
+	KeyEuro             = 0x1b3
+	KeyFrameBack        = 0x1b4 // Consumer - transport controls
+	KeyFrameForward     = 0x1b5
+
+	// Deprecated: Use KeyFrameForward instead.
+	KeyframeForward = KeyFrameForward
+
+	KeyContextMenu             = 0x1b6 // GenDesc - system context menu
+	KeyMediaRepeat             = 0x1b7 // Consumer - transport control
+	Key10ChannelsUp            = 0x1b8 // 10 channels up (10+)
+	Key10ChannelsDown          = 0x1b9 // 10 channels down (10-)
+	KeyImages                  = 0x1ba // AL Image Browser
+	KeyNotificationCenter      = 0x1bc // Show/hide the notification center
+	KeyPickupPhone             = 0x1bd // Answer incoming call
+	KeyHangupPhone             = 0x1be // Decline incoming call
+	KeyLinkPhone               = 0x1bf // AL Phone Syncing
+	KeyDelEOL                  = 0x1c0
+	KeyDelEOS                  = 0x1c1
+	KeyInsLine                 = 0x1c2
+	KeyDelLine                 = 0x1c3
+	KeyFN                      = 0x1d0
+	KeyFNEsc                   = 0x1d1
+	KeyFNF1                    = 0x1d2
+	KeyFNF2                    = 0x1d3
+	KeyFNF3                    = 0x1d4
+	KeyFNF4                    = 0x1d5
+	KeyFNF5                    = 0x1d6
+	KeyFNF6                    = 0x1d7
+	KeyFNF7                    = 0x1d8
+	KeyFNF8                    = 0x1d9
+	KeyFNF9                    = 0x1da
+	KeyFNF10                   = 0x1db
+	KeyFNF11                   = 0x1dc
+	KeyFNF12                   = 0x1dd
+	KeyFN1                     = 0x1de
+	KeyFN2                     = 0x1df
+	KeyFND                     = 0x1e0
+	KeyFNE                     = 0x1e1
+	KeyFNF                     = 0x1e2
+	KeyFNS                     = 0x1e3
+	KeyFNB                     = 0x1e4
+	KeyFNRightShift            = 0x1e5
+	KeyBRLDot1                 = 0x1f1
+	KeyBRLDot2                 = 0x1f2
+	KeyBRLDot3                 = 0x1f3
+	KeyBRLDot4                 = 0x1f4
+	KeyBRLDot5                 = 0x1f5
+	KeyBRLDot6                 = 0x1f6
+	KeyBRLDot7                 = 0x1f7
+	KeyBRLDot8                 = 0x1f8
+	KeyBRLDot9                 = 0x1f9
+	KeyBRLDot10                = 0x1fa
+	KeyNumeric0                = 0x200 // used by phones, remote controls,
+	KeyNumeric1                = 0x201 // and other keypads
+	KeyNumeric2                = 0x202
+	KeyNumeric3                = 0x203
+	KeyNumeric4                = 0x204
+	KeyNumeric5                = 0x205
+	KeyNumeric6                = 0x206
+	KeyNumeric7                = 0x207
+	KeyNumeric8                = 0x208
+	KeyNumeric9                = 0x209
+	KeyNumericStar             = 0x20a
+	KeyNumericPound            = 0x20b
+	KeyNumericA                = 0x20c // Phone key A - HUT Telephony 0xb9
+	KeyNumericB                = 0x20d
+	KeyNumericC                = 0x20e
+	KeyNumericD                = 0x20f
+	KeyCameraFocus             = 0x210
+	KeyWPSButton               = 0x211 // WiFi Protected Setup key
+	KeyTouchpadToggle          = 0x212 // Request switch touchpad on or off
+	KeyTouchpadOn              = 0x213
+	KeyTouchpadOff             = 0x214
+	KeyCameraZoomIn            = 0x215
+	KeyCameraZoomOut           = 0x216
+	KeyCameraUp                = 0x217
+	KeyCameraDown              = 0x218
+	KeyCameraLeft              = 0x219
+	KeyCameraRight             = 0x21a
+	KeyAttendantOn             = 0x21b
+	KeyAttendantOff            = 0x21c
+	KeyAttendantToggle         = 0x21d // Attendant call on or off
+	KeyLightsToggle            = 0x21e // Reading light on or off
+	BtnDpadUp                  = 0x220
+	BtnDpadDown                = 0x221
+	BtnDpadLeft                = 0x222
+	BtnDpadRight               = 0x223
+	KeyALSToggle               = 0x230 // Ambient light sensor
+	KeyRotateLockToggle        = 0x231 // Display rotation lock
+	KeyRefreshRateToggle       = 0x232 // Display refresh rate toggle
+	KeyButtonConfig            = 0x240 // AL Button Configuration
+	KeyTaskManager             = 0x241 // AL Task/Project Manager
+	KeyJournal                 = 0x242 // AL Log/Journal/Timecard
+	KeyControlPanel            = 0x243 // AL Control Panel
+	KeyAppSelect               = 0x244 // AL Select Task/Application
+	KeyScreenSaver             = 0x245 // AL Screen Saver
+	KeyVoiceCommand            = 0x246 // Listening Voice Command
+	KeyAssistant               = 0x247 // AL Context-aware desktop assistant
+	KeyKBDLayoutNext           = 0x248 // AC Next Keyboard Layout Select
+	KeyEmojiPicker             = 0x249 // Show/hide emoji picker (HUTRR101)
+	KeyDictate                 = 0x24a // Start or Stop Voice Dictation Session (HUTRR99)
+	KeyBrightnessMin           = 0x250 // Set Brightness to Minimum
+	KeyBrightnessMax           = 0x251 // Set Brightness to Maximum
+	KeyKBDInputAssistPrev      = 0x260
+	KeyKBDInputAssistNext      = 0x261
+	KeyKBDInputAssistPrevGroup = 0x262
+	KeyKBDInputAssistNextGroup = 0x263
+	KeyKBDInputAssistAccept    = 0x264
+	KeyKBDInputAssistCancel    = 0x265
+	KeyRightUp                 = 0x266
+	KeyRightDown               = 0x267
+	KeyLeftUp                  = 0x268
+	KeyLeftDown                = 0x269
+	KeyRootMenu                = 0x26a // Show Device's Root Menu
+	KeyMediaTopMenu            = 0x26b
+	KeyNumeric11               = 0x26c
+	KeyNumeric12               = 0x26d
+	KeyAudioDesc               = 0x26e
+	Key3DMode                  = 0x26f
+	KeyNextFavorite            = 0x270
+	KeyStopRecord              = 0x271
+	KeyPauseRecord             = 0x272
+	KeyVOD                     = 0x273 // Video on Demand
+	KeyUnmute                  = 0x274
+	KeyFastReverse             = 0x275
+	KeySlowReverse             = 0x276
+	KeyData                    = 0x277
+	KeyOnScreenKeyboard        = 0x278
+	KeyPrivacyScreenToggle     = 0x279
+	KeySelectiveScreenshot     = 0x27a
+	KeyNextElement             = 0x27b
+	KeyPreviousElement         = 0x27c
+	KeyAutopilotEngageToggle   = 0x27d
+	KeyMarkWaypoint            = 0x27e
+	KeySOS                     = 0x27f
+	KeyNavChart                = 0x280
+	KeyFishingChart            = 0x281
+	KeySingleRangeRadar        = 0x282
+	KeyDualRangeRadar          = 0x283
+	KeyRadarOverlay            = 0x284
+	KeyTraditionalSonar        = 0x285
+	KeyClearVuSonar            = 0x286
+	KeySideVuSonar             = 0x287
+	KeyNavInfo                 = 0x288
+	KeyBrightnessMenu          = 0x289
+	KeyMacro1                  = 0x290
+	KeyMacro2                  = 0x291
+	KeyMacro3                  = 0x292
+	KeyMacro4                  = 0x293
+	KeyMacro5                  = 0x294
+	KeyMacro6                  = 0x295
+	KeyMacro7                  = 0x296
+	KeyMacro8                  = 0x297
+	KeyMacro9                  = 0x298
+	KeyMacro10                 = 0x299
+	KeyMacro11                 = 0x29a
+	KeyMacro12                 = 0x29b
+	KeyMacro13                 = 0x29c
+	KeyMacro14                 = 0x29d
+	KeyMacro15                 = 0x29e
+	KeyMacro16                 = 0x29f
+	KeyMacro17                 = 0x2a0
+	KeyMacro18                 = 0x2a1
+	KeyMacro19                 = 0x2a2
+	KeyMacro20                 = 0x2a3
+	KeyMacro21                 = 0x2a4
+	KeyMacro22                 = 0x2a5
+	KeyMacro23                 = 0x2a6
+	KeyMacro24                 = 0x2a7
+	KeyMacro25                 = 0x2a8
+	KeyMacro26                 = 0x2a9
+	KeyMacro27                 = 0x2aa
+	KeyMacro28                 = 0x2ab
+	KeyMacro29                 = 0x2ac
+	KeyMacro30                 = 0x2ad
+	KeyMacroRecordStart        = 0x2b0
+	KeyMacroRecordStop         = 0x2b1
+	KeyMacroPresetCycle        = 0x2b2
+	KeyMacroPreset1            = 0x2b3
+	KeyMacroPreset2            = 0x2b4
+	KeyMacroPreset3            = 0x2b5
+	KeyKBDLCDMenu1             = 0x2b8
+	KeyKBDLCDMenu2             = 0x2b9
+	KeyKBDLCDMenu3             = 0x2ba
+	KeyKBDLCDMenu4             = 0x2bb
+	KeyKBDLCDMenu5             = 0x2bc
+	BtnTriggerHappy            = 0x2c0
+	BtnTriggerHappy1           = 0x2c0
+	BtnTriggerHappy2           = 0x2c1
+	BtnTriggerHappy3           = 0x2c2
+	BtnTriggerHappy4           = 0x2c3
+	BtnTriggerHappy5           = 0x2c4
+	BtnTriggerHappy6           = 0x2c5
+	BtnTriggerHappy7           = 0x2c6
+	BtnTriggerHappy8           = 0x2c7
+	BtnTriggerHappy9           = 0x2c8
+	BtnTriggerHappy10          = 0x2c9
+	BtnTriggerHappy11          = 0x2ca
+	BtnTriggerHappy12          = 0x2cb
+	BtnTriggerHappy13          = 0x2cc
+	BtnTriggerHappy14          = 0x2cd
+	BtnTriggerHappy15          = 0x2ce
+	BtnTriggerHappy16          = 0x2cf
+	BtnTriggerHappy17          = 0x2d0
+	BtnTriggerHappy18          = 0x2d1
+	BtnTriggerHappy19          = 0x2d2
+	BtnTriggerHappy20          = 0x2d3
+	BtnTriggerHappy21          = 0x2d4
+	BtnTriggerHappy22          = 0x2d5
+	BtnTriggerHappy23          = 0x2d6
+	BtnTriggerHappy24          = 0x2d7
+	BtnTriggerHappy25          = 0x2d8
+	BtnTriggerHappy26          = 0x2d9
+	BtnTriggerHappy27          = 0x2da
+	BtnTriggerHappy28          = 0x2db
+	BtnTriggerHappy29          = 0x2dc
+	BtnTriggerHappy30          = 0x2dd
+	BtnTriggerHappy31          = 0x2de
+	BtnTriggerHappy32          = 0x2df
+	BtnTriggerHappy33          = 0x2e0
+	BtnTriggerHappy34          = 0x2e1
+	BtnTriggerHappy35          = 0x2e2
+	BtnTriggerHappy36          = 0x2e3
+	BtnTriggerHappy37          = 0x2e4
+	BtnTriggerHappy38          = 0x2e5
+	BtnTriggerHappy39          = 0x2e6
+	BtnTriggerHappy40          = 0x2e7
+	KeyMinInteresting          = KeyMute
+	KeyMax                     = 0x2ff
+	KeyCount                   = KeyMax + 1
+)
+
+// Relative events describe relative changes in a property.
+// For example, a mouse may move to the left by a certain
+// number of units, but its absolute position in space is unknown.
+// If the absolute position is known, EvAbsolute codes should be used
+// instead of EvRelative codes.
+//
+// RelWheel and RelHWheel are used for vertical and horizontal scroll
+// wheels, respectively. RelWheelHiRes and RelHWheelHiRes report the
+// same motion in fractions of a detent, in units of 1/120th.
+const (
+	RelX           = 0x00
+	RelY           = 0x01
+	RelZ           = 0x02
+	RelRX          = 0x03
+	RelRY          = 0x04
+	RelRZ          = 0x05
+	RelHWheel      = 0x06
+	RelDial        = 0x07
+	RelWheel       = 0x08
+	RelMisc        = 0x09
+	RelReserved    = 0x0a
+	RelWheelHiRes  = 0x0b
+	RelHWheelHiRes = 0x0c
+	RelMax         = 0x0f
+	RelCount       = RelMax + 1
+)
+
+// Absolute events describe absolute changes in a property.
+// For example, a touchpad may emit coordinates for a touch location.
+// A few codes have special meanings:
+//
+// AbsDistance is used to describe the distance of a tool
+// from an interaction surface. This event should only be emitted
+// while the tool is hovering, meaning in close proximity to the
+// device and while the value of the BtnTouch code is 0.
+// If the input device may be used freely in three dimensions,
+// consider AbsZ instead.
+//
+// AbsMt<name> is used to describe multitouch input events.
+const (
+	AbsX             = 0x00
+	AbsY             = 0x01
+	AbsZ             = 0x02
+	AbsRX            = 0x03
+	AbsRY            = 0x04
+	AbsRZ            = 0x05
+	AbsThrottle      = 0x06
+	AbsRudder        = 0x07
+	AbsWheel         = 0x08
+	AbsGas           = 0x09
+	AbsBrake         = 0x0a
+	AbsHat0X         = 0x10
+	AbsHat0Y         = 0x11
+	AbsHat1X         = 0x12
+	AbsHat1Y         = 0x13
+	AbsHat2X         = 0x14
+	AbsHat2Y         = 0x15
+	AbsHat3X         = 0x16
+	AbsHat3Y         = 0x17
+	AbsPressure      = 0x18
+	AbsDistance      = 0x19
+	AbsTiltX         = 0x1a
+	AbsTiltY         = 0x1b
+	AbsToolWidth     = 0x1c
+	AbsVolume        = 0x20
+	AbsProfile       = 0x21
+	AbsMisc          = 0x28
+	AbsReserved      = 0x2e
+	AbsMTSlot        = 0x2f // MT slot being modified
+	AbsMTTouchMajor  = 0x30 // Major axis of touching ellipse
+	AbsMTTouchMinor  = 0x31 // Minor axis (omit if circular)
+	AbsMTWidthMajor  = 0x32 // Major axis of approaching ellipse
+	AbsMTWidthMinor  = 0x33 // Minor axis (omit if circular)
+	AbsMTOrientation = 0x34 // Ellipse orientation
+	AbsMTPositionX   = 0x35 // Center X touch position
+	AbsMTPositionY   = 0x36 // Center Y touch position
+	AbsMTToolType    = 0x37 // Type of touching device
+
+	// Deprecated: Use AbsMTToolType instead.
+	AbsMTToolTYPE = AbsMTToolType
+
+	AbsMTBlobId     = 0x38 // Group a set of packets as a blob
+	AbsMTTrackingId = 0x39 // Unique ID of initiated contact
+	AbsMTPressure   = 0x3a // Pressure on contact area
+	AbsMTDistance   = 0x3b // Contact hover distance
+	AbsMTToolX      = 0x3c // Center X tool position
+	AbsMTToolY      = 0x3d // Center Y tool position
+	AbsMax          = 0x3f
+	AbsCount        = AbsMax + 1
+)
+
+// Switch events describe stateful binary switches. For example,
+// the SwLid code is used to denote when a laptop lid is closed.
+//
+// Upon binding to a device or resuming from suspend, a driver must report
+// the current switch state. This ensures that the device, kernel, and userspace
+// state is in sync.
+//
+// Upon resume, if the switch state is the same as before suspend, then the input
+// subsystem will filter out the duplicate switch state reports. The driver does
+// not need to keep the state of the switch at any time.
+const (
+	SwLid                = 0x00        // set = lid shut
+	SwTabletMode         = 0x01        // set = tablet mode
+	SwHeadphoneInsert    = 0x02        // set = inserted
+	SwRFKillAll          = 0x03        // rfkill master switch, type "any" set = radio enabled
+	SwRadio              = SwRFKillAll // deprecated
+	SwMicrophoneInsert   = 0x04        // set = inserted
+	SwDock               = 0x05        // set = plugged into dock
+	SwLineoutInsert      = 0x06        // set = inserted
+	SwJackPhysicalInsert = 0x07        // set = mechanical switch set
+	SwVideoOutInsert     = 0x08        // set = inserted
+	SwCameraLensCover    = 0x09        // set = lens covered
+	SwKeypadSlide        = 0x0a        // set = keypad slide out
+	SwFrontProximity     = 0x0b        // set = front proximity sensor active
+	SwRotateLock         = 0x0c        // set = rotate locked/disabled
+	SwLineInInsert       = 0x0d        // set = inserted
+	SwMuteDevice         = 0x0e        // set = device disabled
+	SwPenInserted        = 0x0f        // set = pen inserted
+	SwMachineCover       = 0x10        // set = cover closed
+	SwMax                = 0x10
+	SwCount              = SwMax + 1
+)
+
+// Miscellaneous events are used for input and output events
+// that do not fall under other categories.
+//
+// MiscTimestamp has a special meaning.
+// It is used to report the number of microseconds since the last reset. This event
+// should be coded as an uint32 value, which is allowed to wrap around with
+// no special consequence. It is assumed that the time difference between two
+// consecutive events is reliable on a reasonable time scale (hours).
+// A reset to zero can happen, in which case the time since the last event is
+// unknown. If the device does not provide this information, the driver must
+// not provide it to user space.
+const (
+	MiscSerial    = 0x00
+	MiscPulseLed  = 0x01
+	MiscGesture   = 0x02
+	MiscRaw       = 0x03
+	MiscScan      = 0x04
+	MiscTimestamp = 0x05
+	MiscMax       = 0x07
+	MiscCount     = MiscMax + 1
+)
+
+// LED events are used for input and output to set and query the state of
+// various LEDs on devices.
+const (
+	LedNumLock    = 0x00
+	LedCapsLock   = 0x01
+	LedScrollLock = 0x02
+	LedCompose    = 0x03
+	LedKana       = 0x04
+	LedSleep      = 0x05
+	LedSuspend    = 0x06
+	LedMute       = 0x07
+	LedMisc       = 0x08
+	LedMail       = 0x09
+	LedCharging   = 0x0a
+	LedMax        = 0x0f
+	LedCount      = LedMax + 1
+)
+
+// Repeat events are used for specifying autorepeating events.
+const (
+	RepDelay  = 0x00
+	RepPeriod = 0x01
+	RepMax    = 0x01
+	RepCount  = RepMax + 1
+)
+
+// Sound events are used for sending sound
+// commands to simple sound output devices.
+const (
+	SndClick = 0x00
+	SndBell  = 0x01
+	SndTone  = 0x02
+	SndMax   = 0x07
+	SndCount = SndMax + 1
+)
+
+// Multitouch tools, as reported by AbsMTToolType.
+const (
+	MtToolFinger = 0x00
+	MtToolPen    = 0x01
+	MtToolPalm   = 0x02
+	MtToolDial   = 0x0a
+	MtToolMax    = 0x0f
+)
+
+// Values describing the status of a force-feedback effect
+const (
+	FFStatusStopped = 0x00
+	FFStatusPlaying = 0x01
+	FFStatusMax     = 0x01
+)
+
+// Force feedback effect types, periodic effect waveforms
+// and the force feedback device properties FFGain and FFAutoCenter.
+const (
+	FFRumble      = 0x50
+	FFPeriodic    = 0x51
+	FFConstant    = 0x52
+	FFSpring      = 0x53
+	FFFriction    = 0x54
+	FFDamper      = 0x55
+	FFInertia     = 0x56
+	FFRamp        = 0x57
+	FFEffectMin   = FFRumble
+	FFEffectMax   = FFRamp
+	FFSquare      = 0x58
+	FFTriangle    = 0x59
+	FFSine        = 0x5a
+	FFSawUp       = 0x5b
+	FFSawDown     = 0x5c
+	FFCustom      = 0x5d
+	FFWaveformMin = FFSquare
+	FFWaveformMax = FFCustom
+	FFGain        = 0x60
+	FFAutoCenter  = 0x61
+	FFMaxEffects  = FFGain
+	FFMax         = 0x7f
+	FFCount       = FFMax + 1
+)
+
+// Bus types, as found in Id.BusType.
+const (
+	BusPCI        = 0x01
+	BusISAPNP     = 0x02
+	BusUSB        = 0x03
+	BusHIL        = 0x04
+	BusBluetooth  = 0x05
+	BusVirtual    = 0x06
+	BusISA        = 0x10
+	BusI8042      = 0x11
+	BusXTKBD      = 0x12
+	BusRS232      = 0x13
+	BusGamePort   = 0x14
+	BusParPort    = 0x15
+	BusAmiga      = 0x16
+	BusADB        = 0x17
+	BusI2C        = 0x18
+	BusHost       = 0x19
+	BusGSC        = 0x1A
+	BusAtari      = 0x1B
+	BusSPI        = 0x1C
+	BusRMI        = 0x1D
+	BusCEC        = 0x1E
+	BusIntelISHTP = 0x1F
+	BusAMDSFH     = 0x20
+)
+
+// codeNames holds the names of the codes, by event type.
+var codeNames = map[int]map[int]string{
+	EvSync:                synNames,
+	EvKeys:                keyNames,
+	EvRelative:            relNames,
+	EvAbsolute:            absNames,
+	EvSwitch:              swNames,
+	EvMisc:                mscNames,
+	EvLed:                 ledNames,
+	EvRepeat:              repNames,
+	EvSound:               sndNames,
+	EvForceFeedbackStatus: ffStatusNames,
+	EvForceFeedback:       ffNames,
+}
+
+// typeNames holds the names of the EV_ constants.
+var typeNames = map[int]string{
+	EvSync:                "EV_SYN",
+	EvKeys:                "EV_KEY",
+	EvRelative:            "EV_REL",
+	EvAbsolute:            "EV_ABS",
+	EvMisc:                "EV_MSC",
+	EvSwitch:              "EV_SW",
+	EvLed:                 "EV_LED",
+	EvSound:               "EV_SND",
+	EvRepeat:              "EV_REP",
+	EvForceFeedback:       "EV_FF",
+	EvPower:               "EV_PWR",
+	EvForceFeedbackStatus: "EV_FF_STATUS",
+}
+
+// propNames holds the names of the INPUT_PROP_ constants.
+var propNames = map[int]string{
+	InputPropPointer:       "INPUT_PROP_POINTER",
+	InputPropDirect:        "INPUT_PROP_DIRECT",
+	InputPropButtonPad:     "INPUT_PROP_BUTTONPAD",
+	InputPropSemiMT:        "INPUT_PROP_SEMI_MT",
+	InputPropTopButtonPad:  "INPUT_PROP_TOPBUTTONPAD",
+	InputPropPointingStick: "INPUT_PROP_POINTING_STICK",
+	InputPropAccelerometer: "INPUT_PROP_ACCELEROMETER",
+}
+
+// synNames holds the names of the SYN_ constants.
+var synNames = map[int]string{
+	SynReport:   "SYN_REPORT",
+	SynConfig:   "SYN_CONFIG",
+	SynMTReport: "SYN_MT_REPORT",
+	SynDropped:  "SYN_DROPPED",
+}
+
+// keyNames holds the names of the BTN_ and KEY_ constants.
+var keyNames = map[int]string{
+	KeyReserved:                "KEY_RESERVED",
+	KeyEscape:                  "KEY_ESC",
+	Key1:                       "KEY_1",
+	Key2:                       "KEY_2",
+	Key3:                       "KEY_3",
+	Key4:                       "KEY_4",
+	Key5:                       "KEY_5",
+	Key6:                       "KEY_6",
+	Key7:                       "KEY_7",
+	Key8:                       "KEY_8",
+	Key9:                       "KEY_9",
+	Key0:                       "KEY_0",
+	KeyMinus:                   "KEY_MINUS",
+	KeyEqual:                   "KEY_EQUAL",
+	KeyBackSpace:               "KEY_BACKSPACE",
+	KeyTab:                     "KEY_TAB",
+	KeyQ:                       "KEY_Q",
+	KeyW:                       "KEY_W",
+	KeyE:                       "KEY_E",
+	KeyR:                       "KEY_R",
+	KeyT:                       "KEY_T",
+	KeyY:                       "KEY_Y",
+	KeyU:                       "KEY_U",
+	KeyI:                       "KEY_I",
+	KeyO:                       "KEY_O",
+	KeyP:                       "KEY_P",
+	KeyLeftBrace:               "KEY_LEFTBRACE",
+	KeyRightBrace:              "KEY_RIGHTBRACE",
+	KeyEnter:                   "KEY_ENTER",
+	KeyLeftCtrl:                "KEY_LEFTCTRL",
+	KeyA:                       "KEY_A",
+	KeyS:                       "KEY_S",
+	KeyD:                       "KEY_D",
+	KeyF:                       "KEY_F",
+	KeyG:                       "KEY_G",
+	KeyH:                       "KEY_H",
+	KeyJ:                       "KEY_J",
+	KeyK:                       "KEY_K",
+	KeyL:                       "KEY_L",
+	KeySemiColon:               "KEY_SEMICOLON",
+	KeyApostrophe:              "KEY_APOSTROPHE",
+	KeyGrave:                   "KEY_GRAVE",
+	KeyLeftShift:               "KEY_LEFTSHIFT",
+	KeyBackSlash:               "KEY_BACKSLASH",
+	KeyZ:                       "KEY_Z",
+	KeyX:                       "KEY_X",
+	KeyC:                       "KEY_C",
+	KeyV:                       "KEY_V",
+	KeyB:                       "KEY_B",
+	KeyN:                       "KEY_N",
+	KeyM:                       "KEY_M",
+	KeyComma:                   "KEY_COMMA",
+	KeyDot:                     "KEY_DOT",
+	KeySlash:                   "KEY_SLASH",
+	KeyRightShift:              "KEY_RIGHTSHIFT",
+	KeyKPAsterisk:              "KEY_KPASTERISK",
+	KeyLeftAlt:                 "KEY_LEFTALT",
+	KeySpace:                   "KEY_SPACE",
+	KeyCapsLock:                "KEY_CAPSLOCK",
+	KeyF1:                      "KEY_F1",
+	KeyF2:                      "KEY_F2",
+	KeyF3:                      "KEY_F3",
+	KeyF4:                      "KEY_F4",
+	KeyF5:                      "KEY_F5",
+	KeyF6:                      "KEY_F6",
+	KeyF7:                      "KEY_F7",
+	KeyF8:                      "KEY_F8",
+	KeyF9:                      "KEY_F9",
+	KeyF10:                     "KEY_F10",
+	KeyNumLock:                 "KEY_NUMLOCK",
+	KeyScrollLock:              "KEY_SCROLLLOCK",
+	KeyKP7:                     "KEY_KP7",
+	KeyKP8:                     "KEY_KP8",
+	KeyKP9:                     "KEY_KP9",
+	KeyKPMinus:                 "KEY_KPMINUS",
+	KeyKP4:                     "KEY_KP4",
+	KeyKP5:                     "KEY_KP5",
+	KeyKP6:                     "KEY_KP6",
+	KeyKPPlus:                  "KEY_KPPLUS",
+	KeyKP1:                     "KEY_KP1",
+	KeyKP2:                     "KEY_KP2",
+	KeyKP3:                     "KEY_KP3",
+	KeyKP0:                     "KEY_KP0",
+	KeyKPDot:                   "KEY_KPDOT",
+	KeyZenkakuhankaku:          "KEY_ZENKAKUHANKAKU",
+	Key102ND:                   "KEY_102ND",
+	KeyF11:                     "KEY_F11",
+	KeyF12:                     "KEY_F12",
+	KeyRO:                      "KEY_RO",
+	KeyKatakana:                "KEY_KATAKANA",
+	KeyHiragana:                "KEY_HIRAGANA",
+	KeyHenkan:                  "KEY_HENKAN",
+	KeyKatakanaHiragana:        "KEY_KATAKANAHIRAGANA",
+	KeyMuhenkan:                "KEY_MUHENKAN",
+	KeyKPJPComma:               "KEY_KPJPCOMMA",
+	KeyKPEnter:                 "KEY_KPENTER",
+	KeyRightCtrl:               "KEY_RIGHTCTRL",
+	KeyKPSlash:                 "KEY_KPSLASH",
+	KeySysRQ:                   "KEY_SYSRQ",
+	KeyRightAlt:                "KEY_RIGHTALT",
+	KeyLineFeed:                "KEY_LINEFEED",
+	KeyHome:                    "KEY_HOME",
+	KeyUp:                      "KEY_UP",
+	KeyPageUp:                  "KEY_PAGEUP",
+	KeyLeft:                    "KEY_LEFT",
+	KeyRight:                   "KEY_RIGHT",
+	KeyEnd:                     "KEY_END",
+	KeyDown:                    "KEY_DOWN",
+	KeyPageDown:                "KEY_PAGEDOWN",
+	KeyInsert:                  "KEY_INSERT",
+	KeyDelete:                  "KEY_DELETE",
+	KeyMacro:                   "KEY_MACRO",
+	KeyMute:                    "KEY_MUTE",
+	KeyVolumeDown:              "KEY_VOLUMEDOWN",
+	KeyVolumeUp:                "KEY_VOLUMEUP",
+	KeyPower:                   "KEY_POWER",
+	KeyKPEqual:                 "KEY_KPEQUAL",
+	KeyKPPlusMinus:             "KEY_KPPLUSMINUS",
+	KeyPause:                   "KEY_PAUSE",
+	KeyScale:                   "KEY_SCALE",
+	KeyKPComma:                 "KEY_KPCOMMA",
+	KeyHangeul:                 "KEY_HANGEUL",
+	KeyHanja:                   "KEY_HANJA",
+	KeyYen:                     "KEY_YEN",
+	KeyLeftMeta:                "KEY_LEFTMETA",
+	KeyRightMeta:               "KEY_RIGHTMETA",
+	KeyCompose:                 "KEY_COMPOSE",
+	KeyStop:                    "KEY_STOP",
+	KeyAgain:                   "KEY_AGAIN",
+	KeyProps:                   "KEY_PROPS",
+	KeyUndo:                    "KEY_UNDO",
+	KeyFront:                   "KEY_FRONT",
+	KeyCopy:                    "KEY_COPY",
+	KeyOpen:                    "KEY_OPEN",
+	KeyPaste:                   "KEY_PASTE",
+	KeyFind:                    "KEY_FIND",
+	KeyCut:                     "KEY_CUT",
+	KeyHelp:                    "KEY_HELP",
+	KeyMenu:                    "KEY_MENU",
+	KeyCalc:                    "KEY_CALC",
+	KeySetup:                   "KEY_SETUP",
+	KeySleep:                   "KEY_SLEEP",
+	KeyWakeup:                  "KEY_WAKEUP",
+	KeyFile:                    "KEY_FILE",
+	KeySendFile:                "KEY_SENDFILE",
+	KeyDeleteFile:              "KEY_DELETEFILE",
+	KeyXFer:                    "KEY_XFER",
+	KeyProg1:                   "KEY_PROG1",
+	KeyProg2:                   "KEY_PROG2",
+	KeyWWW:                     "KEY_WWW",
+	KeyMSDOS:                   "KEY_MSDOS",
+	KeyCoffee:                  "KEY_COFFEE",
+	KeyRotateDisplay:           "KEY_ROTATE_DISPLAY",
+	KeyCycleWindows:            "KEY_CYCLEWINDOWS",
+	KeyMail:                    "KEY_MAIL",
+	KeyBookmarks:               "KEY_BOOKMARKS",
+	KeyComputer:                "KEY_COMPUTER",
+	KeyBack:                    "KEY_BACK",
+	KeyForward:                 "KEY_FORWARD",
+	KeyCloseCD:                 "KEY_CLOSECD",
+	KeyEjectCD:                 "KEY_EJECTCD",
+	KeyEjectCloseCD:            "KEY_EJECTCLOSECD",
+	KeyNextSong:                "KEY_NEXTSONG",
+	KeyPlayPause:               "KEY_PLAYPAUSE",
+	KeyPreviousSong:            "KEY_PREVIOUSSONG",
+	KeyStopCD:                  "KEY_STOPCD",
+	KeyRecord:                  "KEY_RECORD",
+	KeyRewind:                  "KEY_REWIND",
+	KeyPhone:                   "KEY_PHONE",
+	KeyISO:                     "KEY_ISO",
+	KeyConfig:                  "KEY_CONFIG",
+	KeyHomepage:                "KEY_HOMEPAGE",
+	KeyRefresh:                 "KEY_REFRESH",
+	KeyExit:                    "KEY_EXIT",
+	KeyMove:                    "KEY_MOVE",
+	KeyEdit:                    "KEY_EDIT",
+	KeyScrollUp:                "KEY_SCROLLUP",
+	KeyScrollDown:              "KEY_SCROLLDOWN",
+	KeyKPLeftParen:             "KEY_KPLEFTPAREN",
+	KeyKPRightParen:            "KEY_KPRIGHTPAREN",
+	KeyNew:                     "KEY_NEW",
+	KeyRedo:                    "KEY_REDO",
+	KeyF13:                     "KEY_F13",
+	KeyF14:                     "KEY_F14",
+	KeyF15:                     "KEY_F15",
+	KeyF16:                     "KEY_F16",
+	KeyF17:                     "KEY_F17",
+	KeyF18:                     "KEY_F18",
+	KeyF19:                     "KEY_F19",
+	KeyF20:                     "KEY_F20",
+	KeyF21:                     "KEY_F21",
+	KeyF22:                     "KEY_F22",
+	KeyF23:                     "KEY_F23",
+	KeyF24:                     "KEY_F24",
+	KeyPlayCD:                  "KEY_PLAYCD",
+	KeyPauseCD:                 "KEY_PAUSECD",
+	KeyProg3:                   "KEY_PROG3",
+	KeyProg4:                   "KEY_PROG4",
+	KeyAllApplications:         "KEY_ALL_APPLICATIONS",
+	KeySuspend:                 "KEY_SUSPEND",
+	KeyClose:                   "KEY_CLOSE",
+	KeyPlay:                    "KEY_PLAY",
+	KeyFastForward:             "KEY_FASTFORWARD",
+	KeyBassBoost:               "KEY_BASSBOOST",
+	KeyPrint:                   "KEY_PRINT",
+	KeyHP:                      "KEY_HP",
+	KeyCamera:                  "KEY_CAMERA",
+	KeySound:                   "KEY_SOUND",
+	KeyQuestion:                "KEY_QUESTION",
+	KeyEmail:                   "KEY_EMAIL",
+	KeyChat:                    "KEY_CHAT",
+	KeySearch:                  "KEY_SEARCH",
+	KeyConnect:                 "KEY_CONNECT",
+	KeyFinance:                 "KEY_FINANCE",
+	KeySport:                   "KEY_SPORT",
+	KeyShop:                    "KEY_SHOP",
+	KeyAltErase:                "KEY_ALTERASE",
+	KeyCancel:                  "KEY_CANCEL",
+	KeyBrightnessDown:          "KEY_BRIGHTNESSDOWN",
+	KeyBrightnessUp:            "KEY_BRIGHTNESSUP",
+	KeyMedia:                   "KEY_MEDIA",
+	KeySwitchVideoMode:         "KEY_SWITCHVIDEOMODE",
+	KeyKBDIllumToggle:          "KEY_KBDILLUMTOGGLE",
+	KeyKBDIllumDown:            "KEY_KBDILLUMDOWN",
+	KeyKBDIllumUp:              "KEY_KBDILLUMUP",
+	KeySend:                    "KEY_SEND",
+	KeyReply:                   "KEY_REPLY",
+	KeyForwardMail:             "KEY_FORWARDMAIL",
+	KeySave:                    "KEY_SAVE",
+	KeyDocuments:               "KEY_DOCUMENTS",
+	KeyBattery:                 "KEY_BATTERY",
+	KeyBluetooth:               "KEY_BLUETOOTH",
+	KeyWLAN:                    "KEY_WLAN",
+	KeyUWB:                     "KEY_UWB",
+	KeyUnknown:                 "KEY_UNKNOWN",
+	KeyVideoNext:               "KEY_VIDEO_NEXT",
+	KeyVideoPrevious:           "KEY_VIDEO_PREV",
+	KeyBrightnessCycle:         "KEY_BRIGHTNESS_CYCLE",
+	KeyBrightnessAuto:          "KEY_BRIGHTNESS_AUTO",
+	KeyDisplayOff:              "KEY_DISPLAY_OFF",
+	KeyWWAN:                    "KEY_WWAN",
+	KeyRFKill:                  "KEY_RFKILL",
+	KeyMicMute:                 "KEY_MICMUTE",
+	Btn0:                       "BTN_0",
+	Btn1:                       "BTN_1",
+	Btn2:                       "BTN_2",
+	Btn3:                       "BTN_3",
+	Btn4:                       "BTN_4",
+	Btn5:                       "BTN_5",
+	Btn6:                       "BTN_6",
+	Btn7:                       "BTN_7",
+	Btn8:                       "BTN_8",
+	Btn9:                       "BTN_9",
+	BtnLeft:                    "BTN_LEFT",
+	BtnRight:                   "BTN_RIGHT",
+	BtnMiddle:                  "BTN_MIDDLE",
+	BtnSide:                    "BTN_SIDE",
+	BtnExtra:                   "BTN_EXTRA",
+	BtnForward:                 "BTN_FORWARD",
+	BtnBack:                    "BTN_BACK",
+	BtnTask:                    "BTN_TASK",
+	BtnTrigger:                 "BTN_TRIGGER",
+	BtnThumb:                   "BTN_THUMB",
+	BtnThumb2:                  "BTN_THUMB2",
+	BtnTop:                     "BTN_TOP",
+	BtnTop2:                    "BTN_TOP2",
+	BtnPinkie:                  "BTN_PINKIE",
+	BtnBase:                    "BTN_BASE",
+	BtnBase2:                   "BTN_BASE2",
+	BtnBase3:                   "BTN_BASE3",
+	BtnBase4:                   "BTN_BASE4",
+	BtnBase5:                   "BTN_BASE5",
+	BtnBase6:                   "BTN_BASE6",
+	BtnDead:                    "BTN_DEAD",
+	BtnSouth:                   "BTN_SOUTH",
+	BtnEast:                    "BTN_EAST",
+	BtnC:                       "BTN_C",
+	BtnNorth:                   "BTN_NORTH",
+	BtnWest:                    "BTN_WEST",
+	BtnZ:                       "BTN_Z",
+	BtnTL:                      "BTN_TL",
+	BtnTR:                      "BTN_TR",
+	BtnTL2:                     "BTN_TL2",
+	BtnTR2:                     "BTN_TR2",
+	BtnSelect:                  "BTN_SELECT",
+	BtnStart:                   "BTN_START",
+	BtnMode:                    "BTN_MODE",
+	BtnThumbL:                  "BTN_THUMBL",
+	BtnThumbR:                  "BTN_THUMBR",
+	BtnToolPen:                 "BTN_TOOL_PEN",
+	BtnToolRubber:              "BTN_TOOL_RUBBER",
+	BtnToolBrush:               "BTN_TOOL_BRUSH",
+	BtnToolPencil:              "BTN_TOOL_PENCIL",
+	BtnToolAirbrush:            "BTN_TOOL_AIRBRUSH",
+	BtnToolFinger:              "BTN_TOOL_FINGER",
+	BtnToolMouse:               "BTN_TOOL_MOUSE",
+	BtnToolLens:                "BTN_TOOL_LENS",
+	BtnToolQuintTap:            "BTN_TOOL_QUINTTAP",
+	BtnStylus3:                 "BTN_STYLUS3",
+	BtnTouch:                   "BTN_TOUCH",
+	BtnStylus:                  "BTN_STYLUS",
+	BtnStylus2:                 "BTN_STYLUS2",
+	BtnToolDoubleTap:           "BTN_TOOL_DOUBLETAP",
+	BtnToolTripleTap:           "BTN_TOOL_TRIPLETAP",
+	BtnToolQuadTap:             "BTN_TOOL_QUADTAP",
+	BtnGearDown:                "BTN_GEAR_DOWN",
+	BtnGearUp:                  "BTN_GEAR_UP",
+	KeyOk:                      "KEY_OK",
+	KeySelect:                  "KEY_SELECT",
+	KeyGoto:                    "KEY_GOTO",
+	KeyClear:                   "KEY_CLEAR",
+	KeyPower2:                  "KEY_POWER2",
+	KeyOption:                  "KEY_OPTION",
+	KeyInfo:                    "KEY_INFO",
+	KeyTime:                    "KEY_TIME",
+	KeyVendor:                  "KEY_VENDOR",
+	KeyArchive:                 "KEY_ARCHIVE",
+	KeyProgram:                 "KEY_PROGRAM",
+	KeyChannel:                 "KEY_CHANNEL",
+	KeyFavorites:               "KEY_FAVORITES",
+	KeyEPG:                     "KEY_EPG",
+	KeyPVR:                     "KEY_PVR",
+	KeyMHP:                     "KEY_MHP",
+	KeyLanguage:                "KEY_LANGUAGE",
+	KeyTitle:                   "KEY_TITLE",
+	KeySubtitle:                "KEY_SUBTITLE",
+	KeyAngle:                   "KEY_ANGLE",
+	KeyFullScreen:              "KEY_FULL_SCREEN",
+	KeyMode:                    "KEY_MODE",
+	KeyKeyboard:                "KEY_KEYBOARD",
+	KeyAspectRatio:             "KEY_ASPECT_RATIO",
+	KeyPC:                      "KEY_PC",
+	KeyTV:                      "KEY_TV",
+	KeyTV2:                     "KEY_TV2",
+	KeyVCR:                     "KEY_VCR",
+	KeyVCR2:                    "KEY_VCR2",
+	KeySAT:                     "KEY_SAT",
+	KeySAT2:                    "KEY_SAT2",
+	KeyCD:                      "KEY_CD",
+	KeyTape:                    "KEY_TAPE",
+	KeyRadio:                   "KEY_RADIO",
+	KeyTuner:                   "KEY_TUNER",
+	KeyPlayer:                  "KEY_PLAYER",
+	KeyText:                    "KEY_TEXT",
+	KeyDVD:                     "KEY_DVD",
+	KeyAUX:                     "KEY_AUX",
+	KeyMP3:                     "KEY_MP3",
+	KeyAudio:                   "KEY_AUDIO",
+	KeyVideo:                   "KEY_VIDEO",
+	KeyDirectory:               "KEY_DIRECTORY",
+	KeyList:                    "KEY_LIST",
+	KeyMemo:                    "KEY_MEMO",
+	KeyCalendar:                "KEY_CALENDAR",
+	KeyRed:                     "KEY_RED",
+	KeyGreen:                   "KEY_GREEN",
+	KeyYellow:                  "KEY_YELLOW",
+	KeyBlue:                    "KEY_BLUE",
+	KeyChannelUp:               "KEY_CHANNELUP",
+	KeyChannelDown:             "KEY_CHANNELDOWN",
+	KeyFirst:                   "KEY_FIRST",
+	KeyLast:                    "KEY_LAST",
+	KeyAB:                      "KEY_AB",
+	KeyNext:                    "KEY_NEXT",
+	KeyRestart:                 "KEY_RESTART",
+	KeySlow:                    "KEY_SLOW",
+	KeyShuffle:                 "KEY_SHUFFLE",
+	KeyBreak:                   "KEY_BREAK",
+	KeyPrevious:                "KEY_PREVIOUS",
+	KeyDigits:                  "KEY_DIGITS",
+	KeyTeen:                    "KEY_TEEN",
+	KeyTwen:                    "KEY_TWEN",
+	KeyVideoPhone:              "KEY_VIDEOPHONE",
+	KeyGames:                   "KEY_GAMES",
+	KeyZoomIn:                  "KEY_ZOOMIN",
+	KeyZoomOut:                 "KEY_ZOOMOUT",
+	KeyZoomReset:               "KEY_ZOOMRESET",
+	KeyWordProcessor:           "KEY_WORDPROCESSOR",
+	KeyEditor:                  "KEY_EDITOR",
+	KeySpreadsheet:             "KEY_SPREADSHEET",
+	KeyGraphicsEditor:          "KEY_GRAPHICSEDITOR",
+	KeyPresentation:            "KEY_PRESENTATION",
+	KeyDatabase:                "KEY_DATABASE",
+	KeyNews:                    "KEY_NEWS",
+	KeyVoiceMail:               "KEY_VOICEMAIL",
+	KeyAddressBook:             "KEY_ADDRESSBOOK",
+	KeyMessenger:               "KEY_MESSENGER",
+	KeyDisplayToggle:           "KEY_DISPLAYTOGGLE",
+	KeySpellCheck:              "KEY_SPELLCHECK",
+	KeyLogoff:                  "KEY_LOGOFF",
+	KeyDollar:                  "KEY_DOLLAR",
+	KeyEuro:                    "KEY_EURO",
+	KeyFrameBack:               "KEY_FRAMEBACK",
+	KeyFrameForward:            "KEY_FRAMEFORWARD",
+	KeyContextMenu:             "KEY_CONTEXT_MENU",
+	KeyMediaRepeat:             "KEY_MEDIA_REPEAT",
+	Key10ChannelsUp:            "KEY_10CHANNELSUP",
+	Key10ChannelsDown:          "KEY_10CHANNELSDOWN",
+	KeyImages:                  "KEY_IMAGES",
+	KeyNotificationCenter:      "KEY_NOTIFICATION_CENTER",
+	KeyPickupPhone:             "KEY_PICKUP_PHONE",
+	KeyHangupPhone:             "KEY_HANGUP_PHONE",
+	KeyLinkPhone:               "KEY_LINK_PHONE",
+	KeyDelEOL:                  "KEY_DEL_EOL",
+	KeyDelEOS:                  "KEY_DEL_EOS",
+	KeyInsLine:                 "KEY_INS_LINE",
+	KeyDelLine:                 "KEY_DEL_LINE",
+	KeyFN:                      "KEY_FN",
+	KeyFNEsc:                   "KEY_FN_ESC",
+	KeyFNF1:                    "KEY_FN_F1",
+	KeyFNF2:                    "KEY_FN_F2",
+	KeyFNF3:                    "KEY_FN_F3",
+	KeyFNF4:                    "KEY_FN_F4",
+	KeyFNF5:                    "KEY_FN_F5",
+	KeyFNF6:                    "KEY_FN_F6",
+	KeyFNF7:                    "KEY_FN_F7",
+	KeyFNF8:                    "KEY_FN_F8",
+	KeyFNF9:                    "KEY_FN_F9",
+	KeyFNF10:                   "KEY_FN_F10",
+	KeyFNF11:                   "KEY_FN_F11",
+	KeyFNF12:                   "KEY_FN_F12",
+	KeyFN1:                     "KEY_FN_1",
+	KeyFN2:                     "KEY_FN_2",
+	KeyFND:                     "KEY_FN_D",
+	KeyFNE:                     "KEY_FN_E",
+	KeyFNF:                     "KEY_FN_F",
+	KeyFNS:                     "KEY_FN_S",
+	KeyFNB:                     "KEY_FN_B",
+	KeyFNRightShift:            "KEY_FN_RIGHT_SHIFT",
+	KeyBRLDot1:                 "KEY_BRL_DOT1",
+	KeyBRLDot2:                 "KEY_BRL_DOT2",
+	KeyBRLDot3:                 "KEY_BRL_DOT3",
+	KeyBRLDot4:                 "KEY_BRL_DOT4",
+	KeyBRLDot5:                 "KEY_BRL_DOT5",
+	KeyBRLDot6:                 "KEY_BRL_DOT6",
+	KeyBRLDot7:                 "KEY_BRL_DOT7",
+	KeyBRLDot8:                 "KEY_BRL_DOT8",
+	KeyBRLDot9:                 "KEY_BRL_DOT9",
+	KeyBRLDot10:                "KEY_BRL_DOT10",
+	KeyNumeric0:                "KEY_NUMERIC_0",
+	KeyNumeric1:                "KEY_NUMERIC_1",
+	KeyNumeric2:                "KEY_NUMERIC_2",
+	KeyNumeric3:                "KEY_NUMERIC_3",
+	KeyNumeric4:                "KEY_NUMERIC_4",
+	KeyNumeric5:                "KEY_NUMERIC_5",
+	KeyNumeric6:                "KEY_NUMERIC_6",
+	KeyNumeric7:                "KEY_NUMERIC_7",
+	KeyNumeric8:                "KEY_NUMERIC_8",
+	KeyNumeric9:                "KEY_NUMERIC_9",
+	KeyNumericStar:             "KEY_NUMERIC_STAR",
+	KeyNumericPound:            "KEY_NUMERIC_POUND",
+	KeyNumericA:                "KEY_NUMERIC_A",
+	KeyNumericB:                "KEY_NUMERIC_B",
+	KeyNumericC:                "KEY_NUMERIC_C",
+	KeyNumericD:                "KEY_NUMERIC_D",
+	KeyCameraFocus:             "KEY_CAMERA_FOCUS",
+	KeyWPSButton:               "KEY_WPS_BUTTON",
+	KeyTouchpadToggle:          "KEY_TOUCHPAD_TOGGLE",
+	KeyTouchpadOn:              "KEY_TOUCHPAD_ON",
+	KeyTouchpadOff:             "KEY_TOUCHPAD_OFF",
+	KeyCameraZoomIn:            "KEY_CAMERA_ZOOMIN",
+	KeyCameraZoomOut:           "KEY_CAMERA_ZOOMOUT",
+	KeyCameraUp:                "KEY_CAMERA_UP",
+	KeyCameraDown:              "KEY_CAMERA_DOWN",
+	KeyCameraLeft:              "KEY_CAMERA_LEFT",
+	KeyCameraRight:             "KEY_CAMERA_RIGHT",
+	KeyAttendantOn:             "KEY_ATTENDANT_ON",
+	KeyAttendantOff:            "KEY_ATTENDANT_OFF",
+	KeyAttendantToggle:         "KEY_ATTENDANT_TOGGLE",
+	KeyLightsToggle:            "KEY_LIGHTS_TOGGLE",
+	BtnDpadUp:                  "BTN_DPAD_UP",
+	BtnDpadDown:                "BTN_DPAD_DOWN",
+	BtnDpadLeft:                "BTN_DPAD_LEFT",
+	BtnDpadRight:               "BTN_DPAD_RIGHT",
+	KeyALSToggle:               "KEY_ALS_TOGGLE",
+	KeyRotateLockToggle:        "KEY_ROTATE_LOCK_TOGGLE",
+	KeyRefreshRateToggle:       "KEY_REFRESH_RATE_TOGGLE",
+	KeyButtonConfig:            "KEY_BUTTONCONFIG",
+	KeyTaskManager:             "KEY_TASKMANAGER",
+	KeyJournal:                 "KEY_JOURNAL",
+	KeyControlPanel:            "KEY_CONTROLPANEL",
+	KeyAppSelect:               "KEY_APPSELECT",
+	KeyScreenSaver:             "KEY_SCREENSAVER",
+	KeyVoiceCommand:            "KEY_VOICECOMMAND",
+	KeyAssistant:               "KEY_ASSISTANT",
+	KeyKBDLayoutNext:           "KEY_KBD_LAYOUT_NEXT",
+	KeyEmojiPicker:             "KEY_EMOJI_PICKER",
+	KeyDictate:                 "KEY_DICTATE",
+	KeyBrightnessMin:           "KEY_BRIGHTNESS_MIN",
+	KeyBrightnessMax:           "KEY_BRIGHTNESS_MAX",
+	KeyKBDInputAssistPrev:      "KEY_KBDINPUTASSIST_PREV",
+	KeyKBDInputAssistNext:      "KEY_KBDINPUTASSIST_NEXT",
+	KeyKBDInputAssistPrevGroup: "KEY_KBDINPUTASSIST_PREVGROUP",
+	KeyKBDInputAssistNextGroup: "KEY_KBDINPUTASSIST_NEXTGROUP",
+	KeyKBDInputAssistAccept:    "KEY_KBDINPUTASSIST_ACCEPT",
+	KeyKBDInputAssistCancel:    "KEY_KBDINPUTASSIST_CANCEL",
+	KeyRightUp:                 "KEY_RIGHT_UP",
+	KeyRightDown:               "KEY_RIGHT_DOWN",
+	KeyLeftUp:                  "KEY_LEFT_UP",
+	KeyLeftDown:                "KEY_LEFT_DOWN",
+	KeyRootMenu:                "KEY_ROOT_MENU",
+	KeyMediaTopMenu:            "KEY_MEDIA_TOP_MENU",
+	KeyNumeric11:               "KEY_NUMERIC_11",
+	KeyNumeric12:               "KEY_NUMERIC_12",
+	KeyAudioDesc:               "KEY_AUDIO_DESC",
+	Key3DMode:                  "KEY_3D_MODE",
+	KeyNextFavorite:            "KEY_NEXT_FAVORITE",
+	KeyStopRecord:              "KEY_STOP_RECORD",
+	KeyPauseRecord:             "KEY_PAUSE_RECORD",
+	KeyVOD:                     "KEY_VOD",
+	KeyUnmute:                  "KEY_UNMUTE",
+	KeyFastReverse:             "KEY_FASTREVERSE",
+	KeySlowReverse:             "KEY_SLOWREVERSE",
+	KeyData:                    "KEY_DATA",
+	KeyOnScreenKeyboard:        "KEY_ONSCREEN_KEYBOARD",
+	KeyPrivacyScreenToggle:     "KEY_PRIVACY_SCREEN_TOGGLE",
+	KeySelectiveScreenshot:     "KEY_SELECTIVE_SCREENSHOT",
+	KeyNextElement:             "KEY_NEXT_ELEMENT",
+	KeyPreviousElement:         "KEY_PREVIOUS_ELEMENT",
+	KeyAutopilotEngageToggle:   "KEY_AUTOPILOT_ENGAGE_TOGGLE",
+	KeyMarkWaypoint:            "KEY_MARK_WAYPOINT",
+	KeySOS:                     "KEY_SOS",
+	KeyNavChart:                "KEY_NAV_CHART",
+	KeyFishingChart:            "KEY_FISHING_CHART",
+	KeySingleRangeRadar:        "KEY_SINGLE_RANGE_RADAR",
+	KeyDualRangeRadar:          "KEY_DUAL_RANGE_RADAR",
+	KeyRadarOverlay:            "KEY_RADAR_OVERLAY",
+	KeyTraditionalSonar:        "KEY_TRADITIONAL_SONAR",
+	KeyClearVuSonar:            "KEY_CLEARVU_SONAR",
+	KeySideVuSonar:             "KEY_SIDEVU_SONAR",
+	KeyNavInfo:                 "KEY_NAV_INFO",
+	KeyBrightnessMenu:          "KEY_BRIGHTNESS_MENU",
+	KeyMacro1:                  "KEY_MACRO1",
+	KeyMacro2:                  "KEY_MACRO2",
+	KeyMacro3:                  "KEY_MACRO3",
+	KeyMacro4:                  "KEY_MACRO4",
+	KeyMacro5:                  "KEY_MACRO5",
+	KeyMacro6:                  "KEY_MACRO6",
+	KeyMacro7:                  "KEY_MACRO7",
+	KeyMacro8:                  "KEY_MACRO8",
+	KeyMacro9:                  "KEY_MACRO9",
+	KeyMacro10:                 "KEY_MACRO10",
+	KeyMacro11:                 "KEY_MACRO11",
+	KeyMacro12:                 "KEY_MACRO12",
+	KeyMacro13:                 "KEY_MACRO13",
+	KeyMacro14:                 "KEY_MACRO14",
+	KeyMacro15:                 "KEY_MACRO15",
+	KeyMacro16:                 "KEY_MACRO16",
+	KeyMacro17:                 "KEY_MACRO17",
+	KeyMacro18:                 "KEY_MACRO18",
+	KeyMacro19:                 "KEY_MACRO19",
+	KeyMacro20:                 "KEY_MACRO20",
+	KeyMacro21:                 "KEY_MACRO21",
+	KeyMacro22:                 "KEY_MACRO22",
+	KeyMacro23:                 "KEY_MACRO23",
+	KeyMacro24:                 "KEY_MACRO24",
+	KeyMacro25:                 "KEY_MACRO25",
+	KeyMacro26:                 "KEY_MACRO26",
+	KeyMacro27:                 "KEY_MACRO27",
+	KeyMacro28:                 "KEY_MACRO28",
+	KeyMacro29:                 "KEY_MACRO29",
+	KeyMacro30:                 "KEY_MACRO30",
+	KeyMacroRecordStart:        "KEY_MACRO_RECORD_START",
+	KeyMacroRecordStop:         "KEY_MACRO_RECORD_STOP",
+	KeyMacroPresetCycle:        "KEY_MACRO_PRESET_CYCLE",
+	KeyMacroPreset1:            "KEY_MACRO_PRESET1",
+	KeyMacroPreset2:            "KEY_MACRO_PRESET2",
+	KeyMacroPreset3:            "KEY_MACRO_PRESET3",
+	KeyKBDLCDMenu1:             "KEY_KBD_LCD_MENU1",
+	KeyKBDLCDMenu2:             "KEY_KBD_LCD_MENU2",
+	KeyKBDLCDMenu3:             "KEY_KBD_LCD_MENU3",
+	KeyKBDLCDMenu4:             "KEY_KBD_LCD_MENU4",
+	KeyKBDLCDMenu5:             "KEY_KBD_LCD_MENU5",
+	BtnTriggerHappy1:           "BTN_TRIGGER_HAPPY1",
+	BtnTriggerHappy2:           "BTN_TRIGGER_HAPPY2",
+	BtnTriggerHappy3:           "BTN_TRIGGER_HAPPY3",
+	BtnTriggerHappy4:           "BTN_TRIGGER_HAPPY4",
+	BtnTriggerHappy5:           "BTN_TRIGGER_HAPPY5",
+	BtnTriggerHappy6:           "BTN_TRIGGER_HAPPY6",
+	BtnTriggerHappy7:           "BTN_TRIGGER_HAPPY7",
+	BtnTriggerHappy8:           "BTN_TRIGGER_HAPPY8",
+	BtnTriggerHappy9:           "BTN_TRIGGER_HAPPY9",
+	BtnTriggerHappy10:          "BTN_TRIGGER_HAPPY10",
+	BtnTriggerHappy11:          "BTN_TRIGGER_HAPPY11",
+	BtnTriggerHappy12:          "BTN_TRIGGER_HAPPY12",
+	BtnTriggerHappy13:          "BTN_TRIGGER_HAPPY13",
+	BtnTriggerHappy14:          "BTN_TRIGGER_HAPPY14",
+	BtnTriggerHappy15:          "BTN_TRIGGER_HAPPY15",
+	BtnTriggerHappy16:          "BTN_TRIGGER_HAPPY16",
+	BtnTriggerHappy17:          "BTN_TRIGGER_HAPPY17",
+	BtnTriggerHappy18:          "BTN_TRIGGER_HAPPY18",
+	BtnTriggerHappy19:          "BTN_TRIGGER_HAPPY19",
+	BtnTriggerHappy20:          "BTN_TRIGGER_HAPPY20",
+	BtnTriggerHappy21:          "BTN_TRIGGER_HAPPY21",
+	BtnTriggerHappy22:          "BTN_TRIGGER_HAPPY22",
+	BtnTriggerHappy23:          "BTN_TRIGGER_HAPPY23",
+	BtnTriggerHappy24:          "BTN_TRIGGER_HAPPY24",
+	BtnTriggerHappy25:          "BTN_TRIGGER_HAPPY25",
+	BtnTriggerHappy26:          "BTN_TRIGGER_HAPPY26",
+	BtnTriggerHappy27:          "BTN_TRIGGER_HAPPY27",
+	BtnTriggerHappy28:          "BTN_TRIGGER_HAPPY28",
+	BtnTriggerHappy29:          "BTN_TRIGGER_HAPPY29",
+	BtnTriggerHappy30:          "BTN_TRIGGER_HAPPY30",
+	BtnTriggerHappy31:          "BTN_TRIGGER_HAPPY31",
+	BtnTriggerHappy32:          "BTN_TRIGGER_HAPPY32",
+	BtnTriggerHappy33:          "BTN_TRIGGER_HAPPY33",
+	BtnTriggerHappy34:          "BTN_TRIGGER_HAPPY34",
+	BtnTriggerHappy35:          "BTN_TRIGGER_HAPPY35",
+	BtnTriggerHappy36:          "BTN_TRIGGER_HAPPY36",
+	BtnTriggerHappy37:          "BTN_TRIGGER_HAPPY37",
+	BtnTriggerHappy38:          "BTN_TRIGGER_HAPPY38",
+	BtnTriggerHappy39:          "BTN_TRIGGER_HAPPY39",
+	BtnTriggerHappy40:          "BTN_TRIGGER_HAPPY40",
+}
+
+// relNames holds the names of the REL_ constants.
+var relNames = map[int]string{
+	RelX:           "REL_X",
+	RelY:           "REL_Y",
+	RelZ:           "REL_Z",
+	RelRX:          "REL_RX",
+	RelRY:          "REL_RY",
+	RelRZ:          "REL_RZ",
+	RelHWheel:      "REL_HWHEEL",
+	RelDial:        "REL_DIAL",
+	RelWheel:       "REL_WHEEL",
+	RelMisc:        "REL_MISC",
+	RelReserved:    "REL_RESERVED",
+	RelWheelHiRes:  "REL_WHEEL_HI_RES",
+	RelHWheelHiRes: "REL_HWHEEL_HI_RES",
+}
+
+// absNames holds the names of the ABS_ constants.
+var absNames = map[int]string{
+	AbsX:             "ABS_X",
+	AbsY:             "ABS_Y",
+	AbsZ:             "ABS_Z",
+	AbsRX:            "ABS_RX",
+	AbsRY:            "ABS_RY",
+	AbsRZ:            "ABS_RZ",
+	AbsThrottle:      "ABS_THROTTLE",
+	AbsRudder:        "ABS_RUDDER",
+	AbsWheel:         "ABS_WHEEL",
+	AbsGas:           "ABS_GAS",
+	AbsBrake:         "ABS_BRAKE",
+	AbsHat0X:         "ABS_HAT0X",
+	AbsHat0Y:         "ABS_HAT0Y",
+	AbsHat1X:         "ABS_HAT1X",
+	AbsHat1Y:         "ABS_HAT1Y",
+	AbsHat2X:         "ABS_HAT2X",
+	AbsHat2Y:         "ABS_HAT2Y",
+	AbsHat3X:         "ABS_HAT3X",
+	AbsHat3Y:         "ABS_HAT3Y",
+	AbsPressure:      "ABS_PRESSURE",
+	AbsDistance:      "ABS_DISTANCE",
+	AbsTiltX:         "ABS_TILT_X",
+	AbsTiltY:         "ABS_TILT_Y",
+	AbsToolWidth:     "ABS_TOOL_WIDTH",
+	AbsVolume:        "ABS_VOLUME",
+	AbsProfile:       "ABS_PROFILE",
+	AbsMisc:          "ABS_MISC",
+	AbsReserved:      "ABS_RESERVED",
+	AbsMTSlot:        "ABS_MT_SLOT",
+	AbsMTTouchMajor:  "ABS_MT_TOUCH_MAJOR",
+	AbsMTTouchMinor:  "ABS_MT_TOUCH_MINOR",
+	AbsMTWidthMajor:  "ABS_MT_WIDTH_MAJOR",
+	AbsMTWidthMinor:  "ABS_MT_WIDTH_MINOR",
+	AbsMTOrientation: "ABS_MT_ORIENTATION",
+	AbsMTPositionX:   "ABS_MT_POSITION_X",
+	AbsMTPositionY:   "ABS_MT_POSITION_Y",
+	AbsMTToolType:    "ABS_MT_TOOL_TYPE",
+	AbsMTBlobId:      "ABS_MT_BLOB_ID",
+	AbsMTTrackingId:  "ABS_MT_TRACKING_ID",
+	AbsMTPressure:    "ABS_MT_PRESSURE",
+	AbsMTDistance:    "ABS_MT_DISTANCE",
+	AbsMTToolX:       "ABS_MT_TOOL_X",
+	AbsMTToolY:       "ABS_MT_TOOL_Y",
+}
+
+// swNames holds the names of the SW_ constants.
+var swNames = map[int]string{
+	SwLid:                "SW_LID",
+	SwTabletMode:         "SW_TABLET_MODE",
+	SwHeadphoneInsert:    "SW_HEADPHONE_INSERT",
+	SwRFKillAll:          "SW_RFKILL_ALL",
+	SwMicrophoneInsert:   "SW_MICROPHONE_INSERT",
+	SwDock:               "SW_DOCK",
+	SwLineoutInsert:      "SW_LINEOUT_INSERT",
+	SwJackPhysicalInsert: "SW_JACK_PHYSICAL_INSERT",
+	SwVideoOutInsert:     "SW_VIDEOOUT_INSERT",
+	SwCameraLensCover:    "SW_CAMERA_LENS_COVER",
+	SwKeypadSlide:        "SW_KEYPAD_SLIDE",
+	SwFrontProximity:     "SW_FRONT_PROXIMITY",
+	SwRotateLock:         "SW_ROTATE_LOCK",
+	SwLineInInsert:       "SW_LINEIN_INSERT",
+	SwMuteDevice:         "SW_MUTE_DEVICE",
+	SwPenInserted:        "SW_PEN_INSERTED",
+	SwMachineCover:       "SW_MACHINE_COVER",
+}
+
+// mscNames holds the names of the MSC_ constants.
+var mscNames = map[int]string{
+	MiscSerial:    "MSC_SERIAL",
+	MiscPulseLed:  "MSC_PULSELED",
+	MiscGesture:   "MSC_GESTURE",
+	MiscRaw:       "MSC_RAW",
+	MiscScan:      "MSC_SCAN",
+	MiscTimestamp: "MSC_TIMESTAMP",
+}
+
+// ledNames holds the names of the LED_ constants.
+var ledNames = map[int]string{
+	LedNumLock:    "LED_NUML",
+	LedCapsLock:   "LED_CAPSL",
+	LedScrollLock: "LED_SCROLLL",
+	LedCompose:    "LED_COMPOSE",
+	LedKana:       "LED_KANA",
+	LedSleep:      "LED_SLEEP",
+	LedSuspend:    "LED_SUSPEND",
+	LedMute:       "LED_MUTE",
+	LedMisc:       "LED_MISC",
+	LedMail:       "LED_MAIL",
+	LedCharging:   "LED_CHARGING",
+}
+
+// repNames holds the names of the REP_ constants.
+var repNames = map[int]string{
+	RepDelay:  "REP_DELAY",
+	RepPeriod: "REP_PERIOD",
+}
+
+// sndNames holds the names of the SND_ constants.
+var sndNames = map[int]string{
+	SndClick: "SND_CLICK",
+	SndBell:  "SND_BELL",
+	SndTone:  "SND_TONE",
+}
+
+// ffStatusNames holds the names of the FF_STATUS_ constants.
+var ffStatusNames = map[int]string{
+	FFStatusStopped: "FF_STATUS_STOPPED",
+	FFStatusPlaying: "FF_STATUS_PLAYING",
+}
+
+// ffNames holds the names of the FF_ constants.
+var ffNames = map[int]string{
+	FFRumble:     "FF_RUMBLE",
+	FFPeriodic:   "FF_PERIODIC",
+	FFConstant:   "FF_CONSTANT",
+	FFSpring:     "FF_SPRING",
+	FFFriction:   "FF_FRICTION",
+	FFDamper:     "FF_DAMPER",
+	FFInertia:    "FF_INERTIA",
+	FFRamp:       "FF_RAMP",
+	FFSquare:     "FF_SQUARE",
+	FFTriangle:   "FF_TRIANGLE",
+	FFSine:       "FF_SINE",
+	FFSawUp:      "FF_SAW_UP",
+	FFSawDown:    "FF_SAW_DOWN",
+	FFCustom:     "FF_CUSTOM",
+	FFGain:       "FF_GAIN",
+	FFAutoCenter: "FF_AUTOCENTER",
+}
+
+// busNames holds the names of the BUS_ constants.
+var busNames = map[int]string{
+	BusPCI:        "BUS_PCI",
+	BusISAPNP:     "BUS_ISAPNP",
+	BusUSB:        "BUS_USB",
+	BusHIL:        "BUS_HIL",
+	BusBluetooth:  "BUS_BLUETOOTH",
+	BusVirtual:    "BUS_VIRTUAL",
+	BusISA:        "BUS_ISA",
+	BusI8042:      "BUS_I8042",
+	BusXTKBD:      "BUS_XTKBD",
+	BusRS232:      "BUS_RS232",
+	BusGamePort:   "BUS_GAMEPORT",
+	BusParPort:    "BUS_PARPORT",
+	BusAmiga:      "BUS_AMIGA",
+	BusADB:        "BUS_ADB",
+	BusI2C:        "BUS_I2C",
+	BusHost:       "BUS_HOST",
+	BusGSC:        "BUS_GSC",
+	BusAtari:      "BUS_ATARI",
+	BusSPI:        "BUS_SPI",
+	BusRMI:        "BUS_RMI",
+	BusCEC:        "BUS_CEC",
+	BusIntelISHTP: "BUS_INTEL_ISHTP",
+	BusAMDSFH:     "BUS_AMD_SFH",
+}
+
+// codeAliases holds alternative names for codes, which are accepted by ParseCode.
+var codeAliases = map[string]int{
+	"SW_RADIO":              SwRadio,
+	"KEY_HANGUEL":           KeyHanguel,
+	"KEY_SCREENLOCK":        KeyScreenlock,
+	"KEY_DIRECTION":         KeyDirection,
+	"KEY_DASHBOARD":         KeyDashboard,
+	"KEY_BRIGHTNESS_ZERO":   KeyBrightnessZero,
+	"KEY_WIMAX":             KeyWIMax,
+	"BTN_MISC":              BtnMisc,
+	"BTN_MOUSE":             BtnMouse,
+	"BTN_JOYSTICK":          BtnJoystick,
+	"BTN_A":                 BtnA,
+	"BTN_GAMEPAD":           BtnGamepad,
+	"BTN_B":                 BtnB,
+	"BTN_X":                 BtnX,
+	"BTN_Y":                 BtnY,
+	"BTN_DIGI":              BtnDigi,
+	"BTN_WHEEL":             BtnWheel,
+	"KEY_ZOOM":              KeyZoom,
+	"KEY_SCREEN":            KeyScreen,
+	"KEY_BRIGHTNESS_TOGGLE": KeyBrightnessToggle,
+	"BTN_TRIGGER_HAPPY":     BtnTriggerHappy,
+}